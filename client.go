@@ -0,0 +1,222 @@
+package reach
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// ClientConfig configures a Client.
+type ClientConfig struct {
+	// Address of the ERB port of the Reach, on the form host:port.
+	Address string
+	// DialTimeout is the timeout for establishing a connection. Defaults to 5s.
+	DialTimeout time.Duration
+	// ReadTimeout is the maximum time to wait for the next message before the stream is considered stalled and the
+	// connection is re-established. Defaults to 5s.
+	ReadTimeout time.Duration
+	// MinBackoff is the initial time to wait before reconnecting. Defaults to 100ms.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum time to wait before reconnecting. Defaults to 10s.
+	MaxBackoff time.Duration
+	// OnStateChange is an optional callback invoked when the connection state changes.
+	//
+	// When the state changes to disconnected, err is the error that caused the disconnect.
+	OnStateChange func(state ConnectionState, err error)
+	// Dial is an optional function for dialing the Reach. Defaults to dialing TCP.
+	Dial func(ctx context.Context, address string) (net.Conn, error)
+	// ScannerConfig configures the ERB scanner of each connection.
+	ScannerConfig erb.ScannerConfig
+}
+
+// Client is a client for the ERB port of a Reach, that automatically reconnects when the connection is lost.
+type Client struct {
+	cfg       ClientConfig
+	conn      net.Conn
+	sc        *erb.Scanner
+	state     ConnectionState
+	backoff   time.Duration
+	err       error
+	watchCtx  context.Context
+	stopWatch chan struct{}
+}
+
+// NewClient creates a new Client with the provided config.
+func NewClient(cfg ClientConfig) *Client {
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = 5 * time.Second
+	}
+	if cfg.ReadTimeout <= 0 {
+		cfg.ReadTimeout = 5 * time.Second
+	}
+	if cfg.MinBackoff <= 0 {
+		cfg.MinBackoff = 100 * time.Millisecond
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 10 * time.Second
+	}
+	if cfg.MaxBackoff < cfg.MinBackoff {
+		cfg.MaxBackoff = cfg.MinBackoff
+	}
+	if cfg.Dial == nil {
+		cfg.Dial = func(ctx context.Context, address string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "tcp", address)
+		}
+	}
+	return &Client{cfg: cfg}
+}
+
+// Scan advances the client to the next message, which will then be available through the Scanner method.
+//
+// Scan connects and reconnects to the Reach as needed, and only returns false when the context is canceled.
+func (c *Client) Scan(ctx context.Context) bool {
+	for {
+		if err := ctx.Err(); err != nil {
+			c.disconnect(err)
+			c.err = err
+			return false
+		}
+		if c.conn == nil {
+			if err := c.connect(ctx); err != nil {
+				c.setState(ConnectionStateDisconnected, err)
+				c.sleepBackoff(ctx)
+				continue
+			}
+		}
+		c.watch(ctx)
+		if err := c.conn.SetReadDeadline(time.Now().Add(c.cfg.ReadTimeout)); err != nil {
+			c.disconnect(fmt.Errorf("reach client: set read deadline: %w", err))
+			c.sleepBackoff(ctx)
+			continue
+		}
+		if c.sc.Scan() {
+			c.backoff = 0
+			return true
+		}
+		if ctx.Err() != nil {
+			continue
+		}
+		err := c.sc.Err()
+		if err == nil {
+			err = io.EOF
+		}
+		c.disconnect(fmt.Errorf("reach client: scan: %w", err))
+		c.sleepBackoff(ctx)
+	}
+}
+
+// Scanner returns the ERB scanner of the current connection, for accessing the current message.
+//
+// The returned scanner is only valid until the next call to Scan.
+func (c *Client) Scanner() *erb.Scanner {
+	return c.sc
+}
+
+// State returns the current connection state.
+func (c *Client) State() ConnectionState {
+	return c.state
+}
+
+// Err returns the error that caused Scan to return false.
+func (c *Client) Err() error {
+	return c.err
+}
+
+// Close the client's connection.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	c.stopWatching()
+	err := c.conn.Close()
+	c.conn = nil
+	c.sc = nil
+	c.setState(ConnectionStateDisconnected, nil)
+	if err != nil {
+		return fmt.Errorf("reach client: close: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) connect(ctx context.Context) error {
+	c.setState(ConnectionStateConnecting, nil)
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.DialTimeout)
+	defer cancel()
+	conn, err := c.cfg.Dial(ctx, c.cfg.Address)
+	if err != nil {
+		return fmt.Errorf("reach client: connect: %w", err)
+	}
+	c.conn = conn
+	c.sc = erb.NewScannerWithConfig(conn, c.cfg.ScannerConfig)
+	c.setState(ConnectionStateConnected, nil)
+	return nil
+}
+
+func (c *Client) disconnect(err error) {
+	if c.conn == nil {
+		return
+	}
+	c.stopWatching()
+	_ = c.conn.Close()
+	c.conn = nil
+	c.setState(ConnectionStateDisconnected, err)
+}
+
+// watch the context and close the connection when the context is canceled, to interrupt blocking reads.
+func (c *Client) watch(ctx context.Context) {
+	if c.watchCtx == ctx {
+		return
+	}
+	c.stopWatching()
+	stop := make(chan struct{})
+	conn := c.conn
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-stop:
+		}
+	}()
+	c.watchCtx = ctx
+	c.stopWatch = stop
+}
+
+func (c *Client) stopWatching() {
+	if c.stopWatch != nil {
+		close(c.stopWatch)
+	}
+	c.watchCtx = nil
+	c.stopWatch = nil
+}
+
+func (c *Client) sleepBackoff(ctx context.Context) {
+	if c.backoff == 0 {
+		c.backoff = c.cfg.MinBackoff
+	} else {
+		c.backoff *= 2
+		if c.backoff > c.cfg.MaxBackoff {
+			c.backoff = c.cfg.MaxBackoff
+		}
+	}
+	timer := time.NewTimer(c.backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func (c *Client) setState(state ConnectionState, err error) {
+	if c.state == state {
+		return
+	}
+	c.state = state
+	if c.cfg.OnStateChange != nil {
+		c.cfg.OnStateChange(state, err)
+	}
+}
@@ -0,0 +1,119 @@
+package reach
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"gotest.tools/v3/assert"
+)
+
+func TestClient_Reconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sim := newTestSimulator()
+	// serve 2 epochs on each connection, then close the connection
+	address := serveTest(ctx, t, func(conn net.Conn) {
+		_ = sim.WriteEpochs(conn, 2)
+	})
+	var mu sync.Mutex
+	var states []ConnectionState
+	client := NewClient(ClientConfig{
+		Address:    address,
+		MinBackoff: time.Millisecond,
+		OnStateChange: func(state ConnectionState, _ error) {
+			mu.Lock()
+			defer mu.Unlock()
+			states = append(states, state)
+		},
+	})
+	var numVER int
+	for numVER < 2 && client.Scan(ctx) {
+		if client.Scanner().ID() == erb.IDVER {
+			numVER++
+		}
+	}
+	assert.NilError(t, client.Err())
+	assert.Equal(t, 2, numVER)
+	assert.NilError(t, client.Close())
+	mu.Lock()
+	defer mu.Unlock()
+	assert.DeepEqual(t, []ConnectionState{
+		ConnectionStateConnecting,
+		ConnectionStateConnected,
+		ConnectionStateDisconnected,
+		ConnectionStateConnecting,
+		ConnectionStateConnected,
+		ConnectionStateDisconnected,
+	}, states)
+}
+
+func TestClient_ReadTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// never send anything on the connection
+	address := serveTest(ctx, t, func(net.Conn) {
+		<-ctx.Done()
+	})
+	errs := make(chan error, 1)
+	client := NewClient(ClientConfig{
+		Address:     address,
+		ReadTimeout: 10 * time.Millisecond,
+		OnStateChange: func(state ConnectionState, err error) {
+			if state == ConnectionStateDisconnected {
+				errs <- err
+				cancel()
+			}
+		},
+	})
+	assert.Assert(t, !client.Scan(ctx))
+	assert.Equal(t, context.Canceled, client.Err())
+	err := <-errs
+	var netErr net.Error
+	assert.Assert(t, errors.As(err, &netErr), "expected network error, got %v", err)
+	assert.Assert(t, netErr.Timeout())
+}
+
+func TestClient_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	address := serveTest(ctx, t, func(net.Conn) {
+		<-ctx.Done()
+	})
+	client := NewClient(ClientConfig{Address: address, ReadTimeout: time.Hour})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	assert.Assert(t, !client.Scan(ctx))
+	assert.Equal(t, context.Canceled, client.Err())
+	assert.Equal(t, ConnectionStateDisconnected, client.State())
+}
+
+func serveTest(ctx context.Context, t *testing.T, handler func(net.Conn)) string {
+	t.Helper()
+	lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", "localhost:0")
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		_ = lis.Close()
+	})
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() {
+					_ = conn.Close()
+				}()
+				handler(conn)
+			}()
+		}
+	}()
+	return lis.Addr().String()
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"time"
+
+	"go.einride.tech/reach"
+	"go.einride.tech/reach/ntrip"
+	"go.einride.tech/reach/rtcm3"
+)
+
+func runCaster(ctx context.Context, args []string, _, stderr io.Writer) error {
+	fs := newFlagSet("caster", "<endpoint>", stderr)
+	listen := fs.String("listen", ":2101", "TCP `address` to serve the caster on")
+	mountpoint := fs.String("mountpoint", "REACH", "`mountpoint` of the correction stream")
+	identifier := fs.String("identifier", "", "source `identifier` of the stream in the sourcetable")
+	country := fs.String("country", "", "ISO 3166 `country` code of the stream in the sourcetable")
+	users := userList{}
+	fs.Var(users, "user", "`username:password` of a user allowed to connect, can be repeated (default no authentication)")
+	erbEndpoint := fs.String("erb", "", "ERB `endpoint` of the base, for publishing its position in the sourcetable")
+	quiet := fs.Bool("q", false, "don't log connection state changes and clients")
+	args, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	logger := log.New(stderr, "", log.LstdFlags)
+	if *quiet {
+		logger.SetOutput(ioutil.Discard)
+	}
+	address, dial, err := parseEndpoint(args[0])
+	if err != nil {
+		return &usageError{err: err}
+	}
+	if dial == nil {
+		dial = func(ctx context.Context, address string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "tcp", address)
+		}
+	}
+	caster := ntrip.NewCaster(ntrip.CasterConfig{
+		Users: users,
+		OnEvent: func(event ntrip.Event) {
+			if event.Err != nil {
+				logger.Printf("%v %s %v: %v", event.Type, event.Mountpoint, event.RemoteAddr, event.Err)
+				return
+			}
+			logger.Printf("%v %s %v", event.Type, event.Mountpoint, event.RemoteAddr)
+		},
+	})
+	stream := caster.AddStream(ntrip.StreamEntry{
+		Mountpoint: *mountpoint,
+		Identifier: *identifier,
+		Format:     "RTCM 3",
+		Country:    *country,
+		Generator:  "Emlid Reach",
+	})
+	var lc net.ListenConfig
+	lis, err := lc.Listen(ctx, "tcp", *listen)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var serveErr error
+	serveDone := make(chan struct{})
+	go func() {
+		defer close(serveDone)
+		if err := caster.Serve(ctx, lis); err != nil {
+			serveErr = err
+			cancel()
+		}
+	}()
+	logger.Printf("serving caster on %v", lis.Addr())
+	if *erbEndpoint != "" {
+		cfg, err := newClientConfig(*erbEndpoint, logger)
+		if err != nil {
+			cancel()
+			<-serveDone
+			return err
+		}
+		erbDone := make(chan struct{})
+		defer func() {
+			cancel()
+			<-erbDone
+		}()
+		go func() {
+			defer close(erbDone)
+			erbClient := reach.NewClient(cfg)
+			defer func() {
+				_ = erbClient.Close()
+			}()
+			for erbClient.Scan(ctx) {
+				stream.Update(erbClient.Scanner())
+			}
+		}()
+	}
+	for ctx.Err() == nil {
+		if err := forwardCorrections(ctx, dial, address, stream); err != nil && ctx.Err() == nil {
+			logger.Printf("corrections: %v", err)
+		}
+		timer := time.NewTimer(correctionRetryInterval)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+	<-serveDone
+	return serveErr
+}
+
+// correctionReadTimeout is the maximum time to wait for a frame from a correction source, before reconnecting.
+const correctionReadTimeout = 10 * time.Second
+
+// forwardCorrections forwards the RTCM 3 frames of a correction source to a caster stream, frame by frame.
+func forwardCorrections(
+	ctx context.Context,
+	dial func(context.Context, string) (net.Conn, error),
+	address string,
+	stream *ntrip.Stream,
+) error {
+	dialCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	conn, err := dial(dialCtx, address)
+	cancel()
+	if err != nil {
+		return err
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		}
+		_ = conn.Close()
+	}()
+	sc := rtcm3.NewScannerWithConfig(conn, rtcm3.ScannerConfig{Resync: true})
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(correctionReadTimeout)); err != nil {
+			return fmt.Errorf("set read deadline: %w", err)
+		}
+		if !sc.Scan() {
+			break
+		}
+		if _, err := stream.Write(sc.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("source closed")
+}
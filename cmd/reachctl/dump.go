@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"go.einride.tech/reach/erb"
+)
+
+func runDump(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("dump", "<file>", stderr)
+	ids := idFilter{}
+	fs.Var(ids, "id", "comma-separated `IDs` of messages to print, for example POS,STAT (default all)")
+	output := outputFormatText
+	fs.Var(&output, "output", outputFlagUsage)
+	raw := fs.Bool("raw", false, "print recorded data between packets, in text output")
+	args, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := validateOutput(output, ids); err != nil {
+		return err
+	}
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	rr := erb.NewRecordReader(f)
+	w := newMessageWriter(output, stdout)
+	var r bytes.Reader
+	for ctx.Err() == nil {
+		record, err := rr.ReadRecord()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+		r.Reset(record.Data)
+		sc := erb.NewScanner(&r)
+		if !sc.Scan() {
+			if *raw && output == outputFormatText {
+				if _, err := fmt.Fprintf(
+					stdout, "%s raw: %s\n", formatReceiveTime(record.Time), hex.EncodeToString(record.Data),
+				); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !ids.Match(sc.ID()) {
+			continue
+		}
+		if err := w.WriteMessage(record.Time, sc); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
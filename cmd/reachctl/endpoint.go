@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"strings"
+
+	"go.einride.tech/reach"
+	"go.einride.tech/reach/serial"
+)
+
+// parseEndpoint parses a Reach endpoint into an address and an optional dial function.
+func parseEndpoint(endpoint string) (string, func(context.Context, string) (net.Conn, error), error) {
+	switch {
+	case strings.HasPrefix(endpoint, "serial://"):
+		cfg, err := serial.ParseURL(endpoint)
+		if err != nil {
+			return "", nil, err
+		}
+		return cfg.Name, func(context.Context, string) (net.Conn, error) {
+			port, err := serial.Open(cfg)
+			if err != nil {
+				return nil, err
+			}
+			return port, nil
+		}, nil
+	case strings.HasPrefix(endpoint, "tcp://"):
+		return strings.TrimPrefix(endpoint, "tcp://"), nil, nil
+	default:
+		return endpoint, nil, nil
+	}
+}
+
+// newClientConfig returns a client config for the provided endpoint, which logs connection state changes to logger.
+func newClientConfig(endpoint string, logger *log.Logger) (reach.ClientConfig, error) {
+	address, dial, err := parseEndpoint(endpoint)
+	if err != nil {
+		return reach.ClientConfig{}, &usageError{err: err}
+	}
+	return reach.ClientConfig{
+		Address: address,
+		Dial:    dial,
+		OnStateChange: func(state reach.ConnectionState, err error) {
+			if err != nil {
+				logger.Printf("%v: %v", state, err)
+				return
+			}
+			logger.Printf("%v", state)
+		},
+	}, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.einride.tech/reach/erb"
+	"go.einride.tech/reach/track"
+)
+
+func runExport(_ context.Context, args []string, _, stderr io.Writer) error {
+	fs := newFlagSet("export", "<file> <output>", stderr)
+	format := fs.String("format", "", "output `format`: gpx or kml (default from the output file extension)")
+	name := fs.String("name", "", "track `name` (default the name of the input file)")
+	raw := fs.Bool("raw", false, "read a raw ERB stream instead of a recording")
+	args, err := parseFlags(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	input, output := args[0], args[1]
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(output)), ".")
+	}
+	var write func(io.Writer, *track.Track) error
+	switch *format {
+	case "gpx":
+		write = track.WriteGPX
+	case "kml":
+		write = track.WriteKML
+	default:
+		return &usageError{err: fmt.Errorf("unknown export format %q (expected gpx or kml)", *format)}
+	}
+	if *name == "" {
+		*name = strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+	}
+	in, err := os.Open(input)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+	var r io.Reader = in
+	if !*raw {
+		r = erb.NewReplayer(in, erb.ReplayerConfig{})
+	}
+	points, err := track.Read(erb.NewScanner(r))
+	if err != nil {
+		return fmt.Errorf("%s: %w", input, err)
+	}
+	out, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(out)
+	if err := write(w, &track.Track{Name: *name, Points: points}); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.einride.tech/reach/erb"
+)
+
+// knownIDs are the known ERB message IDs.
+var knownIDs = []erb.ID{erb.IDVER, erb.IDPOS, erb.IDSTAT, erb.IDDOPS, erb.IDVEL, erb.IDSVI}
+
+// idFilter is a flag value for filtering messages by ID.
+type idFilter map[erb.ID]bool
+
+var _ flag.Value = idFilter{}
+
+// String implements flag.Value.
+func (f idFilter) String() string {
+	ids := make([]string, 0, len(f))
+	for _, id := range knownIDs {
+		if f[id] {
+			ids = append(ids, id.String())
+		}
+	}
+	return strings.Join(ids, ",")
+}
+
+// Set implements flag.Value.
+func (f idFilter) Set(s string) error {
+	for _, name := range strings.Split(s, ",") {
+		id, err := parseID(strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		f[id] = true
+	}
+	return nil
+}
+
+// Match returns true if the filter matches the message ID.
+//
+// An empty filter matches all messages.
+func (f idFilter) Match(id erb.ID) bool {
+	return len(f) == 0 || f[id]
+}
+
+// parseID parses a message ID from a message name, such as POS, or a numeric message ID.
+func parseID(s string) (erb.ID, error) {
+	for _, id := range knownIDs {
+		if strings.EqualFold(s, id.String()) {
+			return id, nil
+		}
+	}
+	if n, err := strconv.ParseUint(s, 0, 8); err == nil {
+		return erb.ID(n), nil
+	}
+	return 0, fmt.Errorf("unknown message ID %q", s)
+}
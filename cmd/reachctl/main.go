@@ -1,51 +1,142 @@
+// Command reachctl is a command line tool for Emlid Reach receivers.
 package main
 
 import (
-	"encoding/hex"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"net"
+	"io"
 	"os"
+	"os/signal"
+)
 
-	"go.einride.tech/reach/erb"
+// Exit codes.
+const (
+	exitCodeOK    = 0
+	exitCodeError = 1
+	exitCodeUsage = 2
 )
 
+const usage = `usage: reachctl <command> [flags] <args>
+
+commands:
+  tail           print messages from a Reach
+  record         record messages from a Reach to a file
+  replay         serve a recording to TCP clients
+  stats          print packet rates, checksum failures and fix types of a Reach
+  dump           print the messages of a recording
+  export         export a recording as a GPX or KML track
+  serve-metrics  serve Prometheus metrics of a Reach over HTTP
+  ntrip          forward corrections from an NTRIP caster to a Reach
+  caster         serve the corrections of a Reach base to NTRIP clients
+
+endpoints:
+  host:port
+  tcp://host:port
+  serial:///dev/ttyUSB0?baud=115200
+
+Run 'reachctl <command> -h' for the flags of a command.
+`
+
+// command is a reachctl subcommand.
+type command struct {
+	name string
+	run  func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+}
+
+var commands = []command{
+	{name: "tail", run: runTail},
+	{name: "record", run: runRecord},
+	{name: "replay", run: runReplay},
+	{name: "stats", run: runStats},
+	{name: "dump", run: runDump},
+	{name: "export", run: runExport},
+	{name: "serve-metrics", run: runServeMetrics},
+	{name: "ntrip", run: runNTRIP},
+	{name: "caster", run: runCaster},
+}
+
+// usageError is an error caused by invalid usage of the command line.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("usage: reachctl <host:port>")
-		os.Exit(1)
-	}
-	conn, err := net.Dial("tcp", os.Args[1])
-	if err != nil {
-		panic(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt)
+		<-sigCh
+		cancel()
+	}()
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	cancel()
+	os.Exit(code)
+}
+
+// run the command line and return the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+		return exitCodeUsage
 	}
-	defer func() {
-		if err := conn.Close(); err != nil {
-			panic(err)
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
 		}
-	}()
-	sc := erb.NewScanner(conn)
-	for sc.Scan() {
-		switch sc.ID() {
-		case erb.IDVER:
-			fmt.Printf("%v: %+v\n", sc.ID(), sc.VER())
-		case erb.IDPOS:
-			fmt.Printf("%v: %+v\n", sc.ID(), sc.POS())
-		case erb.IDSTAT:
-			fmt.Printf("%v: %+v\n", sc.ID(), sc.STAT())
-		case erb.IDDOPS:
-			fmt.Printf("%v: %+v\n", sc.ID(), sc.DOPS())
-		case erb.IDVEL:
-			fmt.Printf("%v: %+v\n", sc.ID(), sc.VEL())
-		case erb.IDSVI:
-			fmt.Printf("%v: %+v\n", sc.ID(), sc.SVI())
-			for sc.ScanSVI() {
-				fmt.Printf("%v: %+v\n", sc.ID(), sc.SV())
-			}
+		err := cmd.run(ctx, args[1:], stdout, stderr)
+		var errUsage *usageError
+		switch {
+		case err == nil, errors.Is(err, flag.ErrHelp):
+			return exitCodeOK
+		case errors.As(err, &errUsage):
+			_, _ = fmt.Fprintf(stderr, "reachctl %s: %v\n", cmd.name, err)
+			return exitCodeUsage
 		default:
-			fmt.Printf("%v: %s\n", sc.ID(), hex.EncodeToString(sc.Bytes()))
+			_, _ = fmt.Fprintf(stderr, "reachctl %s: %v\n", cmd.name, err)
+			return exitCodeError
+		}
+	}
+	switch args[0] {
+	case "-h", "-help", "--help", "help":
+		_, _ = fmt.Fprint(stdout, usage)
+		return exitCodeOK
+	}
+	_, _ = fmt.Fprintf(stderr, "reachctl: unknown command %q\n\n%s", args[0], usage)
+	return exitCodeUsage
+}
+
+// newFlagSet returns a new flag set for a command, with usage printed to stderr.
+func newFlagSet(name, argsUsage string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "usage: reachctl %s [flags] %s\n\nflags:\n", name, argsUsage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses the flags of a command and returns the expected number of positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string, nArgs int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, err
 		}
+		return nil, &usageError{err: err}
 	}
-	if sc.Err() != nil {
-		panic(sc.Err())
+	if fs.NArg() != nArgs {
+		fs.Usage()
+		return nil, &usageError{err: fmt.Errorf("expected %d argument(s) but got %d", nArgs, fs.NArg())}
 	}
+	return fs.Args(), nil
 }
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"go.einride.tech/reach/erbsim"
+	"go.einride.tech/reach/ntrip"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestRun_Usage(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{name: "no command", args: nil, expected: exitCodeUsage},
+		{name: "unknown command", args: []string{"foo"}, expected: exitCodeUsage},
+		{name: "help", args: []string{"help"}, expected: exitCodeOK},
+		{name: "command help", args: []string{"tail", "-h"}, expected: exitCodeOK},
+		{name: "missing argument", args: []string{"tail"}, expected: exitCodeUsage},
+		{name: "unknown flag", args: []string{"stats", "-foo", "localhost:9001"}, expected: exitCodeUsage},
+		{name: "unknown ID", args: []string{"tail", "-id", "FOO", "localhost:9001"}, expected: exitCodeUsage},
+		{name: "invalid endpoint", args: []string{"tail", "serial://foo"}, expected: exitCodeUsage},
+		{name: "invalid seek", args: []string{"replay", "-seek", "2059", "file.erb"}, expected: exitCodeUsage},
+		{
+			name:     "invalid NTRIP version",
+			args:     []string{"ntrip", "-version", "3", "localhost:2101", "YARD", "localhost:9002"},
+			expected: exitCodeUsage,
+		},
+		{name: "invalid user", args: []string{"caster", "-user", "rover", "localhost:9002"}, expected: exitCodeUsage},
+		{name: "missing file", args: []string{"dump", "testdata/missing.erb"}, expected: exitCodeError},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			assert.Equal(t, tt.expected, run(context.Background(), tt.args, &stdout, &stderr), stderr.String())
+		})
+	}
+}
+
+func TestRun_RecordDump(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := newTestServer(ctx, t)
+	dir, err := ioutil.TempDir("", "reachctl")
+	assert.NilError(t, err)
+	defer func() {
+		assert.NilError(t, os.RemoveAll(dir))
+	}()
+	filename := filepath.Join(dir, "recording.erb")
+	var stdout, stderr bytes.Buffer
+	code := run(ctx, []string{"record", "-duration", "500ms", server.Addr().String(), filename}, &stdout, &stderr)
+	assert.Equal(t, exitCodeOK, code, stderr.String())
+	stdout.Reset()
+	stderr.Reset()
+	code = run(ctx, []string{"dump", "-id", "POS,STAT", filename}, &stdout, &stderr)
+	assert.Equal(t, exitCodeOK, code, stderr.String())
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	assert.Assert(t, len(lines) >= 2, stdout.String())
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		_, err := time.Parse(time.RFC3339Nano, fields[0])
+		assert.NilError(t, err)
+		assert.Assert(t, fields[1] == "POS:" || fields[1] == "STAT:", line)
+	}
+}
+
+func TestRun_Stats(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := newTestServer(ctx, t)
+	var stdout, stderr bytes.Buffer
+	args := []string{"stats", "-q", "-duration", "500ms", "-interval", "1h", server.Addr().String()}
+	code := run(ctx, args, &stdout, &stderr)
+	assert.Equal(t, exitCodeOK, code, stderr.String())
+	assert.Assert(t, strings.Contains(stdout.String(), "POS "), stdout.String())
+	assert.Assert(t, strings.Contains(stdout.String(), "checksum failures:  0"), stdout.String())
+	assert.Assert(t, strings.Contains(stdout.String(), "fix type RTK:"), stdout.String())
+}
+
+func TestRun_ServeMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := newTestServer(ctx, t)
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.NilError(t, err)
+	address := lis.Addr().String()
+	assert.NilError(t, lis.Close())
+	var stdout, stderr bytes.Buffer
+	done := make(chan int)
+	go func() {
+		args := []string{"serve-metrics", "-q", "-listen", address, "-receiver", "rover", server.Addr().String()}
+		done <- run(ctx, args, &stdout, &stderr)
+	}()
+	var body string
+	for i := 0; i < 100 && !strings.Contains(body, "reach_fix_type"); i++ {
+		time.Sleep(20 * time.Millisecond)
+		resp, err := http.Get("http://" + address + "/metrics")
+		if err != nil {
+			continue
+		}
+		data, err := ioutil.ReadAll(resp.Body)
+		assert.NilError(t, err)
+		assert.NilError(t, resp.Body.Close())
+		body = string(data)
+	}
+	assert.Assert(t, strings.Contains(body, `reach_fix_type{receiver="rover"} 3`), body)
+	assert.Assert(t, strings.Contains(body, `reach_packets_total{receiver="rover",id="POS"}`), body)
+	cancel()
+	assert.Equal(t, exitCodeOK, <-done, stderr.String())
+}
+
+func TestRun_NTRIP(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := newTestServer(ctx, t)
+	caster, err := net.Listen("tcp", "localhost:0")
+	assert.NilError(t, err)
+	defer func() {
+		_ = caster.Close()
+	}()
+	corrections, err := net.Listen("tcp", "localhost:0")
+	assert.NilError(t, err)
+	defer func() {
+		_ = corrections.Close()
+	}()
+	gga := make(chan string, 1)
+	go func() {
+		conn, err := caster.Accept()
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		br := bufio.NewReader(conn)
+		if _, err := http.ReadRequest(br); err != nil {
+			return
+		}
+		_, _ = io.WriteString(conn, "ICY 200 OK\r\n")
+		// wait for a position from the Reach before sending corrections
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return
+		}
+		gga <- line
+		_, _ = io.WriteString(conn, "corrections")
+		_, _ = io.Copy(ioutil.Discard, conn)
+	}()
+	var stdout, stderr bytes.Buffer
+	done := make(chan int)
+	go func() {
+		args := []string{
+			"ntrip", "-q", "-version", "1", "-erb", server.Addr().String(),
+			caster.Addr().String(), "YARD", corrections.Addr().String(),
+		}
+		done <- run(ctx, args, &stdout, &stderr)
+	}()
+	conn, err := corrections.Accept()
+	assert.NilError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	data := make([]byte, len("corrections"))
+	_, err = io.ReadFull(conn, data)
+	assert.NilError(t, err)
+	assert.Equal(t, "corrections", string(data))
+	assert.Assert(t, strings.HasPrefix(<-gga, "$GPGGA,"))
+	cancel()
+	assert.Equal(t, exitCodeOK, <-done, stderr.String())
+}
+
+func TestRun_Caster(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := newTestServer(ctx, t)
+	frame, err := hex.DecodeString("d300133ed7d30202980edeef34b4bd62ac0941986f33360b98")
+	assert.NilError(t, err)
+	source, err := net.Listen("tcp", "localhost:0")
+	assert.NilError(t, err)
+	defer func() {
+		_ = source.Close()
+	}()
+	go func() {
+		conn, err := source.Accept()
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		for ctx.Err() == nil {
+			if _, err := conn.Write(frame); err != nil {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.NilError(t, err)
+	address := lis.Addr().String()
+	assert.NilError(t, lis.Close())
+	var stdout, stderr bytes.Buffer
+	done := make(chan int)
+	go func() {
+		args := []string{
+			"caster", "-q", "-listen", address, "-mountpoint", "YARD", "-user", "rover:secret",
+			"-erb", server.Addr().String(), source.Addr().String(),
+		}
+		done <- run(ctx, args, &stdout, &stderr)
+	}()
+	client := ntrip.NewClient(ntrip.ClientConfig{
+		Address:    address,
+		Mountpoint: "YARD",
+		Username:   "rover",
+		Password:   "secret",
+		MinBackoff: 20 * time.Millisecond,
+	})
+	var sourcetable ntrip.Sourcetable
+	for i := 0; i < 100; i++ {
+		sourcetable, err = client.FetchSourcetable(ctx)
+		if err == nil && len(sourcetable.Streams) == 1 && sourcetable.Streams[0].LatitudeDegrees != 0 {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	assert.NilError(t, err)
+	assert.Equal(t, "YARD", sourcetable.Streams[0].Mountpoint)
+	assert.Equal(t, 57.7, sourcetable.Streams[0].LatitudeDegrees)
+	assert.Equal(t, "B", sourcetable.Streams[0].Authentication)
+	clientCtx, cancelClient := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelClient()
+	w := &frameWriter{expected: len(frame), cancel: cancelClient}
+	_ = client.Run(clientCtx, w)
+	assert.Assert(t, bytes.HasPrefix(w.buf.Bytes(), frame))
+	cancel()
+	assert.Equal(t, exitCodeOK, <-done, stderr.String())
+}
+
+// frameWriter is a writer that cancels a context when the expected number of bytes has been written.
+type frameWriter struct {
+	buf      bytes.Buffer
+	expected int
+	cancel   context.CancelFunc
+}
+
+func (w *frameWriter) Write(p []byte) (int, error) {
+	n, err := w.buf.Write(p)
+	if w.buf.Len() >= w.expected {
+		w.cancel()
+	}
+	return n, err
+}
+
+func TestRun_DumpOutput(t *testing.T) {
+	filename := writeTestRecording(t)
+	defer func() {
+		assert.NilError(t, os.Remove(filename))
+	}()
+	for _, tt := range []struct {
+		name       string
+		args       []string
+		goldenFile string
+	}{
+		{name: "text", args: []string{"-output", "text"}, goldenFile: "dump.txt.golden"},
+		{name: "json", args: []string{"-output", "json"}, goldenFile: "dump.json.golden"},
+		{name: "csv POS", args: []string{"-output", "csv", "-id", "POS"}, goldenFile: "dump.pos.csv.golden"},
+		{name: "csv SVI", args: []string{"-output", "csv", "-id", "SVI"}, goldenFile: "dump.svi.csv.golden"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append(append([]string{"dump"}, tt.args...), filename)
+			assert.Equal(t, exitCodeOK, run(context.Background(), args, &stdout, &stderr), stderr.String())
+			golden.Assert(t, stdout.String(), tt.goldenFile)
+		})
+	}
+	t.Run("csv without ID", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"dump", "-output", "csv", filename}, &stdout, &stderr)
+		assert.Equal(t, exitCodeUsage, code)
+	})
+}
+
+func TestCSVMessageWriter_SVIWithoutSVs(t *testing.T) {
+	var data bytes.Buffer
+	enc := erb.NewEncoder(&data)
+	assert.NilError(t, enc.EncodeSVI(erb.SVI{TimeGPS: 1000}, nil))
+	assert.NilError(t, enc.EncodeSVI(erb.SVI{TimeGPS: 1200, NumSVs: 1}, []erb.SV{{ID: 12}}))
+	var output bytes.Buffer
+	w := newMessageWriter(outputFormatCSV, &output)
+	sc := erb.NewScanner(&data)
+	for sc.Scan() {
+		assert.NilError(t, w.WriteMessage(time.Time{}, sc))
+	}
+	assert.NilError(t, sc.Err())
+	assert.NilError(t, w.Flush())
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Equal(t, 3, len(lines), output.String())
+	assert.Assert(t, strings.HasPrefix(lines[1], "1000,0,,"), lines[1])
+	assert.Equal(t, strings.Count(lines[0], ","), strings.Count(lines[1], ","))
+	assert.Assert(t, strings.HasPrefix(lines[2], "1200,1,12,"), lines[2])
+}
+
+func TestRun_Export(t *testing.T) {
+	filename := writeTestRecording(t)
+	defer func() {
+		assert.NilError(t, os.Remove(filename))
+	}()
+	dir, err := ioutil.TempDir("", "reachctl")
+	assert.NilError(t, err)
+	defer func() {
+		assert.NilError(t, os.RemoveAll(dir))
+	}()
+	for _, format := range []string{"gpx", "kml"} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			output := filepath.Join(dir, "track."+format)
+			var stdout, stderr bytes.Buffer
+			code := run(context.Background(), []string{"export", "-name", "test", filename, output}, &stdout, &stderr)
+			assert.Equal(t, exitCodeOK, code, stderr.String())
+			data, err := ioutil.ReadFile(output)
+			assert.NilError(t, err)
+			golden.Assert(t, string(data), "export."+format+".golden")
+		})
+	}
+	t.Run("unknown format", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"export", filename, filepath.Join(dir, "track.txt")}, &stdout, &stderr)
+		assert.Equal(t, exitCodeUsage, code)
+	})
+}
+
+func TestParseGPSTime(t *testing.T) {
+	week, tow, err := parseGPSTime("2059:113968400")
+	assert.NilError(t, err)
+	assert.Equal(t, uint16(2059), week)
+	assert.Equal(t, uint32(113968400), tow)
+	_, _, err = parseGPSTime("2059")
+	assert.Error(t, err, `invalid GPS time "2059" (expected week:tow)`)
+}
+
+// writeTestRecording writes a recording of a single epoch to a temporary file.
+func writeTestRecording(t *testing.T) string {
+	t.Helper()
+	f, err := ioutil.TempFile("", "reachctl")
+	assert.NilError(t, err)
+	w := erb.NewRecordWriter(f)
+	var packet bytes.Buffer
+	enc := erb.NewEncoder(&packet)
+	receiveTime := time.Date(2019, 6, 24, 7, 39, 10, 0, time.UTC)
+	for _, encode := range []func() error{
+		func() error { return enc.EncodeVER(erb.VER{TimeGPS: 113968400, Medium: 1}) },
+		func() error {
+			return enc.EncodePOS(erb.POS{
+				TimeGPS:                       113968400,
+				LongitudeDegrees:              12.7,
+				LatitudeDegrees:               57.7,
+				AltitudeEllipsoidMeters:       70.5,
+				AltitudeMeanSeaLevelMeters:    35.5,
+				HorizontalAccuracyMillimeters: 14,
+				VerticalAccuracyMillimeters:   21,
+			})
+		},
+		func() error {
+			return enc.EncodeSTAT(erb.STAT{
+				TimeGPS: 113968400, WeekGPS: 2059, FixType: erb.FixTypeRTK, HasFix: true, NumSVs: 2,
+			})
+		},
+		func() error {
+			return enc.EncodeSVI(erb.SVI{TimeGPS: 113968400, NumSVs: 2}, []erb.SV{
+				{ID: 3, Type: erb.SVTypeGPS, SignalStrength: 44, AzimuthDegrees: 120, ElevationDegrees: 35},
+				{ID: 12, Type: erb.SVTypeGalileo, SignalStrength: 38, AzimuthDegrees: 250, ElevationDegrees: 60},
+			})
+		},
+	} {
+		packet.Reset()
+		assert.NilError(t, encode())
+		assert.NilError(t, w.WriteRecord(erb.Record{Time: receiveTime, Data: packet.Bytes()}))
+		receiveTime = receiveTime.Add(time.Millisecond)
+	}
+	assert.NilError(t, f.Close())
+	return f.Name()
+}
+
+func newTestServer(ctx context.Context, t *testing.T) *erbsim.Server {
+	t.Helper()
+	sim := erbsim.NewSimulator(erbsim.Config{
+		Interval: 20 * time.Millisecond,
+		Waypoints: []erbsim.Waypoint{
+			{LatitudeDegrees: 57.7, LongitudeDegrees: 12.7, FixType: erb.FixTypeRTK},
+		},
+	})
+	server, err := erbsim.Listen(ctx, "localhost:0", sim)
+	assert.NilError(t, err)
+	go func() {
+		_ = server.Serve(ctx)
+	}()
+	return server
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"time"
+
+	"go.einride.tech/reach"
+	"go.einride.tech/reach/ntrip"
+	"go.einride.tech/reach/rtklink"
+)
+
+// correctionRetryInterval is the time to wait before reconnecting to the correction input port of a Reach.
+const correctionRetryInterval = time.Second
+
+func runNTRIP(ctx context.Context, args []string, _, stderr io.Writer) error {
+	fs := newFlagSet("ntrip", "<caster> <mountpoint> <endpoint>", stderr)
+	username := fs.String("user", "", "`username` for authentication with the caster")
+	password := fs.String("password", "", "`password` for authentication with the caster")
+	version := fs.Int("version", 2, "NTRIP `version` of the caster (1 or 2)")
+	erbEndpoint := fs.String("erb", "", "ERB `endpoint` of the Reach, for uploading its position and diagnosing its fix")
+	quiet := fs.Bool("q", false, "don't log connection state changes and correction link diagnostics")
+	args, err := parseFlags(fs, args, 3)
+	if err != nil {
+		return err
+	}
+	if *version != int(ntrip.Version1) && *version != int(ntrip.Version2) {
+		return &usageError{err: fmt.Errorf("unsupported NTRIP version %d", *version)}
+	}
+	logger := log.New(stderr, "", log.LstdFlags)
+	if *quiet {
+		logger.SetOutput(ioutil.Discard)
+	}
+	address, dial, err := parseEndpoint(args[2])
+	if err != nil {
+		return &usageError{err: err}
+	}
+	if dial == nil {
+		dial = func(ctx context.Context, address string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "tcp", address)
+		}
+	}
+	client := ntrip.NewClient(ntrip.ClientConfig{
+		Address:    args[0],
+		Mountpoint: args[1],
+		Username:   *username,
+		Password:   *password,
+		Version:    ntrip.Version(*version),
+		OnStateChange: func(state reach.ConnectionState, err error) {
+			if err != nil {
+				logger.Printf("caster %v: %v", state, err)
+				return
+			}
+			logger.Printf("caster %v", state)
+		},
+	})
+	var corrections io.Writer
+	if *erbEndpoint != "" {
+		// diagnose the fix type of the Reach from the state of the correction link
+		monitor := rtklink.NewMonitor(rtklink.Config{
+			OnEvent: func(event rtklink.Event) {
+				logger.Printf("%v: %s", event.Type, event.Diagnosis())
+			},
+		})
+		corrections = monitor
+		cfg, err := newClientConfig(*erbEndpoint, logger)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		defer func() {
+			cancel()
+			<-done
+		}()
+		go func() {
+			defer close(done)
+			erbClient := reach.NewClient(cfg)
+			defer func() {
+				_ = erbClient.Close()
+			}()
+			for erbClient.Scan(ctx) {
+				client.Update(erbClient.Scanner())
+				monitor.Update(erbClient.Scanner())
+			}
+		}()
+	}
+	for {
+		dialCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		conn, err := dial(dialCtx, address)
+		cancel()
+		if err == nil {
+			var w io.Writer = conn
+			if corrections != nil {
+				w = io.MultiWriter(conn, corrections)
+			}
+			err = client.Run(ctx, w)
+			_ = conn.Close()
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		logger.Printf("corrections: %v", err)
+		timer := time.NewTimer(correctionRetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
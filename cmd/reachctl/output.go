@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// outputFormat is a flag value for the output format of messages.
+type outputFormat string
+
+const (
+	outputFormatText outputFormat = "text"
+	outputFormatJSON outputFormat = "json"
+	outputFormatCSV  outputFormat = "csv"
+)
+
+// String implements flag.Value.
+func (f *outputFormat) String() string {
+	return string(*f)
+}
+
+// Set implements flag.Value.
+func (f *outputFormat) Set(s string) error {
+	switch s {
+	case "text":
+		*f = outputFormatText
+	case "json", "jsonl", "ndjson":
+		*f = outputFormatJSON
+	case "csv":
+		*f = outputFormatCSV
+	default:
+		return fmt.Errorf("unknown output format %q (expected text, json or csv)", s)
+	}
+	return nil
+}
+
+// outputFlagUsage is the usage of the output format flag.
+const outputFlagUsage = "output `format`: text, json (JSON lines) or csv (requires a single -id)"
+
+// validateOutput validates the combination of an output format and a message ID filter.
+func validateOutput(format outputFormat, ids idFilter) error {
+	if format == outputFormatCSV && len(ids) != 1 {
+		return &usageError{err: fmt.Errorf("csv output requires a single message ID, for example -id POS")}
+	}
+	return nil
+}
+
+// messageWriter writes messages in an output format.
+type messageWriter interface {
+	// WriteMessage writes the current message of the scanner, with an optional receive time.
+	WriteMessage(receiveTime time.Time, sc *erb.Scanner) error
+	// Flush any buffered output.
+	Flush() error
+}
+
+// newMessageWriter returns a new messageWriter for the output format.
+func newMessageWriter(format outputFormat, w io.Writer) messageWriter {
+	switch format {
+	case outputFormatJSON:
+		return &jsonMessageWriter{enc: json.NewEncoder(w)}
+	case outputFormatCSV:
+		return &csvMessageWriter{w: csv.NewWriter(w)}
+	default:
+		return &textMessageWriter{w: w}
+	}
+}
+
+// textMessageWriter writes messages as Go values, one line per message.
+type textMessageWriter struct {
+	w io.Writer
+}
+
+func (t *textMessageWriter) WriteMessage(receiveTime time.Time, sc *erb.Scanner) error {
+	var prefix string
+	if !receiveTime.IsZero() {
+		prefix = formatReceiveTime(receiveTime) + " "
+	}
+	var err error
+	switch sc.ID() {
+	case erb.IDVER:
+		_, err = fmt.Fprintf(t.w, "%s%v: %+v\n", prefix, sc.ID(), sc.VER())
+	case erb.IDPOS:
+		_, err = fmt.Fprintf(t.w, "%s%v: %+v\n", prefix, sc.ID(), sc.POS())
+	case erb.IDSTAT:
+		_, err = fmt.Fprintf(t.w, "%s%v: %+v\n", prefix, sc.ID(), sc.STAT())
+	case erb.IDDOPS:
+		_, err = fmt.Fprintf(t.w, "%s%v: %+v\n", prefix, sc.ID(), sc.DOPS())
+	case erb.IDVEL:
+		_, err = fmt.Fprintf(t.w, "%s%v: %+v\n", prefix, sc.ID(), sc.VEL())
+	case erb.IDSVI:
+		if _, err = fmt.Fprintf(t.w, "%s%v: %+v\n", prefix, sc.ID(), sc.SVI()); err != nil {
+			return err
+		}
+		for sc.ScanSVI() {
+			if _, err = fmt.Fprintf(t.w, "%s%v: %+v\n", prefix, sc.ID(), sc.SV()); err != nil {
+				return err
+			}
+		}
+	default:
+		_, err = fmt.Fprintf(t.w, "%s%v: %s\n", prefix, sc.ID(), hex.EncodeToString(sc.Bytes()))
+	}
+	return err
+}
+
+func (t *textMessageWriter) Flush() error {
+	return nil
+}
+
+// jsonMessageWriter writes messages as JSON lines, with the fields of each message preceded by the message ID.
+type jsonMessageWriter struct {
+	enc *json.Encoder
+}
+
+// jsonHeader is the leading fields of a JSON message.
+type jsonHeader struct {
+	ReceiveTime string `json:"receive_time,omitempty"`
+	ID          erb.ID `json:"id"`
+}
+
+func (j *jsonMessageWriter) WriteMessage(receiveTime time.Time, sc *erb.Scanner) error {
+	header := jsonHeader{ID: sc.ID()}
+	if !receiveTime.IsZero() {
+		header.ReceiveTime = formatReceiveTime(receiveTime)
+	}
+	var v interface{}
+	switch sc.ID() {
+	case erb.IDVER:
+		v = struct {
+			jsonHeader
+			erb.VER
+		}{header, sc.VER()}
+	case erb.IDPOS:
+		v = struct {
+			jsonHeader
+			erb.POS
+		}{header, sc.POS()}
+	case erb.IDSTAT:
+		v = struct {
+			jsonHeader
+			erb.STAT
+		}{header, sc.STAT()}
+	case erb.IDDOPS:
+		v = struct {
+			jsonHeader
+			erb.DOPS
+		}{header, sc.DOPS()}
+	case erb.IDVEL:
+		v = struct {
+			jsonHeader
+			erb.VEL
+		}{header, sc.VEL()}
+	case erb.IDSVI:
+		svs := make([]erb.SV, 0, sc.SVI().NumSVs)
+		for sc.ScanSVI() {
+			svs = append(svs, sc.SV())
+		}
+		v = struct {
+			jsonHeader
+			erb.SVI
+			SVs []erb.SV `json:"svs"`
+		}{header, sc.SVI(), svs}
+	default:
+		v = struct {
+			jsonHeader
+			Data string `json:"data"`
+		}{header, hex.EncodeToString(sc.Bytes())}
+	}
+	return j.enc.Encode(v)
+}
+
+func (j *jsonMessageWriter) Flush() error {
+	return nil
+}
+
+// csvMessageWriter writes messages as CSV, with columns given by the JSON fields of the message type.
+//
+// A header row is written before the first message. SVI messages are written as one row per SV, with the columns of
+// the SV prefixed by sv_, or as a single row with empty SV columns when there are no SVs.
+type csvMessageWriter struct {
+	w             *csv.Writer
+	writtenHeader bool
+	columns       []string
+	values        []string
+}
+
+func (c *csvMessageWriter) WriteMessage(receiveTime time.Time, sc *erb.Scanner) error {
+	c.columns, c.values = c.columns[:0], c.values[:0]
+	if !receiveTime.IsZero() {
+		c.append("receive_time", formatReceiveTime(receiveTime))
+	}
+	switch sc.ID() {
+	case erb.IDVER:
+		c.appendStruct("", sc.VER())
+	case erb.IDPOS:
+		c.appendStruct("", sc.POS())
+	case erb.IDSTAT:
+		c.appendStruct("", sc.STAT())
+	case erb.IDDOPS:
+		c.appendStruct("", sc.DOPS())
+	case erb.IDVEL:
+		c.appendStruct("", sc.VEL())
+	case erb.IDSVI:
+		c.appendStruct("", sc.SVI())
+		n := len(c.values)
+		var hasSVs bool
+		for sc.ScanSVI() {
+			hasSVs = true
+			c.columns, c.values = c.columns[:n], c.values[:n]
+			c.appendStruct("sv_", sc.SV())
+			if err := c.writeRow(); err != nil {
+				return err
+			}
+		}
+		if hasSVs {
+			return nil
+		}
+		// write a row with empty SV columns, to keep epochs without SVs
+		c.appendStruct("sv_", erb.SV{})
+		for i := n; i < len(c.values); i++ {
+			c.values[i] = ""
+		}
+	default:
+		c.append("data", hex.EncodeToString(sc.Bytes()))
+	}
+	return c.writeRow()
+}
+
+func (c *csvMessageWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvMessageWriter) writeRow() error {
+	if !c.writtenHeader {
+		if err := c.w.Write(c.columns); err != nil {
+			return err
+		}
+		c.writtenHeader = true
+	}
+	return c.w.Write(c.values)
+}
+
+func (c *csvMessageWriter) append(column, value string) {
+	c.columns = append(c.columns, column)
+	c.values = append(c.values, value)
+}
+
+// appendStruct appends the JSON fields of a message struct as columns.
+func (c *csvMessageWriter) appendStruct(prefix string, v interface{}) {
+	rv := reflect.ValueOf(v)
+	for i := 0; i < rv.NumField(); i++ {
+		name := strings.Split(rv.Type().Field(i).Tag.Get("json"), ",")[0]
+		c.append(prefix+name, formatCSVValue(rv.Field(i)))
+	}
+}
+
+func formatCSVValue(v reflect.Value) string {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func formatReceiveTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
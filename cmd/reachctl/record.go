@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"go.einride.tech/reach"
+	"go.einride.tech/reach/erb"
+)
+
+func runRecord(ctx context.Context, args []string, _, stderr io.Writer) error {
+	fs := newFlagSet("record", "<endpoint> <file>", stderr)
+	duration := fs.Duration("duration", 0, "stop recording after `duration` (default until interrupted)")
+	args, err := parseFlags(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	logger := log.New(stderr, "", log.LstdFlags)
+	cfg, err := newClientConfig(args[0], logger)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(args[1])
+	if err != nil {
+		return err
+	}
+	var cancel context.CancelFunc
+	if *duration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+	// stop recording on the first write error, instead of reconnecting
+	w := &cancelingWriter{w: bufio.NewWriter(f), cancel: cancel}
+	dialer := &recordingDialer{dial: cfg.Dial}
+	dialer.recorder = erb.NewRecorder(dialer, w)
+	cfg.Dial = dialer.Dial
+	client := reach.NewClient(cfg)
+	var n int
+	start := time.Now()
+	for client.Scan(ctx) {
+		n++
+	}
+	_ = client.Close()
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	if err := w.err; err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write %s: %w", args[1], err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	logger.Printf("recorded %d messages in %v to %s", n, time.Since(start).Round(time.Millisecond), args[1])
+	return nil
+}
+
+// recordingDialer dials connections that are recorded by a single recorder, across reconnects.
+type recordingDialer struct {
+	dial     func(context.Context, string) (net.Conn, error)
+	recorder *erb.Recorder
+	conn     net.Conn
+}
+
+// Dial a new connection to record.
+func (d *recordingDialer) Dial(ctx context.Context, address string) (net.Conn, error) {
+	dial := d.dial
+	if dial == nil {
+		var dialer net.Dialer
+		dial = func(ctx context.Context, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp", address)
+		}
+	}
+	conn, err := dial(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	d.conn = conn
+	return &recordedConn{Conn: conn, recorder: d.recorder}, nil
+}
+
+// Read from the current connection.
+func (d *recordingDialer) Read(b []byte) (int, error) {
+	return d.conn.Read(b)
+}
+
+// recordedConn is a connection that is read through a recorder.
+type recordedConn struct {
+	net.Conn
+	recorder *erb.Recorder
+}
+
+// Read implements io.Reader.
+func (c *recordedConn) Read(b []byte) (int, error) {
+	return c.recorder.Read(b)
+}
+
+// cancelingWriter is a buffered writer that cancels a context on the first write error.
+type cancelingWriter struct {
+	w      *bufio.Writer
+	cancel context.CancelFunc
+	err    error
+}
+
+// Write implements io.Writer.
+func (w *cancelingWriter) Write(b []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n, err := w.w.Write(b)
+	if err != nil {
+		w.err = err
+		w.cancel()
+	}
+	return n, err
+}
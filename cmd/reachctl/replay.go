@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.einride.tech/reach/erb"
+)
+
+func runReplay(ctx context.Context, args []string, _, stderr io.Writer) error {
+	fs := newFlagSet("replay", "<file>", stderr)
+	listen := fs.String("listen", "localhost:9001", "TCP `address` to serve the recording on")
+	realtime := fs.Bool("realtime", false, "replay in realtime instead of as fast as possible")
+	speed := fs.Float64("speed", 1, "speed `multiplier` of realtime replay")
+	loop := fs.Bool("loop", false, "restart the replay at the end of the recording")
+	seek := fs.String("seek", "", "start the replay at GPS `week:tow`, with the time of week in milliseconds")
+	args, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if *speed <= 0 {
+		return &usageError{err: fmt.Errorf("invalid speed %v", *speed)}
+	}
+	var weekGPS uint16
+	var timeGPS uint32
+	if *seek != "" {
+		if weekGPS, timeGPS, err = parseGPSTime(*seek); err != nil {
+			return &usageError{err: err}
+		}
+	}
+	filename := args[0]
+	cfg := erb.ReplayerConfig{Realtime: *realtime, Speed: *speed, Loop: *loop}
+	newReplayer := func() (*erb.Replayer, io.Closer, error) {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, nil, err
+		}
+		replayer := erb.NewReplayer(f, cfg)
+		if *seek != "" {
+			if err := replayer.SeekGPS(weekGPS, timeGPS); err != nil {
+				_ = f.Close()
+				return nil, nil, err
+			}
+		}
+		return replayer, f, nil
+	}
+	// fail early on unreadable recordings
+	if _, f, err := newReplayer(); err != nil {
+		return err
+	} else if err := f.Close(); err != nil {
+		return err
+	}
+	var lc net.ListenConfig
+	lis, err := lc.Listen(ctx, "tcp", *listen)
+	if err != nil {
+		return err
+	}
+	logger := log.New(stderr, "", log.LstdFlags)
+	logger.Printf("replaying %s on %s", filename, lis.Addr())
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	conns := map[net.Conn]struct{}{}
+	go func() {
+		<-ctx.Done()
+		_ = lis.Close()
+		mu.Lock()
+		for conn := range conns {
+			_ = conn.Close()
+		}
+		mu.Unlock()
+	}()
+	defer wg.Wait()
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		mu.Lock()
+		conns[conn] = struct{}{}
+		mu.Unlock()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				mu.Lock()
+				delete(conns, conn)
+				mu.Unlock()
+				_ = conn.Close()
+			}()
+			logger.Printf("%v: connected", conn.RemoteAddr())
+			replayer, f, err := newReplayer()
+			if err != nil {
+				logger.Printf("%v: %v", conn.RemoteAddr(), err)
+				return
+			}
+			defer func() {
+				_ = f.Close()
+			}()
+			// interrupt realtime pacing when the context is canceled
+			stop := make(chan struct{})
+			defer close(stop)
+			go func() {
+				select {
+				case <-ctx.Done():
+				case <-stop:
+				}
+				_ = replayer.Close()
+			}()
+			if _, err := io.Copy(conn, replayer); err != nil && ctx.Err() == nil {
+				logger.Printf("%v: %v", conn.RemoteAddr(), err)
+				return
+			}
+			logger.Printf("%v: done", conn.RemoteAddr())
+		}()
+	}
+}
+
+// parseGPSTime parses a GPS time on the form week:tow, with the time of week in milliseconds.
+func parseGPSTime(s string) (uint16, uint32, error) {
+	i := strings.IndexByte(s, ':')
+	if i == -1 {
+		return 0, 0, fmt.Errorf("invalid GPS time %q (expected week:tow)", s)
+	}
+	week, err := strconv.ParseUint(s[:i], 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid GPS week %q: %w", s[:i], err)
+	}
+	tow, err := strconv.ParseUint(s[i+1:], 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid GPS time of week %q: %w", s[i+1:], err)
+	}
+	return uint16(week), uint32(tow), nil
+}
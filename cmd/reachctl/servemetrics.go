@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"go.einride.tech/reach"
+	"go.einride.tech/reach/erb"
+	"go.einride.tech/reach/metrics"
+)
+
+func runServeMetrics(ctx context.Context, args []string, _, stderr io.Writer) error {
+	fs := newFlagSet("serve-metrics", "<endpoint>", stderr)
+	listen := fs.String("listen", "localhost:9090", "TCP `address` to serve metrics on")
+	path := fs.String("path", "/metrics", "HTTP `path` to serve metrics on")
+	name := fs.String("receiver", "", "`name` of the receiver label (default the endpoint)")
+	quiet := fs.Bool("q", false, "don't log connection state changes")
+	args, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	logger := log.New(stderr, "", log.LstdFlags)
+	if *quiet {
+		logger.SetOutput(ioutil.Discard)
+	}
+	cfg, err := newClientConfig(args[0], logger)
+	if err != nil {
+		return err
+	}
+	if *name == "" {
+		*name = args[0]
+	}
+	exporter := metrics.NewExporter()
+	receiver := exporter.Receiver(*name)
+	cfg.ScannerConfig = erb.ScannerConfig{Resync: true, OnDiscard: receiver.Discard}
+	var lc net.ListenConfig
+	lis, err := lc.Listen(ctx, "tcp", *listen)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(*path, exporter)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var serveErr error
+	serveDone := make(chan struct{})
+	go func() {
+		defer close(serveDone)
+		if err := server.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+			serveErr = err
+			cancel()
+		}
+	}()
+	logger.Printf("serving metrics on http://%s%s", lis.Addr(), *path)
+	client := reach.NewClient(cfg)
+	defer func() {
+		_ = client.Close()
+	}()
+	for client.Scan(ctx) {
+		receiver.Update(client.Scanner())
+	}
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	<-serveDone
+	return serveErr
+}
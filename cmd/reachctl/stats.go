@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"go.einride.tech/reach"
+	"go.einride.tech/reach/erb"
+)
+
+func runStats(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("stats", "<endpoint>", stderr)
+	interval := fs.Duration("interval", 5*time.Second, "`interval` between reports")
+	duration := fs.Duration("duration", 0, "stop after `duration` (default until interrupted)")
+	quiet := fs.Bool("q", false, "don't log connection state changes")
+	args, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return &usageError{err: fmt.Errorf("invalid interval %v", *interval)}
+	}
+	logger := log.New(stderr, "", log.LstdFlags)
+	if *quiet {
+		logger.SetOutput(ioutil.Discard)
+	}
+	cfg, err := newClientConfig(args[0], logger)
+	if err != nil {
+		return err
+	}
+	stats := newPacketStats(time.Now())
+	cfg.ScannerConfig = erb.ScannerConfig{
+		Resync: true,
+		OnDiscard: func(_ []byte, err error) {
+			stats.discard(err)
+		},
+	}
+	if *duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+		defer cancel()
+	}
+	client := reach.NewClient(cfg)
+	defer func() {
+		_ = client.Close()
+	}()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(*interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				_ = stats.writeReport(stdout, now)
+			}
+		}
+	}()
+	for client.Scan(ctx) {
+		stats.add(client.Scanner())
+	}
+	close(done)
+	wg.Wait()
+	return stats.writeReport(stdout, time.Now())
+}
+
+// packetStats are statistics of an ERB stream.
+type packetStats struct {
+	mu               sync.Mutex
+	start            time.Time
+	lastReport       time.Time
+	packets          map[erb.ID]uint64
+	intervalPackets  map[erb.ID]uint64
+	fixTypes         map[erb.FixType]uint64
+	checksumFailures uint64
+	discardedFrames  uint64
+}
+
+func newPacketStats(start time.Time) *packetStats {
+	return &packetStats{
+		start:           start,
+		lastReport:      start,
+		packets:         map[erb.ID]uint64{},
+		intervalPackets: map[erb.ID]uint64{},
+		fixTypes:        map[erb.FixType]uint64{},
+	}
+}
+
+// add the current message of the scanner to the statistics.
+func (s *packetStats) add(sc *erb.Scanner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.packets[sc.ID()]++
+	s.intervalPackets[sc.ID()]++
+	if sc.ID() == erb.IDSTAT {
+		s.fixTypes[sc.STAT().FixType]++
+	}
+}
+
+// discard adds a discarded frame to the statistics.
+func (s *packetStats) discard(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.discardedFrames++
+	if errors.Is(err, erb.ErrChecksumMismatch) {
+		s.checksumFailures++
+	}
+}
+
+// writeReport writes a report of the statistics, with packet rates since the last report.
+func (s *packetStats) writeReport(w io.Writer, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "elapsed: %v\n", now.Sub(s.start).Round(time.Second))
+	_, _ = fmt.Fprintf(tw, "ID\tRATE\tTOTAL\n")
+	ids := make([]erb.ID, 0, len(s.packets))
+	for id := range s.packets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	seconds := now.Sub(s.lastReport).Seconds()
+	for _, id := range ids {
+		var rate float64
+		if seconds > 0 {
+			rate = float64(s.intervalPackets[id]) / seconds
+		}
+		_, _ = fmt.Fprintf(tw, "%v\t%.1f/s\t%d\n", id, rate, s.packets[id])
+	}
+	_, _ = fmt.Fprintf(tw, "checksum failures:\t%d\n", s.checksumFailures)
+	_, _ = fmt.Fprintf(tw, "discarded frames:\t%d\n", s.discardedFrames)
+	fixTypes := make([]erb.FixType, 0, len(s.fixTypes))
+	var numFixes uint64
+	for fixType, n := range s.fixTypes {
+		fixTypes = append(fixTypes, fixType)
+		numFixes += n
+	}
+	sort.Slice(fixTypes, func(i, j int) bool {
+		return fixTypes[i] < fixTypes[j]
+	})
+	for _, fixType := range fixTypes {
+		n := s.fixTypes[fixType]
+		_, _ = fmt.Fprintf(tw, "fix type %v:\t%d\t(%.1f%%)\n", fixType, n, 100*float64(n)/float64(numFixes))
+	}
+	_, _ = fmt.Fprintln(tw)
+	s.lastReport = now
+	s.intervalPackets = map[erb.ID]uint64{}
+	return tw.Flush()
+}
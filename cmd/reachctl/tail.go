@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"time"
+
+	"go.einride.tech/reach"
+)
+
+func runTail(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("tail", "<endpoint>", stderr)
+	ids := idFilter{}
+	fs.Var(ids, "id", "comma-separated `IDs` of messages to print, for example POS,STAT (default all)")
+	output := outputFormatText
+	fs.Var(&output, "output", outputFlagUsage)
+	quiet := fs.Bool("q", false, "don't log connection state changes")
+	args, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := validateOutput(output, ids); err != nil {
+		return err
+	}
+	logger := log.New(stderr, "", log.LstdFlags)
+	if *quiet {
+		logger.SetOutput(ioutil.Discard)
+	}
+	cfg, err := newClientConfig(args[0], logger)
+	if err != nil {
+		return err
+	}
+	client := reach.NewClient(cfg)
+	defer func() {
+		_ = client.Close()
+	}()
+	w := newMessageWriter(output, stdout)
+	for client.Scan(ctx) {
+		sc := client.Scanner()
+		if !ids.Match(sc.ID()) {
+			continue
+		}
+		if err := w.WriteMessage(time.Time{}, sc); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// userList is a flag value for usernames and passwords, on the form username:password.
+type userList map[string]string
+
+var _ flag.Value = userList{}
+
+// String implements flag.Value.
+func (u userList) String() string {
+	usernames := make([]string, 0, len(u))
+	for username := range u {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	return strings.Join(usernames, ",")
+}
+
+// Set implements flag.Value.
+func (u userList) Set(s string) error {
+	i := strings.IndexByte(s, ':')
+	if i <= 0 {
+		return fmt.Errorf("invalid user %q, expected username:password", s)
+	}
+	u[s[:i]] = s[i+1:]
+	return nil
+}
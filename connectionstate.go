@@ -0,0 +1,15 @@
+package reach
+
+// ConnectionState represents the state of a Client's connection to a Reach.
+type ConnectionState uint8
+
+//go:generate stringer -type ConnectionState -trimprefix ConnectionState
+
+const (
+	// ConnectionStateDisconnected is the state when the client is not connected.
+	ConnectionStateDisconnected ConnectionState = iota
+	// ConnectionStateConnecting is the state when the client is dialing the Reach.
+	ConnectionStateConnecting
+	// ConnectionStateConnected is the state when the client is connected.
+	ConnectionStateConnected
+)
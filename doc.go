@@ -0,0 +1,5 @@
+// Package reach provides a Go client for Emlid Reach GNSS receivers.
+//
+// The root package contains receiver-agnostic types, and the protocol-specific primitives live in sub-packages,
+// such as package erb for the Emlid Reach Binary protocol.
+package reach
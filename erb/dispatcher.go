@@ -0,0 +1,266 @@
+package erb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrSlowConsumer is the error of a subscription terminated by SlowConsumerPolicyError.
+var ErrSlowConsumer = errors.New("erb: slow consumer")
+
+// SubscriptionConfig configures a Subscription.
+type SubscriptionConfig struct {
+	// IDs of the messages to subscribe to. Subscribes to all messages when empty.
+	IDs []ID
+	// BufferSize is the number of messages buffered for the subscriber. Defaults to 1.
+	BufferSize int
+	// Policy for handling a full buffer.
+	Policy SlowConsumerPolicy
+}
+
+// Subscription is a subscription to messages from a Dispatcher.
+type Subscription struct {
+	// C is the channel on which the subscribed messages are delivered.
+	//
+	// C is closed when the subscription is terminated, unsubscribed or the dispatcher is closed.
+	C       <-chan Message
+	c       chan Message
+	d       *Dispatcher
+	ids     map[ID]struct{}
+	policy  SlowConsumerPolicy
+	done    chan struct{}
+	once    sync.Once
+	dropped uint64
+	err     error
+	// mu guards sending on and closing of c.
+	mu     sync.Mutex
+	closed bool
+}
+
+// Unsubscribe stops delivery of messages to the subscription, and closes C.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		// closing done first interrupts a blocking send, which holds mu
+		close(s.done)
+		s.d.remove([]*Subscription{s})
+	})
+}
+
+// Dropped returns the number of messages dropped by SlowConsumerPolicyDropOldest.
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Err returns ErrSlowConsumer when the subscription was terminated by SlowConsumerPolicyError.
+//
+// Err must only be called after C has been closed.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+func (s *Subscription) isSubscribed(id ID) bool {
+	if len(s.ids) == 0 {
+		return true
+	}
+	_, ok := s.ids[id]
+	return ok
+}
+
+// close the channel of the subscription, unless already closed.
+func (s *Subscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.c)
+	}
+}
+
+func (s *Subscription) isUnsubscribed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Dispatcher fans out ERB messages to multiple subscribers.
+//
+// Messages are dispatched and the dispatcher is closed from a single goroutine, while subscribers may subscribe and
+// unsubscribe concurrently.
+type Dispatcher struct {
+	mu            sync.Mutex
+	subscriptions []*Subscription
+	closed        bool
+}
+
+// NewDispatcher returns a new Dispatcher.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// Subscribe to messages from the dispatcher.
+func (d *Dispatcher) Subscribe(cfg SubscriptionConfig) *Subscription {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 1
+	}
+	c := make(chan Message, cfg.BufferSize)
+	s := &Subscription{
+		C:      c,
+		c:      c,
+		d:      d,
+		policy: cfg.Policy,
+		done:   make(chan struct{}),
+	}
+	if len(cfg.IDs) > 0 {
+		s.ids = make(map[ID]struct{}, len(cfg.IDs))
+		for _, id := range cfg.IDs {
+			s.ids[id] = struct{}{}
+		}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		s.close()
+		return s
+	}
+	d.subscriptions = append(d.subscriptions, s)
+	return s
+}
+
+// SubscribeFunc subscribes to messages from the dispatcher, and calls fn with each message from a separate goroutine.
+func (d *Dispatcher) SubscribeFunc(cfg SubscriptionConfig, fn func(Message)) *Subscription {
+	s := d.Subscribe(cfg)
+	go func() {
+		for m := range s.C {
+			fn(m)
+		}
+	}()
+	return s
+}
+
+// Run scans messages from sc and dispatches them to the subscribers, until sc is exhausted or the context is
+// canceled. The dispatcher is closed when Run returns.
+//
+// To interrupt a blocking scan when the context is canceled, the reader of the scanner must be closed.
+func (d *Dispatcher) Run(ctx context.Context, sc *Scanner) error {
+	defer d.Close()
+	for sc.Scan() {
+		if err := d.Dispatch(ctx, sc.Message()); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return sc.Err()
+}
+
+// Dispatch a message to the subscribers.
+//
+// Dispatch only returns an error when the context is canceled while blocking on a subscriber.
+func (d *Dispatcher) Dispatch(ctx context.Context, m Message) error {
+	d.mu.Lock()
+	subscriptions := d.subscriptions
+	d.mu.Unlock()
+	var terminated []*Subscription
+	for _, s := range subscriptions {
+		if s.isUnsubscribed() {
+			terminated = append(terminated, s)
+			continue
+		}
+		if !s.isSubscribed(m.ID) {
+			continue
+		}
+		ok, err := d.send(ctx, s, m)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			terminated = append(terminated, s)
+		}
+	}
+	if len(terminated) > 0 {
+		d.remove(terminated)
+	}
+	return nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, s *Subscription, m Message) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false, nil
+	}
+	select {
+	case s.c <- m:
+		return true, nil
+	default:
+	}
+	switch s.policy {
+	case SlowConsumerPolicyBlock:
+		select {
+		case s.c <- m:
+			return true, nil
+		case <-s.done:
+			return false, nil
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+	case SlowConsumerPolicyError:
+		s.err = ErrSlowConsumer
+		return false, nil
+	default: // SlowConsumerPolicyDropOldest
+		for {
+			select {
+			case <-s.c:
+				atomic.AddUint64(&s.dropped, 1)
+			default:
+			}
+			select {
+			case s.c <- m:
+				return true, nil
+			default:
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) remove(terminated []*Subscription) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	subscriptions := make([]*Subscription, 0, len(d.subscriptions))
+	for _, s := range d.subscriptions {
+		isTerminated := false
+		for _, t := range terminated {
+			if s == t {
+				isTerminated = true
+				break
+			}
+		}
+		if !isTerminated {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	d.subscriptions = subscriptions
+	for _, s := range terminated {
+		s.close()
+	}
+}
+
+// Close the dispatcher, which closes the channels of all subscriptions.
+func (d *Dispatcher) Close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return
+	}
+	d.closed = true
+	for _, s := range d.subscriptions {
+		s.close()
+	}
+	d.subscriptions = nil
+}
@@ -0,0 +1,108 @@
+package erb
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestDispatcher_Run(t *testing.T) {
+	d := NewDispatcher()
+	all := d.Subscribe(SubscriptionConfig{BufferSize: 1000})
+	svi := d.Subscribe(SubscriptionConfig{IDs: []ID{IDSVI}, BufferSize: 1000})
+	pos := make(chan Message, 1)
+	d.SubscribeFunc(SubscriptionConfig{IDs: []ID{IDPOS}, Policy: SlowConsumerPolicyBlock}, func(m Message) {
+		select {
+		case pos <- m:
+		default:
+		}
+	})
+	sc := NewScanner(bytes.NewReader(loadHexDump(t, "testdata/hexdump.asta")))
+	assert.NilError(t, d.Run(context.Background(), sc))
+	assert.Equal(t, IDPOS, (<-pos).ID)
+	var ids []ID
+	for m := range all.C {
+		ids = append(ids, m.ID)
+	}
+	assert.DeepEqual(t, []ID{IDVER, IDPOS, IDSTAT, IDDOPS, IDVEL, IDSVI}, ids[:6])
+	m, ok := <-svi.C
+	assert.Assert(t, ok)
+	assert.Equal(t, IDSVI, m.ID)
+	assert.Equal(t, int(m.SVI.NumSVs), len(m.SVs))
+	assert.Equal(t, uint8(2), m.SVs[0].ID)
+}
+
+func TestDispatcher_SlowConsumer(t *testing.T) {
+	ctx := context.Background()
+	d := NewDispatcher()
+	dropOldest := d.Subscribe(SubscriptionConfig{BufferSize: 2, Policy: SlowConsumerPolicyDropOldest})
+	withError := d.Subscribe(SubscriptionConfig{BufferSize: 2, Policy: SlowConsumerPolicyError})
+	unsubscribed := d.Subscribe(SubscriptionConfig{BufferSize: 2, Policy: SlowConsumerPolicyBlock})
+	for i := uint32(0); i < 4; i++ {
+		if i == 2 {
+			unsubscribed.Unsubscribe()
+		}
+		assert.NilError(t, d.Dispatch(ctx, Message{ID: IDPOS, POS: POS{TimeGPS: i}}))
+	}
+	d.Close()
+	// drop-oldest keeps the latest messages
+	var times []uint32
+	for m := range dropOldest.C {
+		times = append(times, m.POS.TimeGPS)
+	}
+	assert.DeepEqual(t, []uint32{2, 3}, times)
+	assert.Equal(t, uint64(2), dropOldest.Dropped())
+	// error terminates the subscription after the buffered messages
+	times = nil
+	for m := range withError.C {
+		times = append(times, m.POS.TimeGPS)
+	}
+	assert.DeepEqual(t, []uint32{0, 1}, times)
+	assert.Equal(t, ErrSlowConsumer, withError.Err())
+	// unsubscribe closes the channel
+	times = nil
+	for m := range unsubscribed.C {
+		times = append(times, m.POS.TimeGPS)
+	}
+	assert.DeepEqual(t, []uint32{0, 1}, times)
+}
+
+func TestDispatcher_BlockCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	d := NewDispatcher()
+	_ = d.Subscribe(SubscriptionConfig{Policy: SlowConsumerPolicyBlock})
+	assert.NilError(t, d.Dispatch(ctx, Message{ID: IDPOS}))
+	cancel()
+	assert.Equal(t, context.Canceled, d.Dispatch(ctx, Message{ID: IDPOS}))
+}
+
+func TestDispatcher_UnsubscribeClosesChannel(t *testing.T) {
+	d := NewDispatcher()
+	s := d.Subscribe(SubscriptionConfig{})
+	// no message is dispatched after the subscription, as when the stream stalls
+	s.Unsubscribe()
+	_, ok := <-s.C
+	assert.Assert(t, !ok)
+	s.Unsubscribe()
+	d.Close()
+}
+
+func TestDispatcher_UnsubscribeWhileBlocked(t *testing.T) {
+	ctx := context.Background()
+	d := NewDispatcher()
+	s := d.Subscribe(SubscriptionConfig{Policy: SlowConsumerPolicyBlock})
+	assert.NilError(t, d.Dispatch(ctx, Message{ID: IDPOS}))
+	done := make(chan error)
+	go func() {
+		done <- d.Dispatch(ctx, Message{ID: IDPOS})
+	}()
+	s.Unsubscribe()
+	assert.NilError(t, <-done)
+	var n int
+	for range s.C {
+		n++
+	}
+	assert.Assert(t, n >= 1)
+}
@@ -3,6 +3,10 @@
 // Implementation is based on the ERB Protocol spec version 0.1.0:
 //
 //  https://files.emlid.com/ERB.pdf
+//
+// Newer protocol versions that extend the payloads with trailing fields are decoded according to the known layouts.
+// The layouts of spec version 0.1.0 are the newest known to this package, so trailing fields are not decoded, but
+// exposed as raw bytes through Scanner.Extension.
 package erb
 
 // Supported protocol versions.
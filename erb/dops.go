@@ -2,6 +2,8 @@ package erb
 
 import (
 	"encoding/binary"
+	"math"
+	"time"
 )
 
 // structure of DOPS message.
@@ -30,15 +32,22 @@ const scaleOfDOPS = 0.01
 // For example, if received value is 123, then real is 1.23.
 type DOPS struct {
 	// TimeGPS is the time of week in milliseconds of the navigation epoch.
-	TimeGPS uint32
+	TimeGPS uint32 `json:"time_gps"`
 	// Geometric DOP.
-	Geometric float64
+	Geometric float64 `json:"geometric"`
 	// Position DOP.
-	Position float64
+	Position float64 `json:"position"`
 	// Vertical DOP.
-	Vertical float64
+	Vertical float64 `json:"vertical"`
 	// Horizontal DOP.
-	Horizontal float64
+	Horizontal float64 `json:"horizontal"`
+}
+
+// Time returns the UTC time of the navigation epoch, given the GPS week of the epoch.
+//
+// DOPS messages carry no GPS week, use the week of the STAT message of the same epoch or ResolveWeekGPS.
+func (d *DOPS) Time(weekGPS uint16) time.Time {
+	return UTCTime(weekGPS, d.TimeGPS)
 }
 
 func (d *DOPS) unmarshalPayload(b []byte) {
@@ -57,3 +66,40 @@ func (d *DOPS) unmarshalPayload(b []byte) {
 		binary.LittleEndian.Uint16(b[indexOfDOPSHorizontal:indexOfDOPSHorizontal+lengthOfDOPSHorizontal]),
 	)
 }
+
+func (d *DOPS) marshalPayload(b []byte) {
+	_ = b[lengthOfDOPS-1] // early bounds check
+	binary.LittleEndian.PutUint32(b[indexOfTimeGPS:indexOfTimeGPS+lengthOfTimeGPS], d.TimeGPS)
+	binary.LittleEndian.PutUint16(
+		b[indexOfDOPSGeo:indexOfDOPSGeo+lengthOfDOPSGeo],
+		uint16(math.Round(d.Geometric/scaleOfDOPS)),
+	)
+	binary.LittleEndian.PutUint16(
+		b[indexOfDOPSPosition:indexOfDOPSPosition+lengthOfDOPSPosition],
+		uint16(math.Round(d.Position/scaleOfDOPS)),
+	)
+	binary.LittleEndian.PutUint16(
+		b[indexOfDOPSVertical:indexOfDOPSVertical+lengthOfDOPSVertical],
+		uint16(math.Round(d.Vertical/scaleOfDOPS)),
+	)
+	binary.LittleEndian.PutUint16(
+		b[indexOfDOPSHorizontal:indexOfDOPSHorizontal+lengthOfDOPSHorizontal],
+		uint16(math.Round(d.Horizontal/scaleOfDOPS)),
+	)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the ERB payload of the DOPS message.
+func (d *DOPS) MarshalBinary() ([]byte, error) {
+	b := make([]byte, lengthOfDOPS)
+	d.marshalPayload(b)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and parses the ERB payload of a DOPS message.
+func (d *DOPS) UnmarshalBinary(b []byte) error {
+	if err := validatePayloadDOPS(b); err != nil {
+		return err
+	}
+	d.unmarshalPayload(b)
+	return nil
+}
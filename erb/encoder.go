@@ -0,0 +1,84 @@
+package erb
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// Encoder provides a convenient interface for encoding ERB messages to a stream.
+type Encoder struct {
+	w       io.Writer
+	packet  []byte
+	payload []byte
+}
+
+// NewEncoder returns a new Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// EncodePacket writes an ERB packet with the provided message ID and raw payload.
+func (e *Encoder) EncodePacket(id ID, payload []byte) error {
+	if len(payload) > math.MaxUint16 {
+		return fmt.Errorf("encode %v packet: payload length %d exceeds maximum %d", id, len(payload), math.MaxUint16)
+	}
+	e.packet = appendPacket(e.packet[:0], id, payload)
+	if _, err := e.w.Write(e.packet); err != nil {
+		return fmt.Errorf("encode %v packet: %w", id, err)
+	}
+	return nil
+}
+
+// EncodeVER writes a VER message.
+func (e *Encoder) EncodeVER(ver VER) error {
+	ver.marshalPayload(e.resizePayload(lengthOfVER))
+	return e.EncodePacket(IDVER, e.payload)
+}
+
+// EncodePOS writes a POS message.
+func (e *Encoder) EncodePOS(pos POS) error {
+	pos.marshalPayload(e.resizePayload(lengthOfPOS))
+	return e.EncodePacket(IDPOS, e.payload)
+}
+
+// EncodeSTAT writes a STAT message.
+func (e *Encoder) EncodeSTAT(stat STAT) error {
+	stat.marshalPayload(e.resizePayload(lengthOfSTAT))
+	return e.EncodePacket(IDSTAT, e.payload)
+}
+
+// EncodeDOPS writes a DOPS message.
+func (e *Encoder) EncodeDOPS(dops DOPS) error {
+	dops.marshalPayload(e.resizePayload(lengthOfDOPS))
+	return e.EncodePacket(IDDOPS, e.payload)
+}
+
+// EncodeVEL writes a VEL message.
+func (e *Encoder) EncodeVEL(vel VEL) error {
+	vel.marshalPayload(e.resizePayload(lengthOfVEL))
+	return e.EncodePacket(IDVEL, e.payload)
+}
+
+// EncodeSVI writes an SVI message containing the provided SVs.
+//
+// The NumSVs field of the SVI message must match the number of provided SVs.
+func (e *Encoder) EncodeSVI(svi SVI, svs []SV) error {
+	if int(svi.NumSVs) != len(svs) {
+		return fmt.Errorf("encode %v: NumSVs is %d but got %d SVs", IDSVI, svi.NumSVs, len(svs))
+	}
+	e.resizePayload(indexOfSV + lengthOfSV*len(svs))
+	svi.marshalPayload(e.payload)
+	for i := range svs {
+		svs[i].marshalPayload(e.payload, i)
+	}
+	return e.EncodePacket(IDSVI, e.payload)
+}
+
+func (e *Encoder) resizePayload(n int) []byte {
+	if cap(e.payload) < n {
+		e.payload = make([]byte, n)
+	}
+	e.payload = e.payload[:n]
+	return e.payload
+}
@@ -0,0 +1,138 @@
+package erb
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestEncoder_HexDump(t *testing.T) {
+	for _, inputFile := range []string{
+		"testdata/hexdump.empty",
+		"testdata/hexdump.asta",
+	} {
+		inputFile := inputFile
+		t.Run(inputFile, func(t *testing.T) {
+			data := loadHexDump(t, inputFile)
+			sc := NewScanner(bytes.NewReader(data))
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf)
+			for sc.Scan() {
+				switch sc.ID() {
+				case IDVER:
+					assert.NilError(t, enc.EncodeVER(sc.VER()))
+				case IDPOS:
+					assert.NilError(t, enc.EncodePOS(sc.POS()))
+				case IDSTAT:
+					assert.NilError(t, enc.EncodeSTAT(sc.STAT()))
+				case IDDOPS:
+					assert.NilError(t, enc.EncodeDOPS(sc.DOPS()))
+				case IDVEL:
+					assert.NilError(t, enc.EncodeVEL(sc.VEL()))
+				case IDSVI:
+					var svs []SV
+					for sc.ScanSVI() {
+						svs = append(svs, sc.SV())
+					}
+					assert.NilError(t, enc.EncodeSVI(sc.SVI(), svs))
+				default:
+					assert.NilError(t, enc.EncodePacket(sc.ID(), sc.Bytes()[indexOfPayload:len(sc.Bytes())-lengthOfChecksum]))
+				}
+			}
+			assert.NilError(t, sc.Err())
+			// the recorded data ends with a truncated packet
+			assert.Assert(t, buf.Len() > 0)
+			assert.DeepEqual(t, data[:buf.Len()], buf.Bytes())
+		})
+	}
+}
+
+func TestEncoder_EncodeSVI_NumSVsMismatch(t *testing.T) {
+	enc := NewEncoder(&bytes.Buffer{})
+	assert.ErrorContains(t, enc.EncodeSVI(SVI{NumSVs: 2}, []SV{{ID: 1}}), "NumSVs is 2 but got 1 SVs")
+}
+
+func TestMarshalBinary_RoundTrip(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   interface {
+			MarshalBinary() ([]byte, error)
+		}
+		out interface {
+			UnmarshalBinary([]byte) error
+		}
+	}{
+		{
+			name: "VER",
+			in:   &VER{TimeGPS: 113968400, High: 0, Medium: 1, Low: 0},
+			out:  &VER{},
+		},
+		{
+			name: "POS",
+			in: &POS{
+				TimeGPS:                       113968400,
+				LongitudeDegrees:              12.78053987650962,
+				LatitudeDegrees:               57.77768346102213,
+				AltitudeEllipsoidMeters:       235.1567126158625,
+				AltitudeMeanSeaLevelMeters:    200.11314348887072,
+				HorizontalAccuracyMillimeters: 3316,
+				VerticalAccuracyMillimeters:   4638,
+			},
+			out: &POS{},
+		},
+		{
+			name: "STAT",
+			in:   &STAT{TimeGPS: 113968400, WeekGPS: 2059, FixType: FixTypeSingle, HasFix: true, NumSVs: 20},
+			out:  &STAT{},
+		},
+		{
+			name: "DOPS",
+			in:   &DOPS{TimeGPS: 113968400, Geometric: 1.36, Position: 1.21, Vertical: 0.98, Horizontal: 0.69},
+			out:  &DOPS{},
+		},
+		{
+			name: "VEL",
+			in: &VEL{
+				TimeGPS:                   113968400,
+				NorthCentimetersPerSecond: 12,
+				EastCentimetersPerSecond:  -34,
+				DownCentimetersPerSecond:  -1,
+				SpeedCentimetersPerSecond: 36,
+				HeadingDegrees:            -88.77799,
+			},
+			out: &VEL{},
+		},
+		{
+			name: "SVI",
+			in:   &SVI{TimeGPS: 113968400, NumSVs: 20},
+			out:  &SVI{},
+		},
+		{
+			name: "SV",
+			in: &SV{
+				ID:                        2,
+				Type:                      SVTypeGPS,
+				SignalStrength:            43,
+				CarrierPhase:              1.1299208e+06,
+				PseudoRangeResidualMeters: 21501676,
+				DopplerFrequencyHz:        1647.887,
+				AzimuthDegrees:            112.8,
+				ElevationDegrees:          32.1,
+			},
+			out: &SV{},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.in.MarshalBinary()
+			assert.NilError(t, err)
+			assert.NilError(t, tt.out.UnmarshalBinary(data))
+			actual, err := tt.out.(interface {
+				MarshalBinary() ([]byte, error)
+			}).MarshalBinary()
+			assert.NilError(t, err)
+			assert.DeepEqual(t, data, actual)
+		})
+	}
+}
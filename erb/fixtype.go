@@ -1,5 +1,7 @@
 package erb
 
+import "fmt"
+
 // FixType represents a type of navigation fix.
 type FixType uint8
 
@@ -11,3 +13,24 @@ const (
 	FixTypeFloat  FixType = 0x02
 	FixTypeRTK    FixType = 0x03
 )
+
+// MarshalText implements encoding.TextMarshaler.
+func (f FixType) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *FixType) UnmarshalText(text []byte) error {
+	for _, value := range []FixType{FixTypeNoFix, FixTypeSingle, FixTypeFloat, FixTypeRTK} {
+		if value.String() == string(text) {
+			*f = value
+			return nil
+		}
+	}
+	// unknown values are formatted as FixType(n) by String
+	if value, ok := parseUnknownText(text, "FixType"); ok {
+		*f = FixType(value)
+		return nil
+	}
+	return fmt.Errorf("unmarshal fix type: unknown value %q", text)
+}
@@ -1,5 +1,7 @@
 package erb
 
+import "fmt"
+
 // ID represents an ERB message ID.
 type ID uint8
 
@@ -19,3 +21,24 @@ const (
 	// IDSVI is the ID of the SVI message.
 	IDSVI ID = 0x06
 )
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *ID) UnmarshalText(text []byte) error {
+	for _, value := range []ID{IDVER, IDPOS, IDSTAT, IDDOPS, IDVEL, IDSVI} {
+		if value.String() == string(text) {
+			*id = value
+			return nil
+		}
+	}
+	// unknown values are formatted as ID(n) by String
+	if value, ok := parseUnknownText(text, "ID"); ok {
+		*id = ID(value)
+		return nil
+	}
+	return fmt.Errorf("unmarshal ID: unknown value %q", text)
+}
@@ -0,0 +1,83 @@
+package erb
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestJSON_RoundTrip(t *testing.T) {
+	messages := []interface{}{
+		&VER{TimeGPS: 1000, High: 0, Medium: 1, Low: 0},
+		&POS{
+			TimeGPS:                       1000,
+			LongitudeDegrees:              12.7,
+			LatitudeDegrees:               57.7,
+			AltitudeEllipsoidMeters:       70.5,
+			AltitudeMeanSeaLevelMeters:    35.5,
+			HorizontalAccuracyMillimeters: 14,
+			VerticalAccuracyMillimeters:   21,
+		},
+		&STAT{TimeGPS: 1000, WeekGPS: 2059, FixType: FixTypeRTK, HasFix: true, NumSVs: 18},
+		&DOPS{TimeGPS: 1000, Geometric: 1.5, Position: 1.25, Vertical: 1, Horizontal: 0.75},
+		&VEL{
+			TimeGPS:                           1000,
+			NorthCentimetersPerSecond:         -3,
+			EastCentimetersPerSecond:          4,
+			DownCentimetersPerSecond:          1,
+			SpeedCentimetersPerSecond:         5,
+			HeadingDegrees:                    126.87,
+			SpeedAccuracyCentimetersPerSecond: 2,
+		},
+		&SVI{TimeGPS: 1000, NumSVs: 1},
+		&SV{
+			ID:                        12,
+			Type:                      SVTypeGalileo,
+			SignalStrength:            42,
+			CarrierPhase:              1234.5,
+			PseudoRangeResidualMeters: -2,
+			DopplerFrequencyHz:        -1500.25,
+			AzimuthDegrees:            180,
+			ElevationDegrees:          45,
+		},
+	}
+	var actual []byte
+	for _, message := range messages {
+		data, err := json.Marshal(message)
+		assert.NilError(t, err)
+		actual = append(actual, data...)
+		actual = append(actual, '\n')
+		unmarshaled := reflect.New(reflect.TypeOf(message).Elem()).Interface()
+		assert.NilError(t, json.Unmarshal(data, unmarshaled))
+		assert.DeepEqual(t, message, unmarshaled)
+	}
+	golden.Assert(t, string(actual), "json.golden")
+}
+
+func TestFixType_UnmarshalText(t *testing.T) {
+	var fixType FixType
+	assert.NilError(t, fixType.UnmarshalText([]byte("Float")))
+	assert.Equal(t, FixTypeFloat, fixType)
+	assert.Error(t, fixType.UnmarshalText([]byte("Foo")), `unmarshal fix type: unknown value "Foo"`)
+}
+
+func TestUnmarshalText_UnknownValues(t *testing.T) {
+	unknownFixType, unknownSVType, unknownID := FixType(5), SVType(9), ID(0x42)
+	for _, value := range []interface {
+		encoding.TextMarshaler
+		encoding.TextUnmarshaler
+	}{&unknownFixType, &unknownSVType, &unknownID} {
+		text, err := value.MarshalText()
+		assert.NilError(t, err)
+		unmarshaled := reflect.New(reflect.TypeOf(value).Elem()).Interface().(encoding.TextUnmarshaler)
+		assert.NilError(t, unmarshaled.UnmarshalText(text), string(text))
+		assert.DeepEqual(t, value, unmarshaled)
+	}
+	var fixType FixType
+	assert.Error(t, fixType.UnmarshalText([]byte("FixType(256)")), `unmarshal fix type: unknown value "FixType(256)"`)
+	assert.Error(t, fixType.UnmarshalText([]byte("SVType(5)")), `unmarshal fix type: unknown value "SVType(5)"`)
+}
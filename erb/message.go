@@ -0,0 +1,53 @@
+package erb
+
+// Message is a snapshot of a single ERB message.
+//
+// Only the field corresponding to the ID of the message is set.
+type Message struct {
+	// ID of the message.
+	ID ID
+	// VER message, when ID is IDVER.
+	VER VER
+	// POS message, when ID is IDPOS.
+	POS POS
+	// STAT message, when ID is IDSTAT.
+	STAT STAT
+	// DOPS message, when ID is IDDOPS.
+	DOPS DOPS
+	// VEL message, when ID is IDVEL.
+	VEL VEL
+	// SVI message, when ID is IDSVI.
+	SVI SVI
+	// SVs of the SVI message, when ID is IDSVI.
+	SVs []SV
+	// Bytes is a copy of the raw packet, when the ID is unknown.
+	Bytes []byte
+}
+
+// Message returns a snapshot of the current message.
+//
+// For SVI messages, the remaining SVs of the message are scanned and included in the snapshot.
+func (c *Scanner) Message() Message {
+	m := Message{ID: c.id}
+	switch c.id {
+	case IDVER:
+		m.VER = c.ver
+	case IDPOS:
+		m.POS = c.pos
+	case IDSTAT:
+		m.STAT = c.stat
+	case IDDOPS:
+		m.DOPS = c.dops
+	case IDVEL:
+		m.VEL = c.vel
+	case IDSVI:
+		m.SVI = c.svi
+		m.SVs = make([]SV, 0, int(c.svi.NumSVs)-c.svIndex)
+		for c.ScanSVI() {
+			m.SVs = append(m.SVs, c.sv)
+		}
+	default:
+		m.Bytes = append([]byte(nil), c.Bytes()...)
+	}
+	return m
+}
@@ -3,9 +3,14 @@ package erb
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"math"
 )
 
+// ErrChecksumMismatch is the error of a packet with an invalid checksum.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
 const (
 	syncChar1 = 'E'
 	syncChar2 = 'R'
@@ -20,6 +25,7 @@ const (
 	lengthOfPayloadLength = 2
 	indexOfPayload        = indexOfPayloadLength + lengthOfPayloadLength
 	lengthOfChecksum      = 2
+	maxLengthOfPacket     = indexOfPayload + math.MaxUint16 + lengthOfChecksum
 )
 
 func calculateIndexOfChecksum(lengthOfPayload uint16) int {
@@ -31,7 +37,24 @@ func calculateLengthOfPacket(lengthOfPayload uint16) int {
 }
 
 // ScanPackets is a split function for a bufio.Scanner that returns each ERB packet.
-func ScanPackets(data []byte, _ bool) (advance int, token []byte, err error) {
+//
+// VER packets of protocol versions not accepted by default are rejected, see ScannerConfig.ProtocolVersions. Use a
+// Scanner to accept other protocol versions.
+func ScanPackets(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	advance, token, err = splitPacket(data, atEOF)
+	if err != nil || token == nil || ID(token[indexOfMessageID]) != IDVER {
+		return advance, token, err
+	}
+	var ver VER
+	ver.unmarshalPayload(token[indexOfPayload:])
+	if !isProtocolVersionSupported(ver.ProtocolVersion()) {
+		return 0, nil, newUnsupportedProtocolVersionError(ver.ProtocolVersion())
+	}
+	return advance, token, nil
+}
+
+// splitPacket is a split function that returns each ERB packet, regardless of protocol version.
+func splitPacket(data []byte, _ bool) (advance int, token []byte, err error) {
 	if len(data) < indexOfPayloadLength+lengthOfPayloadLength {
 		return 0, nil, nil
 	}
@@ -39,6 +62,10 @@ func ScanPackets(data []byte, _ bool) (advance int, token []byte, err error) {
 	if data[0] != syncChar1 || data[1] != syncChar2 {
 		i := bytes.Index(data, []byte{syncChar1, syncChar2})
 		if i == -1 {
+			// keep a trailing first sync char, since the second sync char may not have been read yet
+			if data[len(data)-1] == syncChar1 {
+				return len(data) - 1, nil, nil
+			}
 			return len(data), nil, nil
 		}
 		return i, nil, nil
@@ -47,6 +74,10 @@ func ScanPackets(data []byte, _ bool) (advance int, token []byte, err error) {
 	lengthOfPayload := binary.LittleEndian.Uint16(
 		data[indexOfPayloadLength : indexOfPayloadLength+lengthOfPayloadLength],
 	)
+	// reject impossible lengths before waiting for the payload, a false sync word may claim up to 64 KB
+	if err := validatePayloadLength(ID(data[indexOfMessageID]), lengthOfPayload); err != nil {
+		return 0, nil, err
+	}
 	lengthOfPacket := calculateLengthOfPacket(lengthOfPayload)
 	if len(data) < lengthOfPacket {
 		return 0, nil, nil
@@ -59,7 +90,8 @@ func ScanPackets(data []byte, _ bool) (advance int, token []byte, err error) {
 	actualChecksum := binary.LittleEndian.Uint16(packet[calculateIndexOfChecksum(lengthOfPayload):])
 	if expectedChecksum != actualChecksum {
 		return 0, nil, fmt.Errorf(
-			"checksum mismatch (expected 0x%x but got 0x%x)",
+			"%w (expected 0x%x but got 0x%x)",
+			ErrChecksumMismatch,
 			expectedChecksum,
 			actualChecksum,
 		)
@@ -70,6 +102,19 @@ func ScanPackets(data []byte, _ bool) (advance int, token []byte, err error) {
 	return lengthOfPacket, packet, nil
 }
 
+// appendPacket appends an ERB packet with the provided message ID and payload to b.
+func appendPacket(b []byte, id ID, payload []byte) []byte {
+	start := len(b)
+	b = append(b, syncChar1, syncChar2, byte(id), 0, 0)
+	binary.LittleEndian.PutUint16(
+		b[start+indexOfPayloadLength:start+indexOfPayloadLength+lengthOfPayloadLength],
+		uint16(len(payload)),
+	)
+	b = append(b, payload...)
+	checksum := fletcher(b[start+indexOfMessageID:])
+	return append(b, byte(checksum), byte(checksum>>8))
+}
+
 func fletcher(data []byte) uint16 {
 	var a, b uint8
 	for i := 0; i < len(data); i++ {
@@ -79,6 +124,40 @@ func fletcher(data []byte) uint16 {
 	return uint16(b)<<8 | uint16(a)
 }
 
+// maxLengthOfExtension is the maximum length of trailing fields accepted beyond the known layout of a payload.
+const maxLengthOfExtension = 256
+
+// validatePayloadLength validates the payload length from the header of a packet, before the payload is read.
+func validatePayloadLength(messageID ID, lengthOfPayload uint16) error {
+	var maxLength int
+	switch messageID {
+	case IDVER:
+		maxLength = lengthOfVER + maxLengthOfExtension
+	case IDPOS:
+		maxLength = lengthOfPOS + maxLengthOfExtension
+	case IDSTAT:
+		maxLength = lengthOfSTAT + maxLengthOfExtension
+	case IDDOPS:
+		maxLength = lengthOfDOPS + maxLengthOfExtension
+	case IDVEL:
+		maxLength = lengthOfVEL + maxLengthOfExtension
+	case IDSVI:
+		maxLength = indexOfSV + lengthOfSV*math.MaxUint8 + maxLengthOfExtension
+	default:
+		return nil // allow unknown packets
+	}
+	if int(lengthOfPayload) > maxLength {
+		return fmt.Errorf(
+			"validate %v payload: illegal length %d (expected maximum %d)", messageID, lengthOfPayload, maxLength,
+		)
+	}
+	return nil
+}
+
+// validatePayload validates the length of a payload.
+//
+// Payloads longer than the known layout of a message are allowed, since newer protocol versions may extend the
+// payloads with trailing fields.
 func validatePayload(messageID ID, payload []byte) error {
 	switch messageID {
 	case IDVER:
@@ -99,50 +178,40 @@ func validatePayload(messageID ID, payload []byte) error {
 }
 
 func validatePayloadVER(payload []byte) error {
-	if len(payload) != lengthOfVER {
-		return fmt.Errorf("validate VER payload: illegal length %d (expected %d)", len(payload), lengthOfVER)
-	}
-	var ver VER
-	ver.unmarshalPayload(payload)
-	isProtocolVersionSupported :=
-		ver.High == SupportedProtocolVersionHigh &&
-			ver.Medium == SupportedProtocolVersionMedium &&
-			ver.Low == SupportedProtocolVersionLow
-	if !isProtocolVersionSupported {
-		return fmt.Errorf(
-			"validate VER payload: unsupported protocol version %d.%d.%d",
-			ver.High,
-			ver.Medium,
-			ver.Low,
-		)
+	if len(payload) < lengthOfVER {
+		return fmt.Errorf("validate %v payload: illegal length %d (expected minimum %d)", IDVER, len(payload), lengthOfVER)
 	}
 	return nil
 }
 
 func validatePayloadPOS(payload []byte) error {
-	if len(payload) != lengthOfPOS {
-		return fmt.Errorf("validate %v payload: illegal length %d (expected %d)", IDPOS, len(payload), lengthOfPOS)
+	if len(payload) < lengthOfPOS {
+		return fmt.Errorf("validate %v payload: illegal length %d (expected minimum %d)", IDPOS, len(payload), lengthOfPOS)
 	}
 	return nil
 }
 
 func validatePayloadSTAT(payload []byte) error {
-	if len(payload) != lengthOfSTAT {
-		return fmt.Errorf("validate %v payload: illegal length %d (expected %d)", IDSTAT, len(payload), lengthOfSTAT)
+	if len(payload) < lengthOfSTAT {
+		return fmt.Errorf(
+			"validate %v payload: illegal length %d (expected minimum %d)", IDSTAT, len(payload), lengthOfSTAT,
+		)
 	}
 	return nil
 }
 
 func validatePayloadDOPS(payload []byte) error {
-	if len(payload) != lengthOfDOPS {
-		return fmt.Errorf("validate %v payload: illegal length %d (expected %d)", IDDOPS, len(payload), lengthOfDOPS)
+	if len(payload) < lengthOfDOPS {
+		return fmt.Errorf(
+			"validate %v payload: illegal length %d (expected minimum %d)", IDDOPS, len(payload), lengthOfDOPS,
+		)
 	}
 	return nil
 }
 
 func validatePayloadVEL(payload []byte) error {
-	if len(payload) != lengthOfVEL {
-		return fmt.Errorf("validate %v payload: illegal length %d (expected %d)", IDVEL, len(payload), lengthOfVEL)
+	if len(payload) < lengthOfVEL {
+		return fmt.Errorf("validate %v payload: illegal length %d (expected minimum %d)", IDVEL, len(payload), lengthOfVEL)
 	}
 	return nil
 }
@@ -151,14 +220,36 @@ func validatePayloadSVI(payload []byte) error {
 	const minLength = indexOfNumSVs + lengthOfNumSVs
 	if len(payload) < minLength {
 		return fmt.Errorf(
-			"validate %v payload: illegal length %d (expected minimum %d)", IDSVI, len(payload), lengthOfSVI,
+			"validate %v payload: illegal length %d (expected minimum %d)", IDSVI, len(payload), minLength,
 		)
 	}
 	var svi SVI
 	svi.unmarshalPayload(payload)
 	expectedLength := minLength + lengthOfSV*int(svi.NumSVs)
-	if len(payload) != expectedLength {
-		return fmt.Errorf("validate %v payload: illegal length %d (expected %d)", IDSVI, len(payload), expectedLength)
+	if len(payload) < expectedLength {
+		return fmt.Errorf(
+			"validate %v payload: illegal length %d (expected minimum %d)", IDSVI, len(payload), expectedLength,
+		)
 	}
 	return nil
 }
+
+// lengthOfKnownPayload returns the length of the known layout of a validated payload.
+func lengthOfKnownPayload(messageID ID, payload []byte) int {
+	switch messageID {
+	case IDVER:
+		return lengthOfVER
+	case IDPOS:
+		return lengthOfPOS
+	case IDSTAT:
+		return lengthOfSTAT
+	case IDDOPS:
+		return lengthOfDOPS
+	case IDVEL:
+		return lengthOfVEL
+	case IDSVI:
+		return indexOfSV + lengthOfSV*int(payload[indexOfNumSVs])
+	default:
+		return 0
+	}
+}
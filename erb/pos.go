@@ -3,6 +3,7 @@ package erb
 import (
 	"encoding/binary"
 	"math"
+	"time"
 )
 
 // structure of POS message.
@@ -30,19 +31,26 @@ var _ [lengthOfPOS]struct{} = [indexOfPOSVerticalAccuracy + lengthOfPOSVerticalA
 // Longitude, latitude, altitude and information about accuracy estimate.
 type POS struct {
 	// TimeGPS is the time of week in milliseconds of the navigation epoch.
-	TimeGPS uint32
+	TimeGPS uint32 `json:"time_gps"`
 	// Longitude component (degrees).
-	LongitudeDegrees float64
+	LongitudeDegrees float64 `json:"longitude_degrees"`
 	// Latitude component (degrees).
-	LatitudeDegrees float64
+	LatitudeDegrees float64 `json:"latitude_degrees"`
 	// AltitudeEllipsoid is the height above ellipsoid (m).
-	AltitudeEllipsoidMeters float64
+	AltitudeEllipsoidMeters float64 `json:"altitude_ellipsoid_meters"`
 	// AltitudeMeanSeaLevel is the height above mean sea level (m).
-	AltitudeMeanSeaLevelMeters float64
+	AltitudeMeanSeaLevelMeters float64 `json:"altitude_mean_sea_level_meters"`
 	// HorizontalAccuracyMillimeters is the horizontal accuracy estimate (mm).
-	HorizontalAccuracyMillimeters uint32
+	HorizontalAccuracyMillimeters uint32 `json:"horizontal_accuracy_millimeters"`
 	// VerticalAccuracy is the vertical accuracy estimate (mm).
-	VerticalAccuracyMillimeters uint32
+	VerticalAccuracyMillimeters uint32 `json:"vertical_accuracy_millimeters"`
+}
+
+// Time returns the UTC time of the navigation epoch, given the GPS week of the epoch.
+//
+// POS messages carry no GPS week, use the week of the STAT message of the same epoch or ResolveWeekGPS.
+func (p *POS) Time(weekGPS uint16) time.Time {
+	return UTCTime(weekGPS, p.TimeGPS)
 }
 
 func (p *POS) unmarshalPayload(b []byte) {
@@ -75,3 +83,48 @@ func (p *POS) unmarshalPayload(b []byte) {
 		b[indexOfPOSVerticalAccuracy : indexOfPOSVerticalAccuracy+lengthOfPOSVerticalAccuracy],
 	)
 }
+
+func (p *POS) marshalPayload(b []byte) {
+	_ = b[lengthOfPOS-1] // early bounds check
+	binary.LittleEndian.PutUint32(b[indexOfTimeGPS:indexOfTimeGPS+lengthOfTimeGPS], p.TimeGPS)
+	binary.LittleEndian.PutUint64(
+		b[indexOfPOSLongitude:indexOfPOSLongitude+lengthOfPOSLongitude],
+		math.Float64bits(p.LongitudeDegrees),
+	)
+	binary.LittleEndian.PutUint64(
+		b[indexOfPOSLatitude:indexOfPOSLatitude+lengthOfPOSLatitude],
+		math.Float64bits(p.LatitudeDegrees),
+	)
+	binary.LittleEndian.PutUint64(
+		b[indexOfPOSAltitudeEllipsoid:indexOfPOSAltitudeEllipsoid+lengthOfPOSAltitudeEllipsoid],
+		math.Float64bits(p.AltitudeEllipsoidMeters),
+	)
+	binary.LittleEndian.PutUint64(
+		b[indexOfPOSAltitudeMeanSeaLevel:indexOfPOSAltitudeMeanSeaLevel+lengthOfPOSAltitudeMeanSeaLevel],
+		math.Float64bits(p.AltitudeMeanSeaLevelMeters),
+	)
+	binary.LittleEndian.PutUint32(
+		b[indexOfPOSHorizontalAccuracy:indexOfPOSHorizontalAccuracy+lengthOfPOSHorizontalAccuracy],
+		p.HorizontalAccuracyMillimeters,
+	)
+	binary.LittleEndian.PutUint32(
+		b[indexOfPOSVerticalAccuracy:indexOfPOSVerticalAccuracy+lengthOfPOSVerticalAccuracy],
+		p.VerticalAccuracyMillimeters,
+	)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the ERB payload of the POS message.
+func (p *POS) MarshalBinary() ([]byte, error) {
+	b := make([]byte, lengthOfPOS)
+	p.marshalPayload(b)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and parses the ERB payload of a POS message.
+func (p *POS) UnmarshalBinary(b []byte) error {
+	if err := validatePayloadPOS(b); err != nil {
+		return err
+	}
+	p.unmarshalPayload(b)
+	return nil
+}
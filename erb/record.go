@@ -0,0 +1,187 @@
+package erb
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Recording file format.
+//
+// A recording starts with a header, followed by records of raw stream bytes. Each record contains a single ERB
+// packet, or the bytes between packets, together with the host time when the bytes were received:
+//
+//	header: magic "ERBLOG" | version uint16
+//	record: receive time int64 (Unix nanoseconds) | length uint32 | data
+//
+// All integers are little-endian.
+const (
+	recordingMagic        = "ERBLOG"
+	recordingVersion      = 1
+	lengthOfRecordingHead = 8
+	indexOfRecordTime     = 0
+	lengthOfRecordTime    = 8
+	indexOfRecordLength   = indexOfRecordTime + lengthOfRecordTime
+	lengthOfRecordLength  = 4
+	lengthOfRecordHeader  = indexOfRecordLength + lengthOfRecordLength
+	maxLengthOfRecordData = maxLengthOfPacket
+)
+
+// Record is a chunk of a recorded ERB stream.
+type Record struct {
+	// Time is the host time when the data was received.
+	Time time.Time
+	// Data is the raw stream data, usually a single ERB packet.
+	Data []byte
+}
+
+// GPSTime returns the GPS time of week in milliseconds of the packet in the record.
+//
+// Returns false when the record does not contain a valid packet with a time of week.
+func (r *Record) GPSTime() (uint32, bool) {
+	id, payload, ok := r.packet()
+	if !ok {
+		return 0, false
+	}
+	switch id {
+	case IDPOS, IDSTAT, IDDOPS, IDVEL, IDSVI:
+		return binary.LittleEndian.Uint32(payload[indexOfTimeGPS : indexOfTimeGPS+lengthOfTimeGPS]), true
+	default:
+		return 0, false
+	}
+}
+
+// WeekGPS returns the GPS week number of the packet in the record.
+//
+// Returns false when the record does not contain a valid STAT packet.
+func (r *Record) WeekGPS() (uint16, bool) {
+	id, payload, ok := r.packet()
+	if !ok || id != IDSTAT {
+		return 0, false
+	}
+	var stat STAT
+	stat.unmarshalPayload(payload)
+	return stat.WeekGPS, true
+}
+
+func (r *Record) packet() (ID, []byte, bool) {
+	advance, token, err := splitPacket(r.Data, true)
+	if err != nil || token == nil || advance != len(r.Data) {
+		return 0, nil, false
+	}
+	lengthOfPayload := binary.LittleEndian.Uint16(token[indexOfPayloadLength : indexOfPayloadLength+lengthOfPayloadLength])
+	return ID(token[indexOfMessageID]), token[indexOfPayload : indexOfPayload+lengthOfPayload], true
+}
+
+// RecordWriter writes records of a recording to a stream.
+type RecordWriter struct {
+	w             io.Writer
+	b             []byte
+	writtenHeader bool
+}
+
+// NewRecordWriter returns a new RecordWriter that writes a recording to w.
+func NewRecordWriter(w io.Writer) *RecordWriter {
+	return &RecordWriter{w: w}
+}
+
+// WriteRecord writes a record, preceded by the recording header if this is the first record.
+func (w *RecordWriter) WriteRecord(record Record) error {
+	if len(record.Data) > maxLengthOfRecordData {
+		return fmt.Errorf("write record: data length %d exceeds maximum %d", len(record.Data), maxLengthOfRecordData)
+	}
+	w.b = w.b[:0]
+	if !w.writtenHeader {
+		w.b = append(w.b, recordingMagic...)
+		w.b = append(w.b, recordingVersion, 0)
+	}
+	start := len(w.b)
+	w.b = append(w.b, make([]byte, lengthOfRecordHeader)...)
+	binary.LittleEndian.PutUint64(
+		w.b[start+indexOfRecordTime:start+indexOfRecordTime+lengthOfRecordTime], uint64(record.Time.UnixNano()),
+	)
+	binary.LittleEndian.PutUint32(
+		w.b[start+indexOfRecordLength:start+indexOfRecordLength+lengthOfRecordLength], uint32(len(record.Data)),
+	)
+	w.b = append(w.b, record.Data...)
+	if _, err := w.w.Write(w.b); err != nil {
+		return fmt.Errorf("write record: %w", err)
+	}
+	w.writtenHeader = true
+	return nil
+}
+
+// RecordReader reads records of a recording from a stream.
+type RecordReader struct {
+	r          *bufio.Reader
+	header     [lengthOfRecordHeader]byte
+	data       []byte
+	readHeader bool
+	offset     int64
+}
+
+// NewRecordReader returns a new RecordReader that reads a recording from r.
+func NewRecordReader(r io.Reader) *RecordReader {
+	return &RecordReader{r: bufio.NewReader(r)}
+}
+
+// newRecordReaderAt returns a new RecordReader that reads a recording from r, positioned at the record at offset.
+func newRecordReaderAt(r io.Reader, offset int64) *RecordReader {
+	return &RecordReader{r: bufio.NewReader(r), offset: offset, readHeader: offset > 0}
+}
+
+// ReadRecord reads the next record of the recording.
+//
+// The data of the record is only valid until the next call to ReadRecord. At the end of the recording, io.EOF is
+// returned.
+func (r *RecordReader) ReadRecord() (Record, error) {
+	if !r.readHeader {
+		var header [lengthOfRecordingHead]byte
+		if _, err := io.ReadFull(r.r, header[:]); err != nil {
+			return Record{}, fmt.Errorf("read recording header: %w", noEOF(err))
+		}
+		if !bytes.Equal(header[:len(recordingMagic)], []byte(recordingMagic)) {
+			return Record{}, fmt.Errorf("read recording header: invalid magic %q", header[:len(recordingMagic)])
+		}
+		if version := binary.LittleEndian.Uint16(header[len(recordingMagic):]); version != recordingVersion {
+			return Record{}, fmt.Errorf("read recording header: unsupported version %d", version)
+		}
+		r.readHeader = true
+		r.offset += lengthOfRecordingHead
+	}
+	if _, err := io.ReadFull(r.r, r.header[:]); err != nil {
+		if err == io.EOF {
+			return Record{}, io.EOF
+		}
+		return Record{}, fmt.Errorf("read record: %w", noEOF(err))
+	}
+	length := binary.LittleEndian.Uint32(r.header[indexOfRecordLength : indexOfRecordLength+lengthOfRecordLength])
+	if length > maxLengthOfRecordData {
+		return Record{}, fmt.Errorf("read record: data length %d exceeds maximum %d", length, maxLengthOfRecordData)
+	}
+	if cap(r.data) < int(length) {
+		r.data = make([]byte, length)
+	}
+	r.data = r.data[:length]
+	if _, err := io.ReadFull(r.r, r.data); err != nil {
+		return Record{}, fmt.Errorf("read record: %w", noEOF(err))
+	}
+	r.offset += lengthOfRecordHeader + int64(length)
+	nanos := int64(binary.LittleEndian.Uint64(r.header[indexOfRecordTime : indexOfRecordTime+lengthOfRecordTime]))
+	return Record{Time: time.Unix(0, nanos), Data: r.data}, nil
+}
+
+// Offset returns the offset in the stream of the next record.
+func (r *RecordReader) Offset() int64 {
+	return r.offset
+}
+
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
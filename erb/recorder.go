@@ -0,0 +1,80 @@
+package erb
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Recorder is an io.Reader that records the stream read from an underlying reader.
+//
+// The stream is recorded with the host receive time of each packet, for later replay with a Replayer. A Recorder is
+// typically placed between a connection and a Scanner:
+//
+//	sc := erb.NewScanner(erb.NewRecorder(conn, file))
+type Recorder struct {
+	r   io.Reader
+	w   *RecordWriter
+	buf []byte
+	err error
+	now func() time.Time
+}
+
+// NewRecorder returns a new Recorder that reads from r and records to w.
+func NewRecorder(r io.Reader, w io.Writer) *Recorder {
+	return &Recorder{r: r, w: NewRecordWriter(w), now: time.Now}
+}
+
+// Read implements io.Reader.
+//
+// Each complete packet read is recorded with the time of the read that completed the packet. Recording errors are
+// returned from Read, together with any data read.
+func (r *Recorder) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.buf = append(r.buf, p[:n]...)
+		if recordErr := r.record(r.now(), err != nil); recordErr != nil {
+			r.err = fmt.Errorf("record: %w", recordErr)
+			return n, r.err
+		}
+	} else if err != nil && len(r.buf) > 0 {
+		if recordErr := r.record(r.now(), true); recordErr != nil {
+			r.err = fmt.Errorf("record: %w", recordErr)
+			return n, r.err
+		}
+	}
+	return n, err
+}
+
+// record writes the complete packets in the buffer, and any bytes between packets, as records.
+func (r *Recorder) record(t time.Time, flush bool) error {
+	var start int
+	for start < len(r.buf) {
+		advance, _, err := splitPacket(r.buf[start:], false)
+		if err != nil {
+			// record the sync word of a corrupt packet as raw data, the reader decides how to handle it
+			advance = lengthOfSyncWord
+		}
+		if advance == 0 {
+			break
+		}
+		if advance > maxLengthOfRecordData {
+			advance = maxLengthOfRecordData
+		}
+		if err := r.w.WriteRecord(Record{Time: t, Data: r.buf[start : start+advance]}); err != nil {
+			return err
+		}
+		start += advance
+	}
+	if flush && start < len(r.buf) {
+		if err := r.w.WriteRecord(Record{Time: t, Data: r.buf[start:]}); err != nil {
+			return err
+		}
+		start = len(r.buf)
+	}
+	r.buf = append(r.buf[:0], r.buf[start:]...)
+	return nil
+}
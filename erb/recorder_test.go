@@ -0,0 +1,213 @@
+package erb
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRecorder_HexDump(t *testing.T) {
+	data := loadHexDump(t, "testdata/hexdump.asta")
+	var recording bytes.Buffer
+	recorder := NewRecorder(iotest.HalfReader(bytes.NewReader(data)), &recording)
+	start := time.Unix(1600000000, 0)
+	var reads int
+	recorder.now = func() time.Time {
+		reads++
+		return start.Add(time.Duration(reads) * time.Millisecond)
+	}
+	sc := NewScanner(recorder)
+	var scanned int
+	for sc.Scan() {
+		scanned++
+	}
+	assert.NilError(t, sc.Err())
+	// the recording contains the exact stream, with each packet in a record of its own
+	rr := NewRecordReader(bytes.NewReader(recording.Bytes()))
+	var replayed []byte
+	var packets int
+	var prev time.Time
+	for {
+		record, err := rr.ReadRecord()
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		assert.Assert(t, !record.Time.Before(prev))
+		prev = record.Time
+		if _, token, err := ScanPackets(record.Data, true); err == nil && len(token) == len(record.Data) {
+			packets++
+		}
+		replayed = append(replayed, record.Data...)
+	}
+	assert.DeepEqual(t, data, replayed)
+	assert.Equal(t, scanned, packets)
+	// replaying the recording gives the exact stream
+	replayer := NewReplayer(bytes.NewReader(recording.Bytes()), ReplayerConfig{})
+	actual, err := ioutil.ReadAll(replayer)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, data, actual)
+}
+
+func TestRecordReader_InvalidHeader(t *testing.T) {
+	rr := NewRecordReader(bytes.NewReader([]byte("NOTALOG0")))
+	_, err := rr.ReadRecord()
+	assert.Error(t, err, `read recording header: invalid magic "NOTALO"`)
+}
+
+func TestReplayer_Realtime(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	recording := newTestRecording(t, start, 2059, 100000, 5)
+	replayer := NewReplayer(bytes.NewReader(recording), ReplayerConfig{Realtime: true, Speed: 2})
+	now := time.Unix(0, 0)
+	var sleeps []time.Duration
+	replayer.now = func() time.Time {
+		return now
+	}
+	replayer.sleep = func(d time.Duration) {
+		sleeps = append(sleeps, d)
+		now = now.Add(d)
+	}
+	sc := NewScanner(replayer)
+	var times []uint32
+	for sc.Scan() {
+		if sc.ID() == IDPOS {
+			times = append(times, sc.POS().TimeGPS)
+		}
+	}
+	assert.NilError(t, sc.Err())
+	assert.DeepEqual(t, []uint32{100000, 100200, 100400, 100600, 100800}, times)
+	// epochs recorded 200ms apart are replayed 100ms apart at double speed
+	assert.DeepEqual(t, []time.Duration{
+		100 * time.Millisecond,
+		100 * time.Millisecond,
+		100 * time.Millisecond,
+		100 * time.Millisecond,
+	}, sleeps)
+}
+
+func TestReplayer_Close(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	recording := newTestRecording(t, start, 2059, 100000, 2)
+	// epochs recorded 200ms apart are replayed 200s apart
+	replayer := NewReplayer(bytes.NewReader(recording), ReplayerConfig{Realtime: true, Speed: 0.001})
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(ioutil.Discard, replayer)
+		errCh <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.NilError(t, replayer.Close())
+	select {
+	case err := <-errCh:
+		assert.Equal(t, ErrReplayerClosed, err)
+	case <-time.After(time.Second):
+		t.Fatal("replayer not interrupted by close")
+	}
+	_, err := replayer.Read(make([]byte, 1))
+	assert.Equal(t, ErrReplayerClosed, err)
+}
+
+func TestReplayer_SeekGPS(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		weekGPS  uint16
+		timeGPS  uint32
+		expected []uint32
+		err      string
+	}{
+		{
+			name:     "exact epoch",
+			weekGPS:  2059,
+			timeGPS:  100400,
+			expected: []uint32{100400, 100600, 100800},
+		},
+		{
+			name:     "between epochs",
+			weekGPS:  2059,
+			timeGPS:  100500,
+			expected: []uint32{100600, 100800},
+		},
+		{
+			name:     "previous week",
+			weekGPS:  2058,
+			timeGPS:  500000000,
+			expected: []uint32{100000, 100200, 100400, 100600, 100800},
+		},
+		{
+			name:    "after recording",
+			weekGPS: 2059,
+			timeGPS: 100900,
+			err:     "seek GPS time 2059:100900: not found in recording",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			recording := newTestRecording(t, time.Unix(1600000000, 0), 2059, 100000, 5)
+			replayer := NewReplayer(bytes.NewReader(recording), ReplayerConfig{})
+			err := replayer.SeekGPS(tt.weekGPS, tt.timeGPS)
+			if tt.err != "" {
+				assert.Error(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			sc := NewScanner(replayer)
+			var times []uint32
+			for sc.Scan() {
+				if sc.ID() == IDPOS {
+					times = append(times, sc.POS().TimeGPS)
+				}
+			}
+			assert.NilError(t, sc.Err())
+			assert.DeepEqual(t, tt.expected, times)
+		})
+	}
+}
+
+func TestReplayer_Loop(t *testing.T) {
+	recording := newTestRecording(t, time.Unix(1600000000, 0), 2059, 100000, 5)
+	replayer := NewReplayer(bytes.NewReader(recording), ReplayerConfig{Loop: true})
+	assert.NilError(t, replayer.SeekGPS(2059, 100600))
+	sc := NewScanner(replayer)
+	var times []uint32
+	for len(times) < 5 && sc.Scan() {
+		if sc.ID() == IDPOS {
+			times = append(times, sc.POS().TimeGPS)
+		}
+	}
+	assert.NilError(t, sc.Err())
+	assert.DeepEqual(t, []uint32{100600, 100800, 100600, 100800, 100600}, times)
+}
+
+func TestReplayer_LoopEmpty(t *testing.T) {
+	var recording bytes.Buffer
+	replayer := NewReplayer(bytes.NewReader(recording.Bytes()), ReplayerConfig{Loop: true})
+	_, err := replayer.Read(make([]byte, 1))
+	assert.ErrorContains(t, err, "read recording header")
+}
+
+// newTestRecording returns a recording of n epochs, received 200ms apart, where each epoch has a POS message
+// followed by a STAT message.
+func newTestRecording(t *testing.T, start time.Time, weekGPS uint16, timeGPS uint32, n int) []byte {
+	t.Helper()
+	var recording bytes.Buffer
+	w := NewRecordWriter(&recording)
+	var packet bytes.Buffer
+	enc := NewEncoder(&packet)
+	for i := 0; i < n; i++ {
+		epochGPS := timeGPS + uint32(i)*200
+		receiveTime := start.Add(time.Duration(i) * 200 * time.Millisecond)
+		packet.Reset()
+		assert.NilError(t, enc.EncodePOS(POS{TimeGPS: epochGPS}))
+		assert.NilError(t, w.WriteRecord(Record{Time: receiveTime, Data: packet.Bytes()}))
+		packet.Reset()
+		assert.NilError(t, enc.EncodeSTAT(STAT{TimeGPS: epochGPS, WeekGPS: weekGPS, FixType: FixTypeSingle}))
+		assert.NilError(t, w.WriteRecord(Record{Time: receiveTime, Data: packet.Bytes()}))
+	}
+	return recording.Bytes()
+}
@@ -0,0 +1,198 @@
+package erb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrReplayerClosed is the error returned when reading from a closed Replayer.
+var ErrReplayerClosed = errors.New("erb: replayer closed")
+
+// Replayer is an io.Reader that replays a recording made by a Recorder.
+//
+// By default, the recording is replayed as fast as possible. In realtime mode, the replay is paced by the recorded
+// receive times, and Close may be called from another goroutine to interrupt a Read that waits for the next record.
+type Replayer struct {
+	r         io.ReadSeeker
+	cfg       ReplayerConfig
+	rr        *RecordReader
+	start     int64
+	restart   bool
+	replayed  bool
+	data      []byte
+	err       error
+	paced     bool
+	firstTime time.Time
+	firstWall time.Time
+	now       func() time.Time
+	sleep     func(time.Duration)
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewReplayer returns a new Replayer that replays the recording in r, configured by cfg.
+func NewReplayer(r io.ReadSeeker, cfg ReplayerConfig) *Replayer {
+	cfg.setDefaults()
+	p := &Replayer{r: r, cfg: cfg, restart: true, now: time.Now, done: make(chan struct{})}
+	p.sleep = p.sleepUntilClosed
+	return p
+}
+
+// Read implements io.Reader.
+func (p *Replayer) Read(b []byte) (int, error) {
+	if p.isClosed() {
+		return 0, ErrReplayerClosed
+	}
+	for len(p.data) == 0 {
+		if p.err != nil {
+			return 0, p.err
+		}
+		p.err = p.next()
+	}
+	n := copy(b, p.data)
+	p.data = p.data[n:]
+	return n, nil
+}
+
+// next reads the next record, and waits until the record is due when replaying in realtime.
+func (p *Replayer) next() error {
+	if p.restart {
+		if _, err := p.r.Seek(p.start, io.SeekStart); err != nil {
+			return fmt.Errorf("replay: %w", err)
+		}
+		p.rr = newRecordReaderAt(p.r, p.start)
+		p.restart = false
+		p.replayed = false
+		p.paced = false
+	}
+	record, err := p.rr.ReadRecord()
+	if err == io.EOF {
+		if p.cfg.Loop && p.replayed {
+			p.restart = true
+			return nil
+		}
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("replay: %w", err)
+	}
+	p.replayed = true
+	if p.cfg.Realtime {
+		p.wait(record.Time)
+		if p.isClosed() {
+			return ErrReplayerClosed
+		}
+	}
+	p.data = record.Data
+	return nil
+}
+
+// wait until the record received at t is due.
+func (p *Replayer) wait(t time.Time) {
+	if !p.paced {
+		p.paced = true
+		p.firstTime = t
+		p.firstWall = p.now()
+		return
+	}
+	due := p.firstWall.Add(time.Duration(float64(t.Sub(p.firstTime)) / p.cfg.Speed))
+	if d := due.Sub(p.now()); d > 0 {
+		p.sleep(d)
+	}
+}
+
+// sleepUntilClosed sleeps for d, or until the replayer is closed.
+func (p *Replayer) sleepUntilClosed(d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-p.done:
+	}
+}
+
+// Close the replayer, interrupting any pending Read. The underlying reader is not closed.
+func (p *Replayer) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+	return nil
+}
+
+func (p *Replayer) isClosed() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// SeekGPS positions the replay at the first navigation epoch at or after the provided GPS week and time of week in
+// milliseconds.
+//
+// The GPS week of each epoch is resolved from the nearest STAT message of the recording. When looping, the replay
+// restarts from the seeked position.
+func (p *Replayer) SeekGPS(weekGPS uint16, timeGPS uint32) error {
+	p.restart = true
+	p.data = nil
+	p.err = nil
+	if _, err := p.r.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek GPS time %d:%d: %w", weekGPS, timeGPS, err)
+	}
+	target := millisecondsSinceEpochGPS(weekGPS, timeGPS)
+	rr := NewRecordReader(p.r)
+	var (
+		refWeekGPS uint16
+		refTimeGPS uint32
+		hasRef     bool
+		epochGPS   uint32
+		hasEpoch   bool
+		// epochs waiting for a STAT message to resolve their GPS week
+		pending []pendingEpoch
+	)
+	for {
+		offset := rr.Offset()
+		record, err := rr.ReadRecord()
+		if err == io.EOF {
+			return fmt.Errorf("seek GPS time %d:%d: not found in recording", weekGPS, timeGPS)
+		}
+		if err != nil {
+			return fmt.Errorf("seek GPS time %d:%d: %w", weekGPS, timeGPS, err)
+		}
+		recordGPS, ok := record.GPSTime()
+		if !ok {
+			continue
+		}
+		if !hasEpoch || recordGPS != epochGPS {
+			epochGPS, hasEpoch = recordGPS, true
+			pending = append(pending, pendingEpoch{offset: offset, timeGPS: recordGPS})
+		}
+		if week, ok := record.WeekGPS(); ok {
+			refWeekGPS, refTimeGPS, hasRef = week, recordGPS, true
+		}
+		if !hasRef {
+			continue
+		}
+		for _, epoch := range pending {
+			epochWeekGPS := ResolveWeekGPS(refWeekGPS, refTimeGPS, epoch.timeGPS)
+			if millisecondsSinceEpochGPS(epochWeekGPS, epoch.timeGPS) >= target {
+				p.start = epoch.offset
+				return nil
+			}
+		}
+		pending = pending[:0]
+	}
+}
+
+type pendingEpoch struct {
+	offset  int64
+	timeGPS uint32
+}
+
+func millisecondsSinceEpochGPS(weekGPS uint16, timeGPS uint32) int64 {
+	return int64(weekGPS)*MillisecondsPerWeek + int64(timeGPS)
+}
@@ -0,0 +1,17 @@
+package erb
+
+// ReplayerConfig configures a Replayer.
+type ReplayerConfig struct {
+	// Realtime paces the replay by the recorded receive times. Defaults to replaying as fast as possible.
+	Realtime bool
+	// Speed is a multiplier of the pace of realtime replay. Defaults to 1.
+	Speed float64
+	// Loop restarts the replay from the start position at the end of the recording.
+	Loop bool
+}
+
+func (c *ReplayerConfig) setDefaults() {
+	if c.Speed <= 0 {
+		c.Speed = 1
+	}
+}
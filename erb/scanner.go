@@ -3,14 +3,19 @@ package erb
 import (
 	"bufio"
 	"encoding/binary"
+	"fmt"
 	"io"
 )
 
 // Scanner provides a convenient interface for reading and parsing ERB messages from a stream.
 type Scanner struct {
 	sc      *bufio.Scanner
+	cfg     ScannerConfig
+	stats   ScannerStats
 	err     error
+	version ProtocolVersion
 	payload []byte
+	known   int
 	svIndex int
 	id      ID
 	ver     VER
@@ -24,9 +29,62 @@ type Scanner struct {
 
 // NewScanner returns a new Scanner to read from r.
 func NewScanner(r io.Reader) *Scanner {
-	sc := bufio.NewScanner(r)
-	sc.Split(ScanPackets)
-	return &Scanner{sc: sc}
+	return NewScannerWithConfig(r, ScannerConfig{})
+}
+
+// NewScannerWithConfig returns a new Scanner to read from r, configured by cfg.
+func NewScannerWithConfig(r io.Reader, cfg ScannerConfig) *Scanner {
+	c := &Scanner{sc: bufio.NewScanner(r), cfg: cfg, version: SupportedProtocolVersion()}
+	c.sc.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLengthOfPacket)
+	c.sc.Split(c.scanPackets)
+	return c
+}
+
+func (c *Scanner) scanPackets(data []byte, atEOF bool) (int, []byte, error) {
+	var advance int
+	for {
+		n, token, err := c.scanPacket(data[advance:], atEOF)
+		advance += n
+		// the bufio.Scanner reads more data before splitting again unless a token is returned, which blocks on a live
+		// stream and stops at EOF, so keep skipping until the next packet
+		if err != nil || token != nil || n == 0 {
+			return advance, token, err
+		}
+	}
+}
+
+func (c *Scanner) scanPacket(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	advance, token, err = splitPacket(data, atEOF)
+	if c.cfg.Resync && atEOF && err == nil && advance == 0 && len(data) > 0 {
+		// a false sync word with a corrupt length may hide packets before the end of the stream
+		err = fmt.Errorf("truncated packet (%d bytes at end of stream)", len(data))
+	}
+	if err != nil && c.cfg.Resync {
+		c.stats.DiscardedFrames++
+		if c.cfg.OnDiscard != nil {
+			c.cfg.OnDiscard(data[:discardedFrameLength(data)], err)
+		}
+		// skip the sync word of the discarded frame and resynchronize on the next sync word
+		advance, token, err = 1, nil, nil
+	}
+	if err == nil && token == nil {
+		c.stats.DiscardedBytes += uint64(advance)
+	}
+	return advance, token, err
+}
+
+// discardedFrameLength returns the length of the discarded frame at the start of data.
+func discardedFrameLength(data []byte) int {
+	if len(data) < indexOfPayload {
+		return len(data)
+	}
+	lengthOfPayload := binary.LittleEndian.Uint16(
+		data[indexOfPayloadLength : indexOfPayloadLength+lengthOfPayloadLength],
+	)
+	if n := calculateLengthOfPacket(lengthOfPayload); n < len(data) {
+		return n
+	}
+	return len(data)
 }
 
 // Scan advances the Scanner to the next message, whose ID will then be
@@ -47,10 +105,16 @@ func (c *Scanner) Scan() bool {
 		c.sc.Bytes()[indexOfPayloadLength : indexOfPayloadLength+lengthOfPayloadLength],
 	)
 	c.payload = c.sc.Bytes()[indexOfPayload : indexOfPayload+lengthOfPayload]
+	c.known = lengthOfKnownPayload(c.id, c.payload)
 	// assume the scan function has already validated packet types
 	switch c.id {
 	case IDVER:
 		c.ver.unmarshalPayload(c.payload)
+		if !c.cfg.isProtocolVersionAccepted(c.ver.ProtocolVersion()) {
+			c.err = newUnsupportedProtocolVersionError(c.ver.ProtocolVersion())
+			return false
+		}
+		c.version = c.ver.ProtocolVersion()
 	case IDPOS:
 		c.pos.unmarshalPayload(c.payload)
 	case IDSTAT:
@@ -69,6 +133,45 @@ func (c *Scanner) Scan() bool {
 	return true
 }
 
+// TimeGPS returns the time of week in milliseconds of the navigation epoch of the current message.
+//
+// TimeGPS returns false for messages that are not part of a navigation epoch, such as VER and unknown messages.
+func (c *Scanner) TimeGPS() (uint32, bool) {
+	switch c.id {
+	case IDPOS:
+		return c.pos.TimeGPS, true
+	case IDSTAT:
+		return c.stat.TimeGPS, true
+	case IDDOPS:
+		return c.dops.TimeGPS, true
+	case IDVEL:
+		return c.vel.TimeGPS, true
+	case IDSVI:
+		return c.svi.TimeGPS, true
+	default:
+		return 0, false
+	}
+}
+
+// Stats returns the error statistics of the scanner.
+func (c *Scanner) Stats() ScannerStats {
+	return c.stats
+}
+
+// ProtocolVersion returns the protocol version of the stream, as reported by the last VER message.
+//
+// Before the first VER message, the supported protocol version is assumed.
+func (c *Scanner) ProtocolVersion() ProtocolVersion {
+	return c.version
+}
+
+// Extension returns any trailing fields of the current payload, beyond the known layout of the message.
+//
+// Trailing fields are added to payloads by newer protocol versions. For unknown messages, the full payload is returned.
+func (c *Scanner) Extension() []byte {
+	return c.payload[c.known:]
+}
+
 func (c *Scanner) Err() error {
 	if c.err == io.EOF {
 		return nil
@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -77,3 +79,135 @@ func loadHexDump(t *testing.T, filename string) []byte {
 	assert.NilError(t, sc.Err())
 	return data
 }
+
+func TestScanner_Resync(t *testing.T) {
+	data := loadHexDump(t, "testdata/hexdump.asta")
+	// the recording ends with a truncated packet, keep only the complete packets
+	var lengthOfCompletePackets int
+	sc := NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		lengthOfCompletePackets += len(sc.Bytes())
+	}
+	assert.NilError(t, sc.Err())
+	data = data[:lengthOfCompletePackets]
+	// the second packet of the recording is a POS packet, corrupt its payload
+	const indexOfSecondPacket = 14
+	assert.Equal(t, IDPOS, ID(data[indexOfSecondPacket+indexOfMessageID]))
+	corrupt := append([]byte("garbage"), data...)
+	corrupt[len("garbage")+indexOfSecondPacket+indexOfPayload] ^= 0xff
+	t.Run("strict", func(t *testing.T) {
+		sc := NewScanner(bytes.NewReader(corrupt))
+		assert.Assert(t, sc.Scan())
+		assert.Equal(t, IDVER, sc.ID())
+		assert.Assert(t, !sc.Scan())
+		assert.ErrorContains(t, sc.Err(), "checksum mismatch")
+		assert.Assert(t, errors.Is(sc.Err(), ErrChecksumMismatch))
+	})
+	t.Run("resync", func(t *testing.T) {
+		var discarded [][]byte
+		sc := NewScannerWithConfig(bytes.NewReader(corrupt), ScannerConfig{
+			Resync: true,
+			OnDiscard: func(frame []byte, err error) {
+				assert.ErrorContains(t, err, "checksum mismatch")
+				discarded = append(discarded, append([]byte(nil), frame...))
+			},
+		})
+		var ids []ID
+		for sc.Scan() {
+			ids = append(ids, sc.ID())
+		}
+		assert.NilError(t, sc.Err())
+		assert.DeepEqual(t, []ID{IDVER, IDSTAT, IDDOPS, IDVEL, IDSVI}, ids[:5])
+		assert.Equal(t, 1, len(discarded))
+		assert.Equal(t, calculateLengthOfPacket(lengthOfPOS), len(discarded[0]))
+		assert.Equal(t, uint64(1), sc.Stats().DiscardedFrames)
+		assert.Equal(t, uint64(len("garbage")+calculateLengthOfPacket(lengthOfPOS)), sc.Stats().DiscardedBytes)
+	})
+}
+
+func TestScanner_ResyncFalseLength(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	assert.NilError(t, enc.EncodePOS(POS{TimeGPS: 1000}))
+	// a false sync word of an SVI packet with a corrupt length, followed by a valid packet
+	data := append([]byte{syncChar1, syncChar2, byte(IDSVI), 0xff, 0xff}, buf.Bytes()...)
+	r, w := io.Pipe()
+	go func() {
+		// keep the stream open, as a live connection would
+		_, _ = w.Write(data)
+	}()
+	defer r.Close()
+	var discarded int
+	sc := NewScannerWithConfig(r, ScannerConfig{
+		Resync: true,
+		OnDiscard: func(_ []byte, err error) {
+			assert.ErrorContains(t, err, "illegal length 65535")
+			discarded++
+		},
+	})
+	assert.Assert(t, sc.Scan())
+	assert.Equal(t, IDPOS, sc.ID())
+	assert.Equal(t, uint32(1000), sc.POS().TimeGPS)
+	assert.Equal(t, 1, discarded)
+}
+
+func TestScanner_ProtocolVersion(t *testing.T) {
+	// a stream of a newer protocol version, with an extended POS payload
+	newerVersion := VER{High: 0, Medium: 2, Low: 1}
+	pos := POS{TimeGPS: 1000, LatitudeDegrees: 57.7, LongitudeDegrees: 12.7}
+	posPayload, err := pos.MarshalBinary()
+	assert.NilError(t, err)
+	extension := []byte{0x01, 0x02, 0x03, 0x04}
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	assert.NilError(t, enc.EncodeVER(newerVersion))
+	assert.NilError(t, enc.EncodePacket(IDPOS, append(posPayload, extension...)))
+	t.Run("default", func(t *testing.T) {
+		sc := NewScanner(bytes.NewReader(buf.Bytes()))
+		assert.Equal(t, SupportedProtocolVersion(), sc.ProtocolVersion())
+		assert.Assert(t, sc.Scan())
+		assert.Equal(t, IDVER, sc.ID())
+		assert.Equal(t, "0.2.1", sc.ProtocolVersion().String())
+		assert.Assert(t, sc.Scan())
+		assert.Equal(t, IDPOS, sc.ID())
+		assert.Equal(t, pos, sc.POS())
+		assert.DeepEqual(t, extension, sc.Extension())
+		assert.Assert(t, !sc.Scan())
+		assert.NilError(t, sc.Err())
+	})
+	t.Run("configured", func(t *testing.T) {
+		sc := NewScannerWithConfig(bytes.NewReader(buf.Bytes()), ScannerConfig{
+			ProtocolVersions: []ProtocolVersion{SupportedProtocolVersion()},
+		})
+		assert.Assert(t, !sc.Scan())
+		assert.Error(t, sc.Err(), "validate VER payload: unsupported protocol version 0.2.1")
+	})
+	t.Run("incompatible", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NilError(t, NewEncoder(&buf).EncodeVER(VER{High: 1}))
+		sc := NewScanner(&buf)
+		assert.Assert(t, !sc.Scan())
+		assert.Error(t, sc.Err(), "validate VER payload: unsupported protocol version 1.0.0")
+	})
+	t.Run("configured incompatible", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NilError(t, NewEncoder(&buf).EncodeVER(VER{High: 1}))
+		sc := NewScannerWithConfig(&buf, ScannerConfig{ProtocolVersions: []ProtocolVersion{{High: 1}}})
+		assert.Assert(t, sc.Scan())
+		assert.Equal(t, "1.0.0", sc.ProtocolVersion().String())
+	})
+	t.Run("split function", func(t *testing.T) {
+		sc := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+		sc.Split(ScanPackets)
+		assert.Assert(t, sc.Scan())
+		assert.Assert(t, sc.Scan())
+		assert.Assert(t, !sc.Scan())
+		assert.NilError(t, sc.Err())
+		var incompatible bytes.Buffer
+		assert.NilError(t, NewEncoder(&incompatible).EncodeVER(VER{High: 1}))
+		sc = bufio.NewScanner(&incompatible)
+		sc.Split(ScanPackets)
+		assert.Assert(t, !sc.Scan())
+		assert.Error(t, sc.Err(), "validate VER payload: unsupported protocol version 1.0.0")
+	})
+}
@@ -0,0 +1,39 @@
+package erb
+
+// ScannerConfig configures a Scanner.
+type ScannerConfig struct {
+	// Resync enables a tolerant mode, where corrupt frames are discarded instead of stopping the scanner.
+	//
+	// After a corrupt frame, the scanner resynchronizes on the next sync word.
+	Resync bool
+	// OnDiscard is an optional hook invoked with each frame discarded in tolerant mode and the cause of the discard.
+	//
+	// The frame is only valid for the duration of the call.
+	OnDiscard func(frame []byte, err error)
+	// ProtocolVersions are the protocol versions accepted by the scanner.
+	//
+	// By default, the scanner accepts the supported protocol version and all newer versions with the same high level
+	// of version. Newer versions are decoded according to the known payload layouts, and any trailing fields of
+	// extended payloads are available through Scanner.Extension.
+	ProtocolVersions []ProtocolVersion
+}
+
+func (c *ScannerConfig) isProtocolVersionAccepted(v ProtocolVersion) bool {
+	if len(c.ProtocolVersions) == 0 {
+		return isProtocolVersionSupported(v)
+	}
+	for _, accepted := range c.ProtocolVersions {
+		if v == accepted {
+			return true
+		}
+	}
+	return false
+}
+
+// ScannerStats contains error statistics of a Scanner.
+type ScannerStats struct {
+	// DiscardedFrames is the number of corrupt frames discarded in tolerant mode.
+	DiscardedFrames uint64
+	// DiscardedBytes is the number of bytes discarded while synchronizing to the start of a packet.
+	DiscardedBytes uint64
+}
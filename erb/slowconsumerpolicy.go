@@ -0,0 +1,15 @@
+package erb
+
+// SlowConsumerPolicy determines how a Dispatcher handles a subscription whose buffer is full.
+type SlowConsumerPolicy uint8
+
+//go:generate stringer -type SlowConsumerPolicy -trimprefix SlowConsumerPolicy
+
+const (
+	// SlowConsumerPolicyDropOldest drops the oldest buffered message to make room for the new message.
+	SlowConsumerPolicyDropOldest SlowConsumerPolicy = iota
+	// SlowConsumerPolicyBlock blocks the dispatcher until the subscriber has made room for the new message.
+	SlowConsumerPolicyBlock
+	// SlowConsumerPolicyError terminates the subscription with ErrSlowConsumer.
+	SlowConsumerPolicyError
+)
@@ -2,6 +2,7 @@ package erb
 
 import (
 	"encoding/binary"
+	"time"
 )
 
 // structure of STAT message.
@@ -23,15 +24,20 @@ var _ [lengthOfSTAT]struct{} = [indexOfSTATNumSatellites + lengthOfSTATNumSatell
 // STAT message contains status of fix, its type and also the number of used satellites.
 type STAT struct {
 	// TimeGPS is the time of week in milliseconds of the navigation epoch.
-	TimeGPS uint32
+	TimeGPS uint32 `json:"time_gps"`
 	// WeekGPS is the week number of the navigation epoch.
-	WeekGPS uint16
+	WeekGPS uint16 `json:"week_gps"`
 	// FixType is the fix type.
-	FixType FixType
+	FixType FixType `json:"fix_type"`
 	// HasFix is true when position and velocity are valid.
-	HasFix bool
+	HasFix bool `json:"has_fix"`
 	// NumSVs is the number of used space vehicles.
-	NumSVs uint8
+	NumSVs uint8 `json:"num_svs"`
+}
+
+// Time returns the UTC time of the navigation epoch.
+func (s *STAT) Time() time.Time {
+	return UTCTime(s.WeekGPS, s.TimeGPS)
 }
 
 func (s *STAT) unmarshalPayload(b []byte) {
@@ -41,3 +47,31 @@ func (s *STAT) unmarshalPayload(b []byte) {
 	s.HasFix = b[indexOfSTATHasFix] == 1
 	s.NumSVs = b[indexOfSTATNumSatellites]
 }
+
+func (s *STAT) marshalPayload(b []byte) {
+	_ = b[lengthOfSTAT-1] // early bounds check
+	binary.LittleEndian.PutUint32(b[indexOfTimeGPS:indexOfTimeGPS+lengthOfTimeGPS], s.TimeGPS)
+	binary.LittleEndian.PutUint16(b[indexOfSTATWeekGPS:indexOfSTATWeekGPS+lengthOfSTATWeekGPS], s.WeekGPS)
+	b[indexOfSTATFixType] = uint8(s.FixType)
+	b[indexOfSTATHasFix] = 0
+	if s.HasFix {
+		b[indexOfSTATHasFix] = 1
+	}
+	b[indexOfSTATNumSatellites] = s.NumSVs
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the ERB payload of the STAT message.
+func (s *STAT) MarshalBinary() ([]byte, error) {
+	b := make([]byte, lengthOfSTAT)
+	s.marshalPayload(b)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and parses the ERB payload of a STAT message.
+func (s *STAT) UnmarshalBinary(b []byte) error {
+	if err := validatePayloadSTAT(b); err != nil {
+		return err
+	}
+	s.unmarshalPayload(b)
+	return nil
+}
@@ -2,6 +2,9 @@ package erb
 
 import (
 	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
 )
 
 // structure of SVI message.
@@ -47,9 +50,16 @@ var (
 // SVI message contains information about used observation satellites.
 type SVI struct {
 	// TimeGPS is the time of week in milliseconds of the navigation epoch.
-	TimeGPS uint32
+	TimeGPS uint32 `json:"time_gps"`
 	// NumSVs is the number of visible SVs.
-	NumSVs uint8
+	NumSVs uint8 `json:"num_svs"`
+}
+
+// Time returns the UTC time of the navigation epoch, given the GPS week of the epoch.
+//
+// SVI messages carry no GPS week, use the week of the STAT message of the same epoch or ResolveWeekGPS.
+func (s *SVI) Time(weekGPS uint16) time.Time {
+	return UTCTime(weekGPS, s.TimeGPS)
 }
 
 func (s *SVI) unmarshalPayload(b []byte) {
@@ -62,21 +72,21 @@ func (s *SVI) unmarshalPayload(b []byte) {
 // SV message contains information about a single observation satellite.
 type SV struct {
 	// ID of SV.
-	ID uint8
+	ID uint8 `json:"id"`
 	// Type of SV.
-	Type SVType
+	Type SVType `json:"type"`
 	// SignalStrength of SV in dB-Hz.
-	SignalStrength float64
+	SignalStrength float64 `json:"signal_strength"`
 	// CarrierPhase of SV in cycles.
-	CarrierPhase float64
+	CarrierPhase float64 `json:"carrier_phase"`
 	// PseudoRangeResidual of SV (m).
-	PseudoRangeResidualMeters int32
+	PseudoRangeResidualMeters int32 `json:"pseudo_range_residual_meters"`
 	// DopplerFrequencyHz of SV.
-	DopplerFrequencyHz float64
+	DopplerFrequencyHz float64 `json:"doppler_frequency_hz"`
 	// Azimuth of SV (degrees).
-	AzimuthDegrees float64
+	AzimuthDegrees float64 `json:"azimuth_degrees"`
 	// Elevation of SV (degrees).
-	ElevationDegrees float64
+	ElevationDegrees float64 `json:"elevation_degrees"`
 }
 
 func (s *SV) unmarshalPayload(b []byte, i int) {
@@ -104,3 +114,83 @@ func (s *SV) unmarshalPayload(b []byte, i int) {
 		b[offset+indexOfSVElevation:offset+indexOfSVElevation+lengthOfSVElevation],
 	))
 }
+
+func (s *SVI) marshalPayload(b []byte) {
+	const expectedLength = indexOfNumSVs + lengthOfNumSVs
+	_ = b[expectedLength-1] // early bounds check
+	binary.LittleEndian.PutUint32(b[indexOfTimeGPS:indexOfTimeGPS+lengthOfTimeGPS], s.TimeGPS)
+	b[indexOfNumSVs] = s.NumSVs
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the header of the SVI message payload.
+//
+// The header is followed by NumSVs SV blocks in the full payload, see SV.MarshalBinary and Encoder.EncodeSVI.
+func (s *SVI) MarshalBinary() ([]byte, error) {
+	b := make([]byte, indexOfSV)
+	s.marshalPayload(b)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and parses the header of an SVI message payload.
+//
+// Any SV blocks following the header are ignored, see SV.UnmarshalBinary.
+func (s *SVI) UnmarshalBinary(b []byte) error {
+	if len(b) < indexOfSV {
+		return fmt.Errorf("unmarshal %v: illegal length %d (expected minimum %d)", IDSVI, len(b), indexOfSV)
+	}
+	s.unmarshalPayload(b)
+	return nil
+}
+
+func (s *SV) marshalPayload(b []byte, i int) {
+	offset := i * lengthOfSV
+	expectedLength := indexOfSV + offset + lengthOfSV
+	_ = b[expectedLength-1] // early bounds check
+	b[offset+indexOfSVID] = s.ID
+	b[offset+indexOfSVType] = uint8(s.Type)
+	binary.LittleEndian.PutUint16(
+		b[offset+indexOfSVSignalStrength:offset+indexOfSVSignalStrength+lengthOfSVSignalStrength],
+		uint16(math.Round(s.SignalStrength/scaleOfSVSignalStrength)),
+	)
+	binary.LittleEndian.PutUint32(
+		b[offset+indexOfSVCarrierPhase:offset+indexOfSVCarrierPhase+lengthOfSVCarrierPhase],
+		uint32(int32(math.Round(s.CarrierPhase/scaleOfSVCarrierPhase))),
+	)
+	binary.LittleEndian.PutUint32(
+		b[offset+indexOfSVPseudoRangeResidual:offset+indexOfSVPseudoRangeResidual+lengthOfSVPseudoRangeResidual],
+		uint32(s.PseudoRangeResidualMeters),
+	)
+	binary.LittleEndian.PutUint32(
+		b[offset+indexOfSVDopplerFrequency:offset+indexOfSVDopplerFrequency+lengthOfSVDopplerFrequency],
+		uint32(int32(math.Round(s.DopplerFrequencyHz/scaleOfSVDopplerFrequency))),
+	)
+	binary.LittleEndian.PutUint16(
+		b[offset+indexOfSVAzimuth:offset+indexOfSVAzimuth+lengthOfSVAzimuth],
+		uint16(math.Round(s.AzimuthDegrees/scaleOfSVAzimuth)),
+	)
+	binary.LittleEndian.PutUint16(
+		b[offset+indexOfSVElevation:offset+indexOfSVElevation+lengthOfSVElevation],
+		uint16(math.Round(s.ElevationDegrees/scaleOfSVElevation)),
+	)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the SV block of an SVI message payload.
+func (s *SV) MarshalBinary() ([]byte, error) {
+	b := make([]byte, lengthOfSV)
+	// the SV block marshalers operate on full SVI payloads, so marshal into a payload-sized buffer
+	var payload [lengthOfSVI]byte
+	s.marshalPayload(payload[:], 0)
+	copy(b, payload[indexOfSV:])
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and parses a single SV block of an SVI message payload.
+func (s *SV) UnmarshalBinary(b []byte) error {
+	if len(b) != lengthOfSV {
+		return fmt.Errorf("unmarshal SV: illegal length %d (expected %d)", len(b), lengthOfSV)
+	}
+	var payload [lengthOfSVI]byte
+	copy(payload[indexOfSV:], b)
+	s.unmarshalPayload(payload[:], 0)
+	return nil
+}
@@ -1,5 +1,7 @@
 package erb
 
+import "fmt"
+
 // SVType represents the type of an SV (space vehicle).
 type SVType uint8
 
@@ -14,3 +16,26 @@ const (
 	SVTypeLEO     SVType = 5
 	SVTypeSBAS    SVType = 6
 )
+
+// MarshalText implements encoding.TextMarshaler.
+func (s SVType) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *SVType) UnmarshalText(text []byte) error {
+	for _, value := range []SVType{
+		SVTypeGPS, SVTypeGLONASS, SVTypeGalileo, SVTypeQZSS, SVTypeBeiDou, SVTypeLEO, SVTypeSBAS,
+	} {
+		if value.String() == string(text) {
+			*s = value
+			return nil
+		}
+	}
+	// unknown values are formatted as SVType(n) by String
+	if value, ok := parseUnknownText(text, "SVType"); ok {
+		*s = SVType(value)
+		return nil
+	}
+	return fmt.Errorf("unmarshal SV type: unknown value %q", text)
+}
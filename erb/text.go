@@ -0,0 +1,19 @@
+package erb
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parseUnknownText parses an unknown value of a type, as formatted by the String method generated by stringer.
+func parseUnknownText(text []byte, typeName string) (uint8, bool) {
+	s := string(text)
+	if !strings.HasPrefix(s, typeName+"(") || !strings.HasSuffix(s, ")") {
+		return 0, false
+	}
+	value, err := strconv.ParseUint(s[len(typeName)+1:len(s)-1], 10, 8)
+	if err != nil {
+		return 0, false
+	}
+	return uint8(value), true
+}
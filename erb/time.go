@@ -1,6 +1,107 @@
 package erb
 
+import "time"
+
 const (
 	indexOfTimeGPS  = 0
 	lengthOfTimeGPS = 4
 )
+
+// MillisecondsPerWeek is the number of milliseconds in a GPS week.
+const MillisecondsPerWeek = 7 * 24 * 60 * 60 * 1000
+
+// epochGPS is the start of GPS time.
+var epochGPS = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+// LeapSecond is a change of the offset between GPS time and UTC.
+type LeapSecond struct {
+	// Time is the UTC time from which the offset applies.
+	Time time.Time
+	// Offset is the offset of GPS time ahead of UTC.
+	Offset time.Duration
+}
+
+// LeapSeconds is the table of leap seconds since the start of GPS time, ordered by time.
+//
+// The table must be updated when new leap seconds are announced by the IERS.
+var LeapSeconds = []LeapSecond{
+	{Time: time.Date(1981, time.July, 1, 0, 0, 0, 0, time.UTC), Offset: 1 * time.Second},
+	{Time: time.Date(1982, time.July, 1, 0, 0, 0, 0, time.UTC), Offset: 2 * time.Second},
+	{Time: time.Date(1983, time.July, 1, 0, 0, 0, 0, time.UTC), Offset: 3 * time.Second},
+	{Time: time.Date(1985, time.July, 1, 0, 0, 0, 0, time.UTC), Offset: 4 * time.Second},
+	{Time: time.Date(1988, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 5 * time.Second},
+	{Time: time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 6 * time.Second},
+	{Time: time.Date(1991, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 7 * time.Second},
+	{Time: time.Date(1992, time.July, 1, 0, 0, 0, 0, time.UTC), Offset: 8 * time.Second},
+	{Time: time.Date(1993, time.July, 1, 0, 0, 0, 0, time.UTC), Offset: 9 * time.Second},
+	{Time: time.Date(1994, time.July, 1, 0, 0, 0, 0, time.UTC), Offset: 10 * time.Second},
+	{Time: time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 11 * time.Second},
+	{Time: time.Date(1997, time.July, 1, 0, 0, 0, 0, time.UTC), Offset: 12 * time.Second},
+	{Time: time.Date(1999, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 13 * time.Second},
+	{Time: time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 14 * time.Second},
+	{Time: time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 15 * time.Second},
+	{Time: time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC), Offset: 16 * time.Second},
+	{Time: time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC), Offset: 17 * time.Second},
+	{Time: time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 18 * time.Second},
+}
+
+// GPSTime returns the time on the GPS time scale of the provided GPS week and time of week in milliseconds.
+//
+// The GPS time scale is not adjusted for leap seconds, use UTCTime to get the corresponding UTC time.
+func GPSTime(weekGPS uint16, timeGPS uint32) time.Time {
+	return epochGPS.Add(
+		time.Duration(weekGPS)*MillisecondsPerWeek*time.Millisecond + time.Duration(timeGPS)*time.Millisecond,
+	)
+}
+
+// UTCTime returns the UTC time of the provided GPS week and time of week in milliseconds.
+func UTCTime(weekGPS uint16, timeGPS uint32) time.Time {
+	t := GPSTime(weekGPS, timeGPS)
+	for i := len(LeapSeconds) - 1; i >= 0; i-- {
+		if !t.Before(LeapSeconds[i].Time.Add(LeapSeconds[i].Offset)) {
+			return t.Add(-LeapSeconds[i].Offset)
+		}
+	}
+	return t
+}
+
+// WeekAndTimeGPS returns the GPS week and time of week in milliseconds of the provided time.
+func WeekAndTimeGPS(t time.Time) (weekGPS uint16, timeGPS uint32) {
+	for i := len(LeapSeconds) - 1; i >= 0; i-- {
+		if !t.Before(LeapSeconds[i].Time) {
+			t = t.Add(LeapSeconds[i].Offset)
+			break
+		}
+	}
+	ms := t.Sub(epochGPS).Milliseconds()
+	return uint16(ms / MillisecondsPerWeek), uint32(ms % MillisecondsPerWeek)
+}
+
+// ResolveWeekGPS returns the GPS week of a message with time of week timeGPS, given the GPS week and time of week of
+// a nearby reference message, such as a STAT message.
+//
+// Messages less than half a week apart are assumed, which handles rollover of the time of week between the messages.
+func ResolveWeekGPS(refWeekGPS uint16, refTimeGPS uint32, timeGPS uint32) uint16 {
+	switch {
+	case timeGPS < refTimeGPS && refTimeGPS-timeGPS > MillisecondsPerWeek/2:
+		return refWeekGPS + 1
+	case timeGPS > refTimeGPS && timeGPS-refTimeGPS > MillisecondsPerWeek/2:
+		return refWeekGPS - 1
+	default:
+		return refWeekGPS
+	}
+}
+
+// ElapsedGPS returns the time elapsed from one GPS time of week in milliseconds to another.
+//
+// Times less than half a week apart are assumed, which handles rollover of the time of week between the times.
+func ElapsedGPS(fromTimeGPS, toTimeGPS uint32) time.Duration {
+	ms := int64(toTimeGPS) - int64(fromTimeGPS)
+	switch {
+	case ms < -MillisecondsPerWeek/2:
+		ms += MillisecondsPerWeek
+	case ms > MillisecondsPerWeek/2:
+		ms -= MillisecondsPerWeek
+	}
+	return time.Duration(ms) * time.Millisecond
+}
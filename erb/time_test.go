@@ -0,0 +1,84 @@
+package erb
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestUTCTime(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		weekGPS  uint16
+		timeGPS  uint32
+		expected time.Time
+	}{
+		{
+			name:     "epoch",
+			expected: time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "recording",
+			weekGPS:  2059,
+			timeGPS:  113968400,
+			expected: time.Date(2019, time.June, 24, 7, 39, 10, 400e6, time.UTC),
+		},
+		{
+			name:     "before leap second",
+			weekGPS:  1930,
+			timeGPS:  17*1000 - 1,
+			expected: time.Date(2016, time.December, 31, 23, 59, 59, 999e6, time.UTC),
+		},
+		{
+			name:     "after leap second",
+			weekGPS:  1930,
+			timeGPS:  18 * 1000,
+			expected: time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			actual := UTCTime(tt.weekGPS, tt.timeGPS)
+			assert.Assert(t, tt.expected.Equal(actual), "expected %v but got %v", tt.expected, actual)
+			weekGPS, timeGPS := WeekAndTimeGPS(actual)
+			assert.Equal(t, tt.weekGPS, weekGPS)
+			assert.Equal(t, tt.timeGPS, timeGPS)
+		})
+	}
+}
+
+func TestGPSTime(t *testing.T) {
+	expected := time.Date(2019, time.June, 24, 7, 39, 28, 400e6, time.UTC)
+	actual := GPSTime(2059, 113968400)
+	assert.Assert(t, expected.Equal(actual), "expected %v but got %v", expected, actual)
+}
+
+func TestResolveWeekGPS(t *testing.T) {
+	const lastMillisecondOfWeek = MillisecondsPerWeek - 1
+	assert.Equal(t, uint16(2059), ResolveWeekGPS(2059, 1000, 2000))
+	assert.Equal(t, uint16(2060), ResolveWeekGPS(2059, lastMillisecondOfWeek, 200))
+	assert.Equal(t, uint16(2058), ResolveWeekGPS(2059, 200, lastMillisecondOfWeek))
+}
+
+func TestMessageTime(t *testing.T) {
+	expected := time.Date(2019, time.June, 24, 7, 39, 10, 400e6, time.UTC)
+	const weekGPS, timeGPS = 2059, 113968400
+	stat := STAT{WeekGPS: weekGPS, TimeGPS: timeGPS}
+	pos := POS{TimeGPS: timeGPS}
+	vel := VEL{TimeGPS: timeGPS}
+	dops := DOPS{TimeGPS: timeGPS}
+	svi := SVI{TimeGPS: timeGPS}
+	for _, actual := range []time.Time{
+		stat.Time(), pos.Time(weekGPS), vel.Time(weekGPS), dops.Time(weekGPS), svi.Time(weekGPS),
+	} {
+		assert.Assert(t, expected.Equal(actual), "expected %v but got %v", expected, actual)
+	}
+}
+
+func TestElapsedGPS(t *testing.T) {
+	assert.Equal(t, 200*time.Millisecond, ElapsedGPS(1000, 1200))
+	assert.Equal(t, -200*time.Millisecond, ElapsedGPS(1200, 1000))
+	assert.Equal(t, 300*time.Millisecond, ElapsedGPS(MillisecondsPerWeek-100, 200))
+	assert.Equal(t, -300*time.Millisecond, ElapsedGPS(200, MillisecondsPerWeek-100))
+}
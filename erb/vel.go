@@ -2,6 +2,8 @@ package erb
 
 import (
 	"encoding/binary"
+	"math"
+	"time"
 )
 
 // structure of VEL message.
@@ -30,19 +32,26 @@ var _ [lengthOfVEL]struct{} = [indexOfVELSpeedAccuracy + lengthOfVELSpeedAccurac
 // VEL message contains the velocity in NED (North East Down) coordinates.
 type VEL struct {
 	// TimeGPS is the time of week in milliseconds of the navigation epoch.
-	TimeGPS uint32
+	TimeGPS uint32 `json:"time_gps"`
 	// North velocity component (cm/s).
-	NorthCentimetersPerSecond int32
+	NorthCentimetersPerSecond int32 `json:"north_centimeters_per_second"`
 	// East velocity component (cm/s).
-	EastCentimetersPerSecond int32
+	EastCentimetersPerSecond int32 `json:"east_centimeters_per_second"`
 	// Down velocity component (cm/s).
-	DownCentimetersPerSecond int32
+	DownCentimetersPerSecond int32 `json:"down_centimeters_per_second"`
 	// Speed is the 2D ground speed (cm/s).
-	SpeedCentimetersPerSecond int32
+	SpeedCentimetersPerSecond int32 `json:"speed_centimeters_per_second"`
 	// Heading is the 2D heading of motion.
-	HeadingDegrees float64
+	HeadingDegrees float64 `json:"heading_degrees"`
 	// SpeedAccuracy is the speed accuracy estimate.
-	SpeedAccuracyCentimetersPerSecond uint32
+	SpeedAccuracyCentimetersPerSecond uint32 `json:"speed_accuracy_centimeters_per_second"`
+}
+
+// Time returns the UTC time of the navigation epoch, given the GPS week of the epoch.
+//
+// VEL messages carry no GPS week, use the week of the STAT message of the same epoch or ResolveWeekGPS.
+func (v *VEL) Time(weekGPS uint16) time.Time {
+	return UTCTime(weekGPS, v.TimeGPS)
 }
 
 func (v *VEL) unmarshalPayload(b []byte) {
@@ -69,3 +78,48 @@ func (v *VEL) unmarshalPayload(b []byte) {
 		b[indexOfVELSpeedAccuracy : indexOfVELSpeedAccuracy+lengthOfVELSpeedAccuracy],
 	)
 }
+
+func (v *VEL) marshalPayload(b []byte) {
+	_ = b[lengthOfVEL-1] // early bounds check
+	binary.LittleEndian.PutUint32(b[indexOfTimeGPS:indexOfTimeGPS+lengthOfTimeGPS], v.TimeGPS)
+	binary.LittleEndian.PutUint32(
+		b[indexOfVELNorth:indexOfVELNorth+lengthOfVELNorth],
+		uint32(v.NorthCentimetersPerSecond),
+	)
+	binary.LittleEndian.PutUint32(
+		b[indexOfVELEast:indexOfVELEast+lengthOfVELEast],
+		uint32(v.EastCentimetersPerSecond),
+	)
+	binary.LittleEndian.PutUint32(
+		b[indexOfVELDown:indexOfVELDown+lengthOfVELDown],
+		uint32(v.DownCentimetersPerSecond),
+	)
+	binary.LittleEndian.PutUint32(
+		b[indexOfVELSpeed:indexOfVELSpeed+lengthOfVELSpeed],
+		uint32(v.SpeedCentimetersPerSecond),
+	)
+	binary.LittleEndian.PutUint32(
+		b[indexOfVELHeading:indexOfVELHeading+lengthOfVELHeading],
+		uint32(int32(math.Round(v.HeadingDegrees/scalingOfVELHeading))),
+	)
+	binary.LittleEndian.PutUint32(
+		b[indexOfVELSpeedAccuracy:indexOfVELSpeedAccuracy+lengthOfVELSpeedAccuracy],
+		v.SpeedAccuracyCentimetersPerSecond,
+	)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the ERB payload of the VEL message.
+func (v *VEL) MarshalBinary() ([]byte, error) {
+	b := make([]byte, lengthOfVEL)
+	v.marshalPayload(b)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and parses the ERB payload of a VEL message.
+func (v *VEL) UnmarshalBinary(b []byte) error {
+	if err := validatePayloadVEL(b); err != nil {
+		return err
+	}
+	v.unmarshalPayload(b)
+	return nil
+}
@@ -2,6 +2,7 @@ package erb
 
 import (
 	"encoding/binary"
+	"fmt"
 )
 
 // structure of VER message.
@@ -23,7 +24,22 @@ var _ [lengthOfVER]struct{} = [indexOfVERLow + lengthOfVERLow]struct{}{}
 // It comprises 3 numbers: high level of version, medium level of version and low level of version.
 type VER struct {
 	// TimeGPS is the time of week in milliseconds of the navigation epoch.
-	TimeGPS uint32
+	TimeGPS uint32 `json:"time_gps"`
+	// High level of version.
+	High uint8 `json:"high"`
+	// Medium level of version.
+	Medium uint8 `json:"medium"`
+	// Low level of version.
+	Low uint8 `json:"low"`
+}
+
+// ProtocolVersion returns the protocol version of the VER message.
+func (v *VER) ProtocolVersion() ProtocolVersion {
+	return ProtocolVersion{High: v.High, Medium: v.Medium, Low: v.Low}
+}
+
+// ProtocolVersion is a version of the ERB protocol.
+type ProtocolVersion struct {
 	// High level of version.
 	High uint8
 	// Medium level of version.
@@ -32,6 +48,54 @@ type VER struct {
 	Low uint8
 }
 
+// SupportedProtocolVersion returns the protocol version that the payload layouts of this package are based on.
+func SupportedProtocolVersion() ProtocolVersion {
+	return ProtocolVersion{
+		High:   SupportedProtocolVersionHigh,
+		Medium: SupportedProtocolVersionMedium,
+		Low:    SupportedProtocolVersionLow,
+	}
+}
+
+// isProtocolVersionSupported returns true for the supported protocol version and all newer versions with the same high
+// level of version.
+func isProtocolVersionSupported(v ProtocolVersion) bool {
+	supported := SupportedProtocolVersion()
+	return v.High == supported.High && v.Compare(supported) >= 0
+}
+
+func newUnsupportedProtocolVersionError(v ProtocolVersion) error {
+	return fmt.Errorf("validate %v payload: unsupported protocol version %v", IDVER, v)
+}
+
+// String returns the protocol version on the form high.medium.low.
+func (p ProtocolVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", p.High, p.Medium, p.Low)
+}
+
+// Compare returns -1, 0 or 1 when p is less than, equal to or greater than q.
+func (p ProtocolVersion) Compare(q ProtocolVersion) int {
+	switch {
+	case p.High != q.High:
+		return compareUint8(p.High, q.High)
+	case p.Medium != q.Medium:
+		return compareUint8(p.Medium, q.Medium)
+	default:
+		return compareUint8(p.Low, q.Low)
+	}
+}
+
+func compareUint8(a, b uint8) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
 func (v *VER) unmarshalPayload(b []byte) {
 	_ = b[lengthOfVER-1] // early bounds check
 	v.TimeGPS = binary.LittleEndian.Uint32(b[indexOfTimeGPS : indexOfTimeGPS+lengthOfTimeGPS])
@@ -39,3 +103,27 @@ func (v *VER) unmarshalPayload(b []byte) {
 	v.Medium = b[indexOfVERMedium]
 	v.Low = b[indexOfVERLow]
 }
+
+func (v *VER) marshalPayload(b []byte) {
+	_ = b[lengthOfVER-1] // early bounds check
+	binary.LittleEndian.PutUint32(b[indexOfTimeGPS:indexOfTimeGPS+lengthOfTimeGPS], v.TimeGPS)
+	b[indexOfVERHigh] = v.High
+	b[indexOfVERMedium] = v.Medium
+	b[indexOfVERLow] = v.Low
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the ERB payload of the VER message.
+func (v *VER) MarshalBinary() ([]byte, error) {
+	b := make([]byte, lengthOfVER)
+	v.marshalPayload(b)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and parses the ERB payload of a VER message.
+func (v *VER) UnmarshalBinary(b []byte) error {
+	if err := validatePayloadVER(b); err != nil {
+		return err
+	}
+	v.unmarshalPayload(b)
+	return nil
+}
@@ -0,0 +1,5 @@
+// Package erbsim provides a simulated Emlid Reach receiver that outputs the Emlid Reach Binary protocol (ERB).
+//
+// The simulator generates a time-consistent ERB stream along a scripted trajectory, and can serve the stream on a
+// local TCP listener that mimics the ERB port of a Reach.
+package erbsim
@@ -0,0 +1,91 @@
+package erbsim
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// Server serves simulated ERB streams on a TCP listener, mimicking the ERB port of a Reach.
+//
+// Each accepted connection receives its own stream, starting with a VER message.
+type Server struct {
+	sim       *Simulator
+	lis       net.Listener
+	closed    chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// Listen on the provided TCP address and return a new Server that serves streams from the simulator.
+//
+// Use "localhost:0" to listen on a random free port.
+func Listen(ctx context.Context, address string, sim *Simulator) (*Server, error) {
+	lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("erbsim: listen: %w", err)
+	}
+	return &Server{sim: sim, lis: lis, closed: make(chan struct{})}, nil
+}
+
+// Addr returns the address of the server.
+func (s *Server) Addr() net.Addr {
+	return s.lis.Addr()
+}
+
+// Serve accepts connections until the context is canceled or the server is closed.
+func (s *Server) Serve(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		s.wg.Wait()
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = s.Close()
+		case <-s.closed:
+			cancel()
+		}
+	}()
+	for {
+		conn, err := s.lis.Accept()
+		if err != nil {
+			select {
+			case <-s.closed:
+				return nil
+			default:
+				return fmt.Errorf("erbsim: serve: %w", err)
+			}
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	// errors are expected when the client disconnects
+	_ = s.sim.Stream(ctx, conn)
+}
+
+// Close the server's listener, which causes Serve to return.
+func (s *Server) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		if errClose := s.lis.Close(); errClose != nil {
+			err = fmt.Errorf("erbsim: close: %w", errClose)
+		}
+	})
+	return err
+}
@@ -0,0 +1,214 @@
+package erbsim
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// Config configures a Simulator.
+type Config struct {
+	// Interval between navigation epochs. Defaults to 200ms (5 Hz).
+	Interval time.Duration
+	// WeekGPS is the GPS week number of the first navigation epoch.
+	WeekGPS uint16
+	// TimeGPS is the GPS time of week in milliseconds of the first navigation epoch.
+	TimeGPS uint32
+	// Waypoints of the scripted trajectory, ordered by time.
+	Waypoints []Waypoint
+	// Satellites used in the navigation solution when the receiver has a fix.
+	Satellites []erb.SV
+	// DOPS are the dilution of precision values reported when the receiver has a fix.
+	DOPS erb.DOPS
+	// GeoidSeparationMeters is the height of the geoid above the ellipsoid (m).
+	GeoidSeparationMeters float64
+	// HorizontalAccuracyMillimeters is the reported horizontal accuracy estimate for each fix type (mm).
+	HorizontalAccuracyMillimeters map[erb.FixType]uint32
+	// VerticalAccuracyMillimeters is the reported vertical accuracy estimate for each fix type (mm).
+	VerticalAccuracyMillimeters map[erb.FixType]uint32
+}
+
+// Epoch contains the ERB messages of a single navigation epoch.
+type Epoch struct {
+	POS  erb.POS
+	STAT erb.STAT
+	DOPS erb.DOPS
+	VEL  erb.VEL
+	SVI  erb.SVI
+	SVs  []erb.SV
+}
+
+// Simulator generates a time-consistent ERB stream along a scripted trajectory.
+type Simulator struct {
+	cfg Config
+}
+
+// NewSimulator creates a new Simulator with the provided config.
+func NewSimulator(cfg Config) *Simulator {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 200 * time.Millisecond
+	}
+	if cfg.Satellites == nil {
+		cfg.Satellites = DefaultSatellites()
+	}
+	if cfg.DOPS == (erb.DOPS{}) {
+		cfg.DOPS = erb.DOPS{Geometric: 1.36, Position: 1.21, Vertical: 0.98, Horizontal: 0.69}
+	}
+	if cfg.HorizontalAccuracyMillimeters == nil {
+		cfg.HorizontalAccuracyMillimeters = map[erb.FixType]uint32{
+			erb.FixTypeSingle: 2500,
+			erb.FixTypeFloat:  500,
+			erb.FixTypeRTK:    14,
+		}
+	}
+	if cfg.VerticalAccuracyMillimeters == nil {
+		cfg.VerticalAccuracyMillimeters = map[erb.FixType]uint32{
+			erb.FixTypeSingle: 4000,
+			erb.FixTypeFloat:  800,
+			erb.FixTypeRTK:    20,
+		}
+	}
+	return &Simulator{cfg: cfg}
+}
+
+// DefaultSatellites returns the default set of simulated satellites.
+func DefaultSatellites() []erb.SV {
+	return []erb.SV{
+		{ID: 2, Type: erb.SVTypeGPS, SignalStrength: 43, AzimuthDegrees: 112.8, ElevationDegrees: 32.1},
+		{ID: 6, Type: erb.SVTypeGPS, SignalStrength: 43, AzimuthDegrees: 64.4, ElevationDegrees: 31.7},
+		{ID: 12, Type: erb.SVTypeGPS, SignalStrength: 50, AzimuthDegrees: 92.9, ElevationDegrees: 74.2},
+		{ID: 14, Type: erb.SVTypeGPS, SignalStrength: 37, AzimuthDegrees: 275.3, ElevationDegrees: 23.6},
+		{ID: 24, Type: erb.SVTypeGPS, SignalStrength: 46, AzimuthDegrees: 193.4, ElevationDegrees: 53.2},
+		{ID: 65, Type: erb.SVTypeGLONASS, SignalStrength: 41, AzimuthDegrees: 41.2, ElevationDegrees: 44.9},
+		{ID: 72, Type: erb.SVTypeGLONASS, SignalStrength: 39, AzimuthDegrees: 320.7, ElevationDegrees: 27.5},
+		{ID: 11, Type: erb.SVTypeGalileo, SignalStrength: 42, AzimuthDegrees: 151.0, ElevationDegrees: 61.3},
+		{ID: 36, Type: erb.SVTypeGalileo, SignalStrength: 40, AzimuthDegrees: 233.8, ElevationDegrees: 38.4},
+	}
+}
+
+// Interval returns the interval between navigation epochs.
+func (s *Simulator) Interval() time.Duration {
+	return s.cfg.Interval
+}
+
+// VER returns the VER message of the simulated receiver.
+func (s *Simulator) VER() erb.VER {
+	return erb.VER{
+		TimeGPS: s.cfg.TimeGPS,
+		High:    erb.SupportedProtocolVersionHigh,
+		Medium:  erb.SupportedProtocolVersionMedium,
+		Low:     erb.SupportedProtocolVersionLow,
+	}
+}
+
+// Epoch returns the ERB messages of the i:th navigation epoch.
+func (s *Simulator) Epoch(i int) Epoch {
+	t := time.Duration(i) * s.cfg.Interval
+	weekGPS, timeGPS := s.timeOfEpoch(t)
+	st := interpolate(s.cfg.Waypoints, t)
+	hasFix := st.fixType != erb.FixTypeNoFix
+	var e Epoch
+	e.POS = erb.POS{
+		TimeGPS:                       timeGPS,
+		LongitudeDegrees:              st.longitudeDegrees,
+		LatitudeDegrees:               st.latitudeDegrees,
+		AltitudeEllipsoidMeters:       st.altitudeEllipsoidMeters,
+		AltitudeMeanSeaLevelMeters:    st.altitudeEllipsoidMeters - s.cfg.GeoidSeparationMeters,
+		HorizontalAccuracyMillimeters: s.cfg.HorizontalAccuracyMillimeters[st.fixType],
+		VerticalAccuracyMillimeters:   s.cfg.VerticalAccuracyMillimeters[st.fixType],
+	}
+	if hasFix {
+		e.SVs = s.cfg.Satellites
+		e.DOPS = s.cfg.DOPS
+	}
+	e.DOPS.TimeGPS = timeGPS
+	e.STAT = erb.STAT{
+		TimeGPS: timeGPS,
+		WeekGPS: weekGPS,
+		FixType: st.fixType,
+		HasFix:  hasFix,
+		NumSVs:  uint8(len(e.SVs)),
+	}
+	speed := math.Hypot(st.northMetersPerSecond, st.eastMetersPerSecond)
+	e.VEL = erb.VEL{
+		TimeGPS:                   timeGPS,
+		NorthCentimetersPerSecond: int32(math.Round(100 * st.northMetersPerSecond)),
+		EastCentimetersPerSecond:  int32(math.Round(100 * st.eastMetersPerSecond)),
+		DownCentimetersPerSecond:  int32(math.Round(100 * st.downMetersPerSecond)),
+		SpeedCentimetersPerSecond: int32(math.Round(100 * speed)),
+	}
+	if speed > 0 {
+		heading := math.Atan2(st.eastMetersPerSecond, st.northMetersPerSecond) * 180 / math.Pi
+		e.VEL.HeadingDegrees = math.Mod(heading+360, 360)
+	}
+	e.SVI = erb.SVI{
+		TimeGPS: timeGPS,
+		NumSVs:  uint8(len(e.SVs)),
+	}
+	return e
+}
+
+func (s *Simulator) timeOfEpoch(t time.Duration) (weekGPS uint16, timeGPS uint32) {
+	ms := int64(s.cfg.TimeGPS) + t.Milliseconds()
+	return s.cfg.WeekGPS + uint16(ms/erb.MillisecondsPerWeek), uint32(ms % erb.MillisecondsPerWeek)
+}
+
+// WriteEpoch writes the messages of the i:th navigation epoch to the encoder.
+func (s *Simulator) WriteEpoch(enc *erb.Encoder, i int) error {
+	e := s.Epoch(i)
+	if err := enc.EncodePOS(e.POS); err != nil {
+		return fmt.Errorf("write epoch %d: %w", i, err)
+	}
+	if err := enc.EncodeSTAT(e.STAT); err != nil {
+		return fmt.Errorf("write epoch %d: %w", i, err)
+	}
+	if err := enc.EncodeDOPS(e.DOPS); err != nil {
+		return fmt.Errorf("write epoch %d: %w", i, err)
+	}
+	if err := enc.EncodeVEL(e.VEL); err != nil {
+		return fmt.Errorf("write epoch %d: %w", i, err)
+	}
+	if err := enc.EncodeSVI(e.SVI, e.SVs); err != nil {
+		return fmt.Errorf("write epoch %d: %w", i, err)
+	}
+	return nil
+}
+
+// WriteEpochs writes a VER message followed by n navigation epochs to w, as fast as possible.
+func (s *Simulator) WriteEpochs(w io.Writer, n int) error {
+	enc := erb.NewEncoder(w)
+	if err := enc.EncodeVER(s.VER()); err != nil {
+		return fmt.Errorf("write epochs: %w", err)
+	}
+	for i := 0; i < n; i++ {
+		if err := s.WriteEpoch(enc, i); err != nil {
+			return fmt.Errorf("write epochs: %w", err)
+		}
+	}
+	return nil
+}
+
+// Stream writes a VER message followed by navigation epochs to w at the configured rate, until the context is
+// canceled or an error occurs.
+func (s *Simulator) Stream(ctx context.Context, w io.Writer) error {
+	enc := erb.NewEncoder(w)
+	if err := enc.EncodeVER(s.VER()); err != nil {
+		return fmt.Errorf("stream: %w", err)
+	}
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for i := 0; ; i++ {
+		if err := s.WriteEpoch(enc, i); err != nil {
+			return fmt.Errorf("stream: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package erbsim
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"gotest.tools/v3/assert"
+)
+
+func TestSimulator_WriteEpochs(t *testing.T) {
+	sim := NewSimulator(Config{
+		Interval: 100 * time.Millisecond,
+		WeekGPS:  2059,
+		TimeGPS:  113968400,
+		Waypoints: []Waypoint{
+			{Time: 0, LatitudeDegrees: 57.7, LongitudeDegrees: 12.7, FixType: erb.FixTypeNoFix},
+			{Time: 500 * time.Millisecond, LatitudeDegrees: 57.7, LongitudeDegrees: 12.7, FixType: erb.FixTypeRTK},
+			{Time: 1500 * time.Millisecond, LatitudeDegrees: 57.7, LongitudeDegrees: 12.8, FixType: erb.FixTypeRTK},
+		},
+		GeoidSeparationMeters: 35,
+	})
+	var buf bytes.Buffer
+	assert.NilError(t, sim.WriteEpochs(&buf, 10))
+	sc := erb.NewScanner(&buf)
+	assert.Assert(t, sc.Scan())
+	assert.Equal(t, erb.IDVER, sc.ID())
+	assert.Equal(t, uint8(erb.SupportedProtocolVersionMedium), sc.VER().Medium)
+	var stats []erb.STAT
+	var poss []erb.POS
+	var vels []erb.VEL
+	for sc.Scan() {
+		switch sc.ID() {
+		case erb.IDPOS:
+			poss = append(poss, sc.POS())
+		case erb.IDSTAT:
+			stats = append(stats, sc.STAT())
+		case erb.IDVEL:
+			vels = append(vels, sc.VEL())
+		case erb.IDSVI:
+			var n uint8
+			for sc.ScanSVI() {
+				n++
+			}
+			assert.Equal(t, sc.SVI().NumSVs, n)
+		}
+	}
+	assert.NilError(t, sc.Err())
+	assert.Equal(t, 10, len(stats))
+	assert.Equal(t, 10, len(poss))
+	for i, stat := range stats {
+		assert.Equal(t, uint32(113968400+100*i), stat.TimeGPS)
+		assert.Equal(t, uint16(2059), stat.WeekGPS)
+		assert.Equal(t, stat.TimeGPS, poss[i].TimeGPS)
+	}
+	// no fix before the second waypoint
+	assert.Equal(t, erb.FixTypeNoFix, stats[0].FixType)
+	assert.Assert(t, !stats[0].HasFix)
+	assert.Equal(t, uint8(0), stats[0].NumSVs)
+	// RTK fix after the second waypoint, moving east
+	assert.Equal(t, erb.FixTypeRTK, stats[9].FixType)
+	assert.Assert(t, stats[9].HasFix)
+	assert.Equal(t, uint8(len(DefaultSatellites())), stats[9].NumSVs)
+	assert.Assert(t, poss[9].LongitudeDegrees > 12.7)
+	assert.Equal(t, poss[9].AltitudeEllipsoidMeters-35, poss[9].AltitudeMeanSeaLevelMeters)
+	assert.Assert(t, vels[9].EastCentimetersPerSecond > 0)
+	assert.Equal(t, int32(0), vels[9].NorthCentimetersPerSecond)
+	assert.Assert(t, math.Abs(vels[9].HeadingDegrees-90) < 1e-3)
+}
+
+func TestSimulator_HeadingWest(t *testing.T) {
+	sim := NewSimulator(Config{
+		Interval: 100 * time.Millisecond,
+		Waypoints: []Waypoint{
+			{Time: 0, LatitudeDegrees: 57.7, LongitudeDegrees: 12.8, FixType: erb.FixTypeRTK},
+			{Time: time.Second, LatitudeDegrees: 57.7, LongitudeDegrees: 12.7, FixType: erb.FixTypeRTK},
+		},
+	})
+	e := sim.Epoch(5)
+	assert.Assert(t, e.VEL.EastCentimetersPerSecond < 0)
+	assert.Assert(t, math.Abs(e.VEL.HeadingDegrees-270) < 1e-3, e.VEL.HeadingDegrees)
+}
+
+func TestSimulator_WeekRollover(t *testing.T) {
+	sim := NewSimulator(Config{
+		Interval: time.Second,
+		WeekGPS:  2059,
+		TimeGPS:  erb.MillisecondsPerWeek - 1000,
+	})
+	e := sim.Epoch(1)
+	assert.Equal(t, uint16(2060), e.STAT.WeekGPS)
+	assert.Equal(t, uint32(0), e.STAT.TimeGPS)
+}
+
+func TestServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv, err := Listen(ctx, "localhost:0", NewSimulator(Config{Interval: 10 * time.Millisecond}))
+	assert.NilError(t, err)
+	errServe := make(chan error, 1)
+	go func() {
+		errServe <- srv.Serve(ctx)
+	}()
+	conn, err := net.Dial("tcp", srv.Addr().String())
+	assert.NilError(t, err)
+	sc := erb.NewScanner(conn)
+	assert.Assert(t, sc.Scan())
+	assert.Equal(t, erb.IDVER, sc.ID())
+	assert.Assert(t, sc.Scan())
+	assert.Equal(t, erb.IDPOS, sc.ID())
+	assert.NilError(t, conn.Close())
+	assert.NilError(t, srv.Close())
+	assert.NilError(t, <-errServe)
+}
@@ -0,0 +1,81 @@
+package erbsim
+
+import (
+	"math"
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// earthRadiusMeters is the mean radius of the earth, used for approximating velocities along the trajectory.
+const earthRadiusMeters = 6371008.8
+
+// Waypoint is a point on a scripted trajectory.
+type Waypoint struct {
+	// Time is the time of the waypoint, relative to the start of the simulation.
+	Time time.Duration
+	// LatitudeDegrees is the latitude of the waypoint (degrees).
+	LatitudeDegrees float64
+	// LongitudeDegrees is the longitude of the waypoint (degrees).
+	LongitudeDegrees float64
+	// AltitudeEllipsoidMeters is the height of the waypoint above the ellipsoid (m).
+	AltitudeEllipsoidMeters float64
+	// FixType is the fix type from this waypoint until the next waypoint.
+	FixType erb.FixType
+}
+
+// state is the interpolated state of a trajectory at a point in time.
+type state struct {
+	latitudeDegrees         float64
+	longitudeDegrees        float64
+	altitudeEllipsoidMeters float64
+	northMetersPerSecond    float64
+	eastMetersPerSecond     float64
+	downMetersPerSecond     float64
+	fixType                 erb.FixType
+}
+
+// interpolate the trajectory given by the waypoints at time t.
+//
+// Before the first waypoint and after the last waypoint, the trajectory is stationary.
+func interpolate(waypoints []Waypoint, t time.Duration) state {
+	switch {
+	case len(waypoints) == 0:
+		return state{}
+	case t <= waypoints[0].Time:
+		return stationary(waypoints[0])
+	case t >= waypoints[len(waypoints)-1].Time:
+		return stationary(waypoints[len(waypoints)-1])
+	}
+	i := 1
+	for waypoints[i].Time < t {
+		i++
+	}
+	from, to := waypoints[i-1], waypoints[i]
+	dt := (to.Time - from.Time).Seconds()
+	if dt <= 0 {
+		return stationary(to)
+	}
+	k := (t - from.Time).Seconds() / dt
+	s := state{
+		latitudeDegrees:         from.LatitudeDegrees + k*(to.LatitudeDegrees-from.LatitudeDegrees),
+		longitudeDegrees:        from.LongitudeDegrees + k*(to.LongitudeDegrees-from.LongitudeDegrees),
+		altitudeEllipsoidMeters: from.AltitudeEllipsoidMeters + k*(to.AltitudeEllipsoidMeters-from.AltitudeEllipsoidMeters),
+		fixType:                 from.FixType,
+	}
+	latitudeRadians := s.latitudeDegrees * math.Pi / 180
+	s.northMetersPerSecond = (to.LatitudeDegrees - from.LatitudeDegrees) * math.Pi / 180 * earthRadiusMeters / dt
+	s.eastMetersPerSecond = (to.LongitudeDegrees - from.LongitudeDegrees) * math.Pi / 180 *
+		earthRadiusMeters * math.Cos(latitudeRadians) / dt
+	s.downMetersPerSecond = -(to.AltitudeEllipsoidMeters - from.AltitudeEllipsoidMeters) / dt
+	return s
+}
+
+func stationary(w Waypoint) state {
+	return state{
+		latitudeDegrees:         w.LatitudeDegrees,
+		longitudeDegrees:        w.LongitudeDegrees,
+		altitudeEllipsoidMeters: w.AltitudeEllipsoidMeters,
+		fixType:                 w.FixType,
+	}
+}
@@ -0,0 +1,3 @@
+// Package geodesy provides conversions between WGS84 geodetic coordinates and local metric frames, such as ECEF,
+// ENU, NED and UTM.
+package geodesy
@@ -0,0 +1,74 @@
+package geodesy
+
+import "math"
+
+// LLA is a WGS84 geodetic position.
+type LLA struct {
+	// Latitude component (degrees).
+	LatitudeDegrees float64
+	// Longitude component (degrees).
+	LongitudeDegrees float64
+	// AltitudeEllipsoid is the height above ellipsoid (m).
+	AltitudeEllipsoidMeters float64
+}
+
+// ECEF is a position or vector in the Earth-centered, Earth-fixed frame (m).
+type ECEF struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// ECEF returns the ECEF position of the geodetic position.
+func (p LLA) ECEF() ECEF {
+	lat := p.LatitudeDegrees * math.Pi / 180
+	lon := p.LongitudeDegrees * math.Pi / 180
+	sinLat, cosLat := math.Sincos(lat)
+	sinLon, cosLon := math.Sincos(lon)
+	// prime vertical radius of curvature
+	n := SemiMajorAxisMeters / math.Sqrt(1-eccentricitySquared*sinLat*sinLat)
+	h := p.AltitudeEllipsoidMeters
+	return ECEF{
+		X: (n + h) * cosLat * cosLon,
+		Y: (n + h) * cosLat * sinLon,
+		Z: (n*(1-eccentricitySquared) + h) * sinLat,
+	}
+}
+
+// LLA returns the geodetic position of the ECEF position.
+//
+// Uses the closed-form solution by Heikkinen, which is exact for positions outside the core of the Earth.
+func (p ECEF) LLA() LLA {
+	const (
+		a  = SemiMajorAxisMeters
+		b  = SemiMinorAxisMeters
+		e2 = eccentricitySquared
+	)
+	r := math.Hypot(p.X, p.Y)
+	f := 54 * b * b * p.Z * p.Z
+	g := r*r + (1-e2)*p.Z*p.Z - e2*(a*a-b*b)
+	c := e2 * e2 * f * r * r / (g * g * g)
+	s := math.Cbrt(1 + c + math.Sqrt(c*c+2*c))
+	k := s + 1 + 1/s
+	pp := f / (3 * k * k * g * g)
+	q := math.Sqrt(1 + 2*e2*e2*pp)
+	r0 := -pp*e2*r/(1+q) + math.Sqrt(a*a/2*(1+1/q)-pp*(1-e2)*p.Z*p.Z/(q*(1+q))-pp*r*r/2)
+	u := math.Hypot(r-e2*r0, p.Z)
+	v := math.Sqrt((r-e2*r0)*(r-e2*r0) + (1-e2)*p.Z*p.Z)
+	z0 := b * b * p.Z / (a * v)
+	return LLA{
+		LatitudeDegrees:         math.Atan2(p.Z+secondEccentricitySquared*z0, r) * 180 / math.Pi,
+		LongitudeDegrees:        math.Atan2(p.Y, p.X) * 180 / math.Pi,
+		AltitudeEllipsoidMeters: u * (1 - b*b/(a*v)),
+	}
+}
+
+// Add returns the sum of the ECEF vectors.
+func (p ECEF) Add(q ECEF) ECEF {
+	return ECEF{X: p.X + q.X, Y: p.Y + q.Y, Z: p.Z + q.Z}
+}
+
+// Sub returns the difference of the ECEF vectors.
+func (p ECEF) Sub(q ECEF) ECEF {
+	return ECEF{X: p.X - q.X, Y: p.Y - q.Y, Z: p.Z - q.Z}
+}
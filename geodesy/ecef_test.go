@@ -0,0 +1,60 @@
+package geodesy
+
+import (
+	"math"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLLA_ECEF(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		lla      LLA
+		expected ECEF
+	}{
+		{
+			name:     "equator prime meridian",
+			lla:      LLA{},
+			expected: ECEF{X: SemiMajorAxisMeters},
+		},
+		{
+			name:     "equator 90 east with altitude",
+			lla:      LLA{LongitudeDegrees: 90, AltitudeEllipsoidMeters: 100},
+			expected: ECEF{Y: SemiMajorAxisMeters + 100},
+		},
+		{
+			name:     "north pole",
+			lla:      LLA{LatitudeDegrees: 90},
+			expected: ECEF{Z: SemiMinorAxisMeters},
+		},
+		{
+			name:     "south pole",
+			lla:      LLA{LatitudeDegrees: -90, AltitudeEllipsoidMeters: -10},
+			expected: ECEF{Z: -SemiMinorAxisMeters + 10},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.lla.ECEF()
+			assert.Assert(t, math.Abs(actual.X-tt.expected.X) < 1e-6, "%+v", actual)
+			assert.Assert(t, math.Abs(actual.Y-tt.expected.Y) < 1e-6, "%+v", actual)
+			assert.Assert(t, math.Abs(actual.Z-tt.expected.Z) < 1e-6, "%+v", actual)
+		})
+	}
+}
+
+func TestECEF_LLA_RoundTrip(t *testing.T) {
+	for _, lla := range []LLA{
+		{LatitudeDegrees: 57.7, LongitudeDegrees: 12.7, AltitudeEllipsoidMeters: 70},
+		{LatitudeDegrees: -33.86, LongitudeDegrees: 151.21, AltitudeEllipsoidMeters: 20},
+		{LatitudeDegrees: 0, LongitudeDegrees: -179.9, AltitudeEllipsoidMeters: -50},
+		{LatitudeDegrees: 89.99, LongitudeDegrees: 45, AltitudeEllipsoidMeters: 3000},
+		{LatitudeDegrees: 45, LongitudeDegrees: -90, AltitudeEllipsoidMeters: 400000},
+	} {
+		actual := lla.ECEF().LLA()
+		assert.Assert(t, math.Abs(actual.LatitudeDegrees-lla.LatitudeDegrees) < 1e-9, "%+v", actual)
+		assert.Assert(t, math.Abs(actual.LongitudeDegrees-lla.LongitudeDegrees) < 1e-9, "%+v", actual)
+		assert.Assert(t, math.Abs(actual.AltitudeEllipsoidMeters-lla.AltitudeEllipsoidMeters) < 1e-4, "%+v", actual)
+	}
+}
@@ -0,0 +1,48 @@
+package geodesy
+
+import "go.einride.tech/reach/erb"
+
+// LLAFromPOS returns the geodetic position of a POS message.
+func LLAFromPOS(pos erb.POS) LLA {
+	return LLA{
+		LatitudeDegrees:         pos.LatitudeDegrees,
+		LongitudeDegrees:        pos.LongitudeDegrees,
+		AltitudeEllipsoidMeters: pos.AltitudeEllipsoidMeters,
+	}
+}
+
+// VelocityNED returns the velocity of a VEL message in the NED frame at the receiver (m/s).
+func VelocityNED(vel erb.VEL) NED {
+	return NED{
+		North: float64(vel.NorthCentimetersPerSecond) / 1e2,
+		East:  float64(vel.EastCentimetersPerSecond) / 1e2,
+		Down:  float64(vel.DownCentimetersPerSecond) / 1e2,
+	}
+}
+
+// ENUFromPOS returns the position of a POS message in the ENU frame.
+func (f *LocalFrame) ENUFromPOS(pos erb.POS) ENU {
+	return f.ENU(LLAFromPOS(pos))
+}
+
+// NEDFromPOS returns the position of a POS message in the NED frame.
+func (f *LocalFrame) NEDFromPOS(pos erb.POS) NED {
+	return f.NED(LLAFromPOS(pos))
+}
+
+// ENUFromVEL returns the velocity of a VEL message in the ENU frame (m/s).
+//
+// The velocity of the VEL message is relative to the local frame at the receiver, and is rotated into the frame
+// using the receiver position of the POS message of the same epoch.
+func (f *LocalFrame) ENUFromVEL(pos erb.POS, vel erb.VEL) ENU {
+	receiverFrame := LocalFrame{}
+	receiverFrame.east, receiverFrame.north, receiverFrame.up = enuAxes(LLAFromPOS(pos))
+	return f.ENUFromECEFVector(receiverFrame.ECEFVectorFromENU(VelocityNED(vel).ENU()))
+}
+
+// NEDFromVEL returns the velocity of a VEL message in the NED frame (m/s).
+//
+// See ENUFromVEL.
+func (f *LocalFrame) NEDFromVEL(pos erb.POS, vel erb.VEL) NED {
+	return f.ENUFromVEL(pos, vel).NED()
+}
@@ -0,0 +1,94 @@
+package geodesy
+
+import "math"
+
+// ENU is a position or vector in a local East-North-Up frame (m or m/s).
+type ENU struct {
+	East  float64
+	North float64
+	Up    float64
+}
+
+// NED returns the vector in the North-East-Down frame with the same origin.
+func (v ENU) NED() NED {
+	return NED{North: v.North, East: v.East, Down: -v.Up}
+}
+
+// NED is a position or vector in a local North-East-Down frame (m or m/s).
+type NED struct {
+	North float64
+	East  float64
+	Down  float64
+}
+
+// ENU returns the vector in the East-North-Up frame with the same origin.
+func (v NED) ENU() ENU {
+	return ENU{East: v.East, North: v.North, Up: -v.Down}
+}
+
+// LocalFrame is a local tangent plane frame, with an origin given by a geodetic position.
+type LocalFrame struct {
+	origin     LLA
+	originECEF ECEF
+	// rotation from ECEF to ENU, by rows
+	east, north, up ECEF
+}
+
+// NewLocalFrame returns a new local tangent plane frame with the provided origin.
+func NewLocalFrame(origin LLA) *LocalFrame {
+	east, north, up := enuAxes(origin)
+	return &LocalFrame{origin: origin, originECEF: origin.ECEF(), east: east, north: north, up: up}
+}
+
+// Origin returns the origin of the frame.
+func (f *LocalFrame) Origin() LLA {
+	return f.origin
+}
+
+// ENU returns the position of the geodetic position in the ENU frame.
+func (f *LocalFrame) ENU(p LLA) ENU {
+	return f.ENUFromECEFVector(p.ECEF().Sub(f.originECEF))
+}
+
+// NED returns the position of the geodetic position in the NED frame.
+func (f *LocalFrame) NED(p LLA) NED {
+	return f.ENU(p).NED()
+}
+
+// LLAFromENU returns the geodetic position of a position in the ENU frame.
+func (f *LocalFrame) LLAFromENU(p ENU) LLA {
+	return f.originECEF.Add(f.ECEFVectorFromENU(p)).LLA()
+}
+
+// LLAFromNED returns the geodetic position of a position in the NED frame.
+func (f *LocalFrame) LLAFromNED(p NED) LLA {
+	return f.LLAFromENU(p.ENU())
+}
+
+// ENUFromECEFVector rotates an ECEF vector, such as a velocity, into the ENU frame.
+func (f *LocalFrame) ENUFromECEFVector(v ECEF) ENU {
+	return ENU{East: dot(f.east, v), North: dot(f.north, v), Up: dot(f.up, v)}
+}
+
+// ECEFVectorFromENU rotates an ENU vector, such as a velocity, into the ECEF frame.
+func (f *LocalFrame) ECEFVectorFromENU(v ENU) ECEF {
+	return ECEF{
+		X: f.east.X*v.East + f.north.X*v.North + f.up.X*v.Up,
+		Y: f.east.Y*v.East + f.north.Y*v.North + f.up.Y*v.Up,
+		Z: f.east.Z*v.East + f.north.Z*v.North + f.up.Z*v.Up,
+	}
+}
+
+// enuAxes returns the ECEF unit vectors of the east, north and up axes at a geodetic position.
+func enuAxes(p LLA) (east, north, up ECEF) {
+	sinLat, cosLat := math.Sincos(p.LatitudeDegrees * math.Pi / 180)
+	sinLon, cosLon := math.Sincos(p.LongitudeDegrees * math.Pi / 180)
+	east = ECEF{X: -sinLon, Y: cosLon}
+	north = ECEF{X: -sinLat * cosLon, Y: -sinLat * sinLon, Z: cosLat}
+	up = ECEF{X: cosLat * cosLon, Y: cosLat * sinLon, Z: sinLat}
+	return east, north, up
+}
+
+func dot(a, b ECEF) float64 {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
@@ -0,0 +1,76 @@
+package geodesy
+
+import (
+	"math"
+	"testing"
+
+	"go.einride.tech/reach/erb"
+	"gotest.tools/v3/assert"
+)
+
+func TestLocalFrame(t *testing.T) {
+	origin := LLA{LatitudeDegrees: 57.7, LongitudeDegrees: 12.7, AltitudeEllipsoidMeters: 70}
+	f := NewLocalFrame(origin)
+	assert.Equal(t, origin, f.Origin())
+	t.Run("origin", func(t *testing.T) {
+		assertENU(t, ENU{}, f.ENU(origin), 1e-6)
+	})
+	t.Run("up", func(t *testing.T) {
+		p := origin
+		p.AltitudeEllipsoidMeters += 10
+		assertENU(t, ENU{Up: 10}, f.ENU(p), 1e-6)
+		assert.Assert(t, math.Abs(f.NED(p).Down+10) < 1e-6)
+	})
+	t.Run("north", func(t *testing.T) {
+		// one arc second of latitude is about 30.9 m at 57.7 degrees
+		p := origin
+		p.LatitudeDegrees += 1.0 / 3600
+		enu := f.ENU(p)
+		assert.Assert(t, math.Abs(enu.East) < 1e-6, "%+v", enu)
+		assert.Assert(t, math.Abs(enu.North-30.9) < 0.1, "%+v", enu)
+	})
+	t.Run("east", func(t *testing.T) {
+		p := origin
+		p.LongitudeDegrees += 1.0 / 3600
+		enu := f.ENU(p)
+		assert.Assert(t, math.Abs(enu.East-16.6) < 0.1, "%+v", enu)
+		// the tangent plane drops away from the ellipsoid
+		assert.Assert(t, enu.Up < 0 && enu.Up > -1e-3, "%+v", enu)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		for _, enu := range []ENU{
+			{East: 100, North: 200, Up: 3},
+			{East: -2500, North: 1000, Up: -40},
+		} {
+			assertENU(t, enu, f.ENU(f.LLAFromENU(enu)), 1e-6)
+			assertENU(t, enu, f.ENU(f.LLAFromNED(enu.NED())), 1e-6)
+		}
+	})
+}
+
+func TestLocalFrame_POS_VEL(t *testing.T) {
+	pos := erb.POS{LatitudeDegrees: 57.7, LongitudeDegrees: 12.7, AltitudeEllipsoidMeters: 70}
+	vel := erb.VEL{NorthCentimetersPerSecond: 300, EastCentimetersPerSecond: -400, DownCentimetersPerSecond: 10}
+	t.Run("frame at receiver", func(t *testing.T) {
+		f := NewLocalFrame(LLAFromPOS(pos))
+		assertENU(t, ENU{}, f.ENUFromPOS(pos), 1e-6)
+		assertENU(t, ENU{East: -4, North: 3, Up: -0.1}, f.ENUFromVEL(pos, vel), 1e-9)
+		assert.DeepEqual(t, NED{North: 3, East: -4, Down: 0.1}, VelocityNED(vel))
+	})
+	t.Run("distant frame", func(t *testing.T) {
+		f := NewLocalFrame(LLA{LatitudeDegrees: 57.7, LongitudeDegrees: 11.7})
+		enu := f.ENUFromVEL(pos, vel)
+		// the velocity is rotated, but keeps its magnitude
+		assert.Assert(t, math.Abs(math.Sqrt(enu.East*enu.East+enu.North*enu.North+enu.Up*enu.Up)-math.Sqrt(25.01)) < 1e-9)
+		assert.Assert(t, math.Abs(enu.North-3) > 1e-3, "%+v", enu)
+		ned := f.NEDFromPOS(pos)
+		assert.Assert(t, ned.East > 59000 && ned.East < 60000, "%+v", ned)
+	})
+}
+
+func assertENU(t *testing.T, expected, actual ENU, tolerance float64) {
+	t.Helper()
+	assert.Assert(t, math.Abs(expected.East-actual.East) < tolerance, "expected %+v but got %+v", expected, actual)
+	assert.Assert(t, math.Abs(expected.North-actual.North) < tolerance, "expected %+v but got %+v", expected, actual)
+	assert.Assert(t, math.Abs(expected.Up-actual.Up) < tolerance, "expected %+v but got %+v", expected, actual)
+}
@@ -0,0 +1,164 @@
+package geodesy
+
+import (
+	"fmt"
+	"math"
+)
+
+// UTM projection parameters.
+const (
+	utmScaleFactor        = 0.9996
+	utmFalseEasting       = 500000.0
+	utmFalseNorthingSouth = 10000000.0
+	utmMinLatitudeDegrees = -80.0
+	utmMaxLatitudeDegrees = 84.0
+)
+
+// UTM is a position in the Universal Transverse Mercator projection.
+type UTM struct {
+	// Zone is the longitude zone, from 1 to 60.
+	Zone int
+	// North is true for positions in the northern hemisphere.
+	North bool
+	// Easting is the easting, including the false easting of 500 km (m).
+	Easting float64
+	// Northing is the northing, including the false northing of 10000 km in the southern hemisphere (m).
+	Northing float64
+}
+
+// String returns the UTM position on the form 33N 340000.000 6400000.000.
+func (u UTM) String() string {
+	hemisphere := 'S'
+	if u.North {
+		hemisphere = 'N'
+	}
+	return fmt.Sprintf("%d%c %.3f %.3f", u.Zone, hemisphere, u.Easting, u.Northing)
+}
+
+// UTMZone returns the UTM zone of a geodetic position, including the exceptions for Norway and Svalbard.
+func UTMZone(latitudeDegrees, longitudeDegrees float64) int {
+	lon := math.Mod(longitudeDegrees+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	zone := int(lon/6) + 1
+	switch {
+	case latitudeDegrees >= 56 && latitudeDegrees < 64 && longitudeDegrees >= 3 && longitudeDegrees < 12:
+		zone = 32
+	case latitudeDegrees >= 72 && latitudeDegrees <= 84 && longitudeDegrees >= 0 && longitudeDegrees < 42:
+		switch {
+		case longitudeDegrees < 9:
+			zone = 31
+		case longitudeDegrees < 21:
+			zone = 33
+		case longitudeDegrees < 33:
+			zone = 35
+		default:
+			zone = 37
+		}
+	}
+	return zone
+}
+
+// ToUTM projects a geodetic position to UTM, in the zone of the position.
+func ToUTM(p LLA) (UTM, error) {
+	return ToUTMZone(p, UTMZone(p.LatitudeDegrees, p.LongitudeDegrees))
+}
+
+// ToUTMZone projects a geodetic position to UTM in the provided zone.
+//
+// Projecting into a neighboring zone is useful for keeping a continuous grid when moving across a zone boundary.
+func ToUTMZone(p LLA, zone int) (UTM, error) {
+	if zone < 1 || zone > 60 {
+		return UTM{}, fmt.Errorf("to UTM: invalid zone %d", zone)
+	}
+	if p.LatitudeDegrees < utmMinLatitudeDegrees || p.LatitudeDegrees > utmMaxLatitudeDegrees {
+		return UTM{}, fmt.Errorf("to UTM: latitude %v outside UTM limits", p.LatitudeDegrees)
+	}
+	lat := p.LatitudeDegrees * math.Pi / 180
+	dLon := normalizeRadians((p.LongitudeDegrees - utmCentralMeridianDegrees(zone)) * math.Pi / 180)
+	c := 2 * math.Sqrt(utmN) / (1 + utmN)
+	sinLat := math.Sin(lat)
+	t := math.Sinh(math.Atanh(sinLat) - c*math.Atanh(c*sinLat))
+	xi := math.Atan2(t, math.Cos(dLon))
+	eta := math.Atanh(math.Sin(dLon) / math.Sqrt(1+t*t))
+	easting, northing := eta, xi
+	for j, alpha := range utmAlpha {
+		k := 2 * float64(j+1)
+		easting += alpha * math.Cos(k*xi) * math.Sinh(k*eta)
+		northing += alpha * math.Sin(k*xi) * math.Cosh(k*eta)
+	}
+	u := UTM{
+		Zone:     zone,
+		North:    p.LatitudeDegrees >= 0,
+		Easting:  utmFalseEasting + utmScaleFactor*utmA*easting,
+		Northing: utmScaleFactor * utmA * northing,
+	}
+	if !u.North {
+		u.Northing += utmFalseNorthingSouth
+	}
+	return u, nil
+}
+
+// LLA returns the geodetic position of the UTM position, with zero altitude.
+func (u UTM) LLA() (LLA, error) {
+	if u.Zone < 1 || u.Zone > 60 {
+		return LLA{}, fmt.Errorf("from UTM: invalid zone %d", u.Zone)
+	}
+	northing := u.Northing
+	if !u.North {
+		northing -= utmFalseNorthingSouth
+	}
+	xi := northing / (utmScaleFactor * utmA)
+	eta := (u.Easting - utmFalseEasting) / (utmScaleFactor * utmA)
+	xiPrime, etaPrime := xi, eta
+	for j, beta := range utmBeta {
+		k := 2 * float64(j+1)
+		xiPrime -= beta * math.Sin(k*xi) * math.Cosh(k*eta)
+		etaPrime -= beta * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+	chi := math.Asin(math.Sin(xiPrime) / math.Cosh(etaPrime))
+	lat := chi
+	for j, delta := range utmDelta {
+		lat += delta * math.Sin(2*float64(j+1)*chi)
+	}
+	dLon := math.Atan2(math.Sinh(etaPrime), math.Cos(xiPrime))
+	return LLA{
+		LatitudeDegrees:  lat * 180 / math.Pi,
+		LongitudeDegrees: normalizeRadians(utmCentralMeridianDegrees(u.Zone)*math.Pi/180+dLon) * 180 / math.Pi,
+	}, nil
+}
+
+func utmCentralMeridianDegrees(zone int) float64 {
+	return float64(zone)*6 - 183
+}
+
+// normalizeRadians normalizes an angle to [-π, π).
+func normalizeRadians(a float64) float64 {
+	a = math.Mod(a+math.Pi, 2*math.Pi)
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+	return a - math.Pi
+}
+
+// Coefficients of the Krüger series of the transverse Mercator projection, to third order in the third flattening.
+var (
+	utmN     = Flattening / (2 - Flattening)
+	utmA     = SemiMajorAxisMeters / (1 + utmN) * (1 + utmN*utmN/4 + utmN*utmN*utmN*utmN/64)
+	utmAlpha = [...]float64{
+		utmN/2 - 2*utmN*utmN/3 + 5*utmN*utmN*utmN/16,
+		13*utmN*utmN/48 - 3*utmN*utmN*utmN/5,
+		61 * utmN * utmN * utmN / 240,
+	}
+	utmBeta = [...]float64{
+		utmN/2 - 2*utmN*utmN/3 + 37*utmN*utmN*utmN/96,
+		utmN*utmN/48 + utmN*utmN*utmN/15,
+		17 * utmN * utmN * utmN / 480,
+	}
+	utmDelta = [...]float64{
+		2*utmN - 2*utmN*utmN/3 - 2*utmN*utmN*utmN,
+		7*utmN*utmN/3 - 8*utmN*utmN*utmN/5,
+		56 * utmN * utmN * utmN / 15,
+	}
+)
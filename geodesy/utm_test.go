@@ -0,0 +1,102 @@
+package geodesy
+
+import (
+	"math"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestUTMZone(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		expected  int
+	}{
+		{name: "prime meridian", latitude: 51.5, longitude: 0, expected: 31},
+		{name: "west", latitude: 40.7, longitude: -74, expected: 18},
+		{name: "date line", latitude: 0, longitude: 180, expected: 1},
+		{name: "just west of date line", latitude: 0, longitude: 179.9, expected: 60},
+		{name: "Gothenburg", latitude: 57.7, longitude: 11.97, expected: 32},
+		{name: "Bergen", latitude: 60.39, longitude: 5.32, expected: 32},
+		{name: "Svalbard", latitude: 78.2, longitude: 15.6, expected: 33},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, UTMZone(tt.latitude, tt.longitude))
+		})
+	}
+}
+
+func TestToUTM(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		lla      LLA
+		expected UTM
+	}{
+		{
+			name:     "central meridian at equator",
+			lla:      LLA{LatitudeDegrees: 0, LongitudeDegrees: 3},
+			expected: UTM{Zone: 31, North: true, Easting: 500000, Northing: 0},
+		},
+		{
+			name:     "CN Tower",
+			lla:      LLA{LatitudeDegrees: 43.642567, LongitudeDegrees: -79.387139},
+			expected: UTM{Zone: 17, North: true, Easting: 630084, Northing: 4833439},
+		},
+		{
+			name:     "southern hemisphere",
+			lla:      LLA{LatitudeDegrees: -1e-9, LongitudeDegrees: 3},
+			expected: UTM{Zone: 31, North: false, Easting: 500000, Northing: 10000000},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ToUTM(tt.lla)
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected.Zone, actual.Zone)
+			assert.Equal(t, tt.expected.North, actual.North)
+			assert.Assert(t, math.Abs(tt.expected.Easting-actual.Easting) < 1, actual.String())
+			assert.Assert(t, math.Abs(tt.expected.Northing-actual.Northing) < 1, actual.String())
+		})
+	}
+}
+
+func TestToUTM_Errors(t *testing.T) {
+	_, err := ToUTM(LLA{LatitudeDegrees: 85})
+	assert.Error(t, err, "to UTM: latitude 85 outside UTM limits")
+	_, err = ToUTMZone(LLA{}, 61)
+	assert.Error(t, err, "to UTM: invalid zone 61")
+}
+
+func TestUTM_RoundTrip(t *testing.T) {
+	for _, lla := range []LLA{
+		{LatitudeDegrees: 57.7, LongitudeDegrees: 11.97},
+		{LatitudeDegrees: -33.86, LongitudeDegrees: 151.21},
+		{LatitudeDegrees: 83.9, LongitudeDegrees: -45.5},
+		{LatitudeDegrees: -79.9, LongitudeDegrees: 179.9},
+	} {
+		u, err := ToUTM(lla)
+		assert.NilError(t, err)
+		actual, err := u.LLA()
+		assert.NilError(t, err)
+		assert.Assert(t, math.Abs(actual.LatitudeDegrees-lla.LatitudeDegrees) < 1e-8, "%+v %v", actual, u)
+		assert.Assert(t, math.Abs(actual.LongitudeDegrees-lla.LongitudeDegrees) < 1e-8, "%+v %v", actual, u)
+	}
+}
+
+func TestToUTMZone_Neighbor(t *testing.T) {
+	// a position just east of the zone boundary projected into the western zone
+	lla := LLA{LatitudeDegrees: 50, LongitudeDegrees: 12.01}
+	native, err := ToUTM(lla)
+	assert.NilError(t, err)
+	assert.Equal(t, 33, native.Zone)
+	neighbor, err := ToUTMZone(lla, 32)
+	assert.NilError(t, err)
+	assert.Equal(t, 32, neighbor.Zone)
+	assert.Assert(t, neighbor.Easting > 700000)
+	actual, err := neighbor.LLA()
+	assert.NilError(t, err)
+	assert.Assert(t, math.Abs(actual.LongitudeDegrees-lla.LongitudeDegrees) < 1e-8)
+}
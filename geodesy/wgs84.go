@@ -0,0 +1,15 @@
+package geodesy
+
+// WGS84 ellipsoid parameters.
+const (
+	// SemiMajorAxisMeters is the semi-major axis of the WGS84 ellipsoid (m).
+	SemiMajorAxisMeters = 6378137.0
+	// Flattening is the flattening of the WGS84 ellipsoid.
+	Flattening = 1 / 298.257223563
+	// SemiMinorAxisMeters is the semi-minor axis of the WGS84 ellipsoid (m).
+	SemiMinorAxisMeters = SemiMajorAxisMeters * (1 - Flattening)
+	// eccentricitySquared is the first eccentricity squared of the WGS84 ellipsoid.
+	eccentricitySquared = Flattening * (2 - Flattening)
+	// secondEccentricitySquared is the second eccentricity squared of the WGS84 ellipsoid.
+	secondEccentricitySquared = eccentricitySquared / (1 - eccentricitySquared)
+)
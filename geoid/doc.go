@@ -0,0 +1,6 @@
+// Package geoid provides geoid models for converting between heights above the WGS84 ellipsoid and heights above
+// mean sea level.
+//
+// Models are loaded from grid files of geoid undulations, such as the EGM96 and EGM2008 grids distributed by NGA
+// (WW15MGH.GRD) and GeographicLib (egm96-15.pgm, egm2008-1.pgm).
+package geoid
@@ -0,0 +1,48 @@
+package geoid
+
+import "go.einride.tech/reach/erb"
+
+// Annotation compares the mean sea level altitude of a POS message with a geoid model.
+type Annotation struct {
+	// ReceiverUndulation is the geoid undulation used by the receiver, given by the difference between the
+	// ellipsoid and mean sea level altitudes of the POS message (m).
+	ReceiverUndulationMeters float64
+	// ModelUndulation is the geoid undulation of the model at the position of the POS message (m).
+	ModelUndulationMeters float64
+	// AltitudeMeanSeaLevel is the height above mean sea level according to the model (m).
+	AltitudeMeanSeaLevelMeters float64
+}
+
+// DifferenceMeters returns the difference between the receiver and model undulations (m).
+func (a Annotation) DifferenceMeters() float64 {
+	return a.ReceiverUndulationMeters - a.ModelUndulationMeters
+}
+
+// AltitudeMeanSeaLevel returns the height above mean sea level of a height above the WGS84 ellipsoid (m).
+func (m *Model) AltitudeMeanSeaLevel(latitudeDegrees, longitudeDegrees, altitudeEllipsoidMeters float64) float64 {
+	return altitudeEllipsoidMeters - m.Undulation(latitudeDegrees, longitudeDegrees)
+}
+
+// AltitudeEllipsoid returns the height above the WGS84 ellipsoid of a height above mean sea level (m).
+func (m *Model) AltitudeEllipsoid(latitudeDegrees, longitudeDegrees, altitudeMeanSeaLevelMeters float64) float64 {
+	return altitudeMeanSeaLevelMeters + m.Undulation(latitudeDegrees, longitudeDegrees)
+}
+
+// Annotate compares the mean sea level altitude of a POS message with the model.
+func (m *Model) Annotate(pos erb.POS) Annotation {
+	undulation := m.Undulation(pos.LatitudeDegrees, pos.LongitudeDegrees)
+	return Annotation{
+		ReceiverUndulationMeters:   pos.AltitudeEllipsoidMeters - pos.AltitudeMeanSeaLevelMeters,
+		ModelUndulationMeters:      undulation,
+		AltitudeMeanSeaLevelMeters: pos.AltitudeEllipsoidMeters - undulation,
+	}
+}
+
+// RecomputePOS returns the POS message with the mean sea level altitude recomputed from the ellipsoid altitude with
+// the model.
+func (m *Model) RecomputePOS(pos erb.POS) erb.POS {
+	pos.AltitudeMeanSeaLevelMeters = m.AltitudeMeanSeaLevel(
+		pos.LatitudeDegrees, pos.LongitudeDegrees, pos.AltitudeEllipsoidMeters,
+	)
+	return pos
+}
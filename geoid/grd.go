@@ -0,0 +1,62 @@
+package geoid
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ReadGRD reads a geoid model from a grid file in the ASCII GRD format of NGA, such as the EGM96 grid WW15MGH.GRD.
+//
+// The file starts with a header of the south, north, west and east bounds and the latitude and longitude spacing of
+// the grid in degrees, followed by the undulations in meters by row from north to south and column from west to east.
+func ReadGRD(name string, r io.Reader) (*Model, error) {
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanWords)
+	var header [6]float64
+	for i := range header {
+		if !sc.Scan() {
+			return nil, fmt.Errorf("read GRD %s: header: %w", name, scanErr(sc))
+		}
+		v, err := strconv.ParseFloat(sc.Text(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("read GRD %s: header: %w", name, err)
+		}
+		header[i] = v
+	}
+	south, north, west, east := header[0], header[1], header[2], header[3]
+	deltaLatitude, deltaLongitude := header[4], header[5]
+	if deltaLatitude <= 0 || deltaLongitude <= 0 || north <= south || east <= west {
+		return nil, fmt.Errorf("read GRD %s: invalid header %v", name, header)
+	}
+	rows := int(math.Round((north-south)/deltaLatitude)) + 1
+	cols := int(math.Round((east-west)/deltaLongitude)) + 1
+	m, err := newModel(name, north, west, deltaLatitude, deltaLongitude, rows, cols)
+	if err != nil {
+		return nil, fmt.Errorf("read GRD %s: %w", name, err)
+	}
+	undulations := make([]float32, 0, rows*cols)
+	for len(undulations) < rows*cols {
+		if !sc.Scan() {
+			return nil, fmt.Errorf("read GRD %s: expected %d values but got %d: %w", name, rows*cols, len(undulations),
+				scanErr(sc))
+		}
+		v, err := strconv.ParseFloat(sc.Text(), 32)
+		if err != nil {
+			return nil, fmt.Errorf("read GRD %s: value %d: %w", name, len(undulations), err)
+		}
+		undulations = append(undulations, float32(v))
+	}
+	m.undulations = undulations
+	return m, nil
+}
+
+// scanErr returns the error of a scanner that stopped, with io.ErrUnexpectedEOF at the end of the input.
+func scanErr(sc *bufio.Scanner) error {
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	return io.ErrUnexpectedEOF
+}
@@ -0,0 +1,108 @@
+package geoid
+
+import (
+	"fmt"
+	"math"
+)
+
+// Model is a geoid model, given by a regular grid of geoid undulations.
+type Model struct {
+	name                 string
+	northLatitudeDegrees float64
+	westLongitudeDegrees float64
+	deltaLatitude        float64
+	deltaLongitude       float64
+	rows                 int
+	cols                 int
+	// periodCols is the number of columns covering 360 degrees of longitude, or 0 if the grid is not global.
+	periodCols int
+	// undulations in meters, by row from north to south and column from west to east
+	undulations []float32
+	// raw undulations, as an alternative to undulations for compact grids, given by offset + scale*raw
+	raw    []uint16
+	offset float64
+	scale  float64
+}
+
+// newModel returns a new model of a grid with rows from north to south and columns from west to east.
+//
+// The values of the grid are set by the caller.
+func newModel(
+	name string,
+	northLatitudeDegrees, westLongitudeDegrees, deltaLatitude, deltaLongitude float64,
+	rows, cols int,
+) (*Model, error) {
+	if rows < 2 || cols < 2 || deltaLatitude <= 0 || deltaLongitude <= 0 {
+		return nil, fmt.Errorf("invalid grid of %dx%d with spacing %vx%v", rows, cols, deltaLatitude, deltaLongitude)
+	}
+	m := &Model{
+		name:                 name,
+		northLatitudeDegrees: northLatitudeDegrees,
+		westLongitudeDegrees: westLongitudeDegrees,
+		deltaLatitude:        deltaLatitude,
+		deltaLongitude:       deltaLongitude,
+		rows:                 rows,
+		cols:                 cols,
+	}
+	if period := int(math.Round(360 / deltaLongitude)); cols >= period {
+		m.periodCols = period
+	}
+	return m, nil
+}
+
+// Name returns the name of the model.
+func (m *Model) Name() string {
+	return m.name
+}
+
+// Undulation returns the geoid undulation at a geodetic position, by bilinear interpolation of the grid (m).
+//
+// The undulation is the height of the geoid above the WGS84 ellipsoid. Positions outside the grid are clamped to the
+// edge of the grid. The undulation is NaN when the latitude or longitude is NaN or infinite.
+func (m *Model) Undulation(latitudeDegrees, longitudeDegrees float64) float64 {
+	if !isFinite(latitudeDegrees) || !isFinite(longitudeDegrees) {
+		return math.NaN()
+	}
+	y := clamp((m.northLatitudeDegrees-latitudeDegrees)/m.deltaLatitude, 0, float64(m.rows-1))
+	lon := longitudeDegrees - m.westLongitudeDegrees
+	if m.periodCols > 0 {
+		lon = math.Mod(lon, 360)
+		if lon < 0 {
+			lon += 360
+		}
+	}
+	x := lon / m.deltaLongitude
+	if m.periodCols == 0 {
+		x = clamp(x, 0, float64(m.cols-1))
+	}
+	row0, col0 := int(math.Floor(y)), int(math.Floor(x))
+	dy, dx := y-float64(row0), x-float64(col0)
+	row1, col1 := row0+1, col0+1
+	if row1 >= m.rows {
+		row1 = row0
+	}
+	if m.periodCols > 0 {
+		col0 %= m.periodCols
+		col1 %= m.periodCols
+	} else if col1 >= m.cols {
+		col1 = col0
+	}
+	v00, v01 := m.value(row0, col0), m.value(row0, col1)
+	v10, v11 := m.value(row1, col0), m.value(row1, col1)
+	return (1-dy)*((1-dx)*v00+dx*v01) + dy*((1-dx)*v10+dx*v11)
+}
+
+func (m *Model) value(row, col int) float64 {
+	if m.raw != nil {
+		return m.offset + m.scale*float64(m.raw[row*m.cols+col])
+	}
+	return float64(m.undulations[row*m.cols+col])
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+func clamp(v, min, max float64) float64 {
+	return math.Max(min, math.Min(max, v))
+}
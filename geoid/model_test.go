@@ -0,0 +1,133 @@
+package geoid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.einride.tech/reach/erb"
+	"gotest.tools/v3/assert"
+)
+
+// testGRD is a coarse global grid in the GRD format, with rows at latitudes 90, 0 and -90 and columns at longitudes
+// 0, 120, 240 and 360.
+const testGRD = `-90.0 90.0 0.0 360.0 90.0 120.0
+10.0 10.0 10.0 10.0
+0.0 30.0 60.0 0.0
+-20.0 -20.0 -20.0 -20.0
+`
+
+// newTestPGM returns the grid of testGRD in the PGM format.
+func newTestPGM(t *testing.T) []byte {
+	t.Helper()
+	const offset, scale = -100.0, 0.5
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "P5\n# Geoid test grid\n# Offset %v\n# Scale %v\n3 3\n65535\n", offset, scale)
+	for _, undulation := range []float64{10, 10, 10, 0, 30, 60, -20, -20, -20} {
+		assert.NilError(t, binary.Write(&buf, binary.BigEndian, uint16((undulation-offset)/scale)))
+	}
+	return buf.Bytes()
+}
+
+func TestModel_Undulation(t *testing.T) {
+	grd, err := ReadGRD("test.grd", strings.NewReader(testGRD))
+	assert.NilError(t, err)
+	pgm, err := ReadPGM("test.pgm", bytes.NewReader(newTestPGM(t)))
+	assert.NilError(t, err)
+	for _, m := range []*Model{grd, pgm} {
+		m := m
+		t.Run(m.Name(), func(t *testing.T) {
+			for _, tt := range []struct {
+				latitude  float64
+				longitude float64
+				expected  float64
+			}{
+				{latitude: 0, longitude: 0, expected: 0},
+				{latitude: 0, longitude: 120, expected: 30},
+				{latitude: 0, longitude: 60, expected: 15},
+				{latitude: 0, longitude: 300, expected: 30},
+				{latitude: 0, longitude: -60, expected: 30},
+				{latitude: 0, longitude: 360, expected: 0},
+				{latitude: 45, longitude: 0, expected: 5},
+				{latitude: -45, longitude: 120, expected: 5},
+				{latitude: 90, longitude: 200, expected: 10},
+				{latitude: -90, longitude: 0, expected: -20},
+			} {
+				actual := m.Undulation(tt.latitude, tt.longitude)
+				assert.Assert(
+					t,
+					math.Abs(tt.expected-actual) < 1e-9,
+					"%v, %v: expected %v but got %v", tt.latitude, tt.longitude, tt.expected, actual,
+				)
+			}
+		})
+	}
+}
+
+func TestModel_Undulation_NotFinite(t *testing.T) {
+	m, err := ReadGRD("test.grd", strings.NewReader(testGRD))
+	assert.NilError(t, err)
+	for _, position := range [][2]float64{
+		{math.NaN(), 0},
+		{0, math.NaN()},
+		{math.Inf(1), 0},
+		{0, math.Inf(-1)},
+	} {
+		assert.Assert(t, math.IsNaN(m.Undulation(position[0], position[1])), "%v", position)
+	}
+}
+
+func TestReadGRD_Truncated(t *testing.T) {
+	_, err := ReadGRD("test.grd", strings.NewReader(strings.TrimSuffix(testGRD, "-20.0 -20.0\n")))
+	assert.Error(t, err, "read GRD test.grd: expected 12 values but got 10: unexpected EOF")
+}
+
+func TestReadPGM_MissingScale(t *testing.T) {
+	pgm := bytes.Replace(newTestPGM(t), []byte("# Scale"), []byte("# Foo"), 1)
+	_, err := ReadPGM("test.pgm", bytes.NewReader(pgm))
+	assert.Error(t, err, "read PGM test.pgm: missing offset or scale in header")
+}
+
+func TestOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geoid")
+	assert.NilError(t, err)
+	defer func() {
+		assert.NilError(t, os.RemoveAll(dir))
+	}()
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "WW15MGH.GRD"), []byte(testGRD), 0o600))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "egm.pgm"), newTestPGM(t), 0o600))
+	for _, name := range []string{"WW15MGH.GRD", "egm.pgm"} {
+		m, err := Open(filepath.Join(dir, name))
+		assert.NilError(t, err)
+		assert.Equal(t, name, m.Name())
+		assert.Equal(t, 15.0, m.Undulation(0, 60))
+	}
+	_, err = Open(filepath.Join(dir, "egm.txt"))
+	assert.ErrorContains(t, err, "unknown format")
+}
+
+func TestModel_POS(t *testing.T) {
+	m, err := ReadGRD("test.grd", strings.NewReader(testGRD))
+	assert.NilError(t, err)
+	pos := erb.POS{
+		LatitudeDegrees:            0,
+		LongitudeDegrees:           60,
+		AltitudeEllipsoidMeters:    100,
+		AltitudeMeanSeaLevelMeters: 80,
+	}
+	annotation := m.Annotate(pos)
+	assert.Equal(t, 20.0, annotation.ReceiverUndulationMeters)
+	assert.Equal(t, 15.0, annotation.ModelUndulationMeters)
+	assert.Equal(t, 5.0, annotation.DifferenceMeters())
+	assert.Equal(t, 85.0, annotation.AltitudeMeanSeaLevelMeters)
+	recomputed := m.RecomputePOS(pos)
+	assert.Equal(t, 85.0, recomputed.AltitudeMeanSeaLevelMeters)
+	assert.Equal(t, pos.AltitudeEllipsoidMeters, recomputed.AltitudeEllipsoidMeters)
+	assert.Equal(t, 100.0, m.AltitudeEllipsoid(0, 60, 85))
+}
@@ -0,0 +1,31 @@
+package geoid
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Open a geoid model from a grid file, in a format given by the file extension (.grd or .pgm).
+func Open(filename string) (*Model, error) {
+	var read func(string, io.Reader) (*Model, error)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".grd":
+		read = ReadGRD
+	case ".pgm":
+		read = ReadPGM
+	default:
+		return nil, fmt.Errorf("open geoid model %s: unknown format", filename)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open geoid model: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return read(filepath.Base(filename), bufio.NewReaderSize(f, 1<<20))
+}
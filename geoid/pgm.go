@@ -0,0 +1,87 @@
+package geoid
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadPGM reads a geoid model from a grid file in the PGM format of GeographicLib, such as egm96-15.pgm or
+// egm2008-1.pgm.
+//
+// The file is a binary PGM image of 16-bit big-endian values, with the offset and scale of the undulations in the
+// header comments. The grid covers all latitudes from north to south and all longitudes eastward from 0 degrees.
+func ReadPGM(name string, r io.Reader) (*Model, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("read PGM %s: %w", name, err)
+	}
+	if strings.TrimSpace(magic) != "P5" {
+		return nil, fmt.Errorf("read PGM %s: unsupported format %q", name, strings.TrimSpace(magic))
+	}
+	offset, scale := 0.0, 0.0
+	var hasOffset, hasScale bool
+	var numbers []int
+	for len(numbers) < 3 {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("read PGM %s: header: %w", name, err)
+		}
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			fields := strings.Fields(strings.TrimPrefix(line, "#"))
+			if len(fields) != 2 {
+				continue
+			}
+			switch fields[0] {
+			case "Offset":
+				if offset, err = strconv.ParseFloat(fields[1], 64); err != nil {
+					return nil, fmt.Errorf("read PGM %s: offset: %w", name, err)
+				}
+				hasOffset = true
+			case "Scale":
+				if scale, err = strconv.ParseFloat(fields[1], 64); err != nil {
+					return nil, fmt.Errorf("read PGM %s: scale: %w", name, err)
+				}
+				hasScale = true
+			}
+			continue
+		}
+		for _, field := range strings.Fields(line) {
+			n, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("read PGM %s: header: %w", name, err)
+			}
+			numbers = append(numbers, n)
+		}
+	}
+	if !hasOffset || !hasScale {
+		return nil, fmt.Errorf("read PGM %s: missing offset or scale in header", name)
+	}
+	width, height, maxValue := numbers[0], numbers[1], numbers[2]
+	if maxValue != 0xffff || width < 2 || height < 2 {
+		return nil, fmt.Errorf("read PGM %s: unsupported image of %dx%d with max value %d", name, width, height, maxValue)
+	}
+	m, err := newModel(name, 90, 0, 180/float64(height-1), 360/float64(width), height, width)
+	if err != nil {
+		return nil, fmt.Errorf("read PGM %s: %w", name, err)
+	}
+	// keep the raw values, since the high-resolution grids are large
+	m.raw = make([]uint16, width*height)
+	m.offset = offset
+	m.scale = scale
+	row := make([]byte, 2*width)
+	for i := 0; i < height; i++ {
+		if _, err := io.ReadFull(br, row); err != nil {
+			return nil, fmt.Errorf("read PGM %s: row %d: %w", name, i, err)
+		}
+		for j := 0; j < width; j++ {
+			m.raw[i*width+j] = binary.BigEndian.Uint16(row[2*j:])
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,15 @@
+package reach
+
+// IncompleteEpochPolicy determines how a SolutionScanner handles navigation epochs with missing messages.
+type IncompleteEpochPolicy uint8
+
+//go:generate stringer -type IncompleteEpochPolicy -trimprefix IncompleteEpochPolicy
+
+const (
+	// IncompleteEpochPolicyEmit emits incomplete solutions, with the missing parts left empty.
+	IncompleteEpochPolicyEmit IncompleteEpochPolicy = iota
+	// IncompleteEpochPolicyDrop drops incomplete solutions.
+	IncompleteEpochPolicyDrop
+	// IncompleteEpochPolicyHoldLast emits incomplete solutions, with the missing parts held from the last solution.
+	IncompleteEpochPolicyHoldLast
+)
@@ -0,0 +1,62 @@
+package integrity
+
+import (
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// Config configures a Monitor.
+//
+// Thresholds with a zero value are not checked.
+type Config struct {
+	// AcceptedFixTypes are the fix types accepted for operation. Defaults to RTK.
+	AcceptedFixTypes []erb.FixType
+	// MinNumSVs is the minimum number of used space vehicles.
+	MinNumSVs uint8
+	// MaxHorizontalAccuracyMillimeters is the maximum horizontal accuracy estimate (mm).
+	MaxHorizontalAccuracyMillimeters uint32
+	// MaxVerticalAccuracyMillimeters is the maximum vertical accuracy estimate (mm).
+	MaxVerticalAccuracyMillimeters uint32
+	// MaxPositionDOP is the maximum position dilution of precision.
+	MaxPositionDOP float64
+	// GoodEpochs is the number of consecutive good epochs required before the solution becomes good. Defaults to 1.
+	GoodEpochs int
+	// BadEpochs is the number of consecutive bad epochs required before the solution becomes bad. Defaults to 1.
+	BadEpochs int
+	// MaxAge is the maximum time since the last evaluated epoch before the solution becomes bad. Defaults to 1s.
+	MaxAge time.Duration
+	// OnEvent is an optional callback invoked with each event of the monitor.
+	//
+	// The callback must not call methods of the monitor.
+	OnEvent func(Event)
+	// Now is an optional function returning the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+func (c *Config) setDefaults() {
+	if len(c.AcceptedFixTypes) == 0 {
+		c.AcceptedFixTypes = []erb.FixType{erb.FixTypeRTK}
+	}
+	if c.GoodEpochs <= 0 {
+		c.GoodEpochs = 1
+	}
+	if c.BadEpochs <= 0 {
+		c.BadEpochs = 1
+	}
+	if c.MaxAge <= 0 {
+		c.MaxAge = time.Second
+	}
+	if c.Now == nil {
+		c.Now = time.Now
+	}
+}
+
+func (c *Config) isAccepted(fixType erb.FixType) bool {
+	for _, accepted := range c.AcceptedFixTypes {
+		if fixType == accepted {
+			return true
+		}
+	}
+	return false
+}
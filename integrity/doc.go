@@ -0,0 +1,3 @@
+// Package integrity provides a position quality gate, that decides whether the navigation solution of a Reach is
+// good enough for autonomous operation.
+package integrity
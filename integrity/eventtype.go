@@ -0,0 +1,17 @@
+package integrity
+
+// EventType represents the type of a monitor event.
+type EventType uint8
+
+//go:generate stringer -type EventType -trimprefix EventType
+
+const (
+	// EventTypeGood is the event when the solution becomes good enough for operation.
+	EventTypeGood EventType = iota
+	// EventTypeBad is the event when the solution is no longer good enough for operation.
+	EventTypeBad
+	// EventTypeFixDegraded is the event when the fix type degrades, for example from RTK to float.
+	EventTypeFixDegraded
+	// EventTypeFixImproved is the event when the fix type improves, for example from float to RTK.
+	EventTypeFixImproved
+)
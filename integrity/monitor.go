@@ -0,0 +1,263 @@
+package integrity
+
+import (
+	"sync"
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// Event is an event of a Monitor.
+type Event struct {
+	// Type of the event.
+	Type EventType
+	// TimeGPS is the time of week in milliseconds of the navigation epoch that caused the event.
+	TimeGPS uint32
+	// FixType is the fix type of the epoch.
+	FixType erb.FixType
+	// PreviousFixType is the fix type of the previous epoch.
+	PreviousFixType erb.FixType
+	// Violation is the set of violated thresholds of the epoch.
+	Violation Violation
+}
+
+// Status is the status of a Monitor.
+type Status struct {
+	// Good is true when the solution is good enough for operation.
+	Good bool
+	// TimeGPS is the time of week in milliseconds of the last evaluated navigation epoch.
+	TimeGPS uint32
+	// FixType is the fix type of the last evaluated epoch.
+	FixType erb.FixType
+	// Violation is the set of violated thresholds of the last evaluated epoch.
+	Violation Violation
+}
+
+// Monitor is a position quality gate fed by the STAT, POS and DOPS messages of a Reach.
+//
+// The messages of each navigation epoch are correlated by their TimeGPS, and the epoch is evaluated against the
+// thresholds when all three messages have been received, or when the next epoch starts. Late messages of epochs
+// before the current epoch are ignored. The solution becomes good after a configurable number of consecutive good
+// epochs, and bad after a configurable number of consecutive bad epochs.
+//
+// The solution is stale when no epoch has been evaluated within the maximum age, and then reported as bad by Status.
+// Stale solutions are detected when Check is called. Update, Status and Check may be called from different
+// goroutines.
+type Monitor struct {
+	mu          sync.Mutex
+	cfg         Config
+	status      Status
+	hasEpoch    bool
+	evaluated   bool
+	timeGPS     uint32
+	stat        erb.STAT
+	pos         erb.POS
+	dops        erb.DOPS
+	hasSTAT     bool
+	hasPOS      bool
+	hasDOPS     bool
+	hasFixType  bool
+	goodEpochs  int
+	badEpochs   int
+	prevFixType erb.FixType
+	// lastEvaluation is the time at which the last epoch was evaluated.
+	lastEvaluation time.Time
+}
+
+// NewMonitor returns a new Monitor with the provided config.
+//
+// The solution is initially bad.
+func NewMonitor(cfg Config) *Monitor {
+	cfg.setDefaults()
+	return &Monitor{cfg: cfg}
+}
+
+// Status returns the current status of the monitor.
+//
+// A stale solution is reported as bad, with a stale violation.
+func (m *Monitor) Status() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status := m.status
+	if m.isStale(m.cfg.Now()) {
+		status.Good = false
+		status.Violation |= ViolationStale
+	}
+	return status
+}
+
+// Check the monitor for a stale solution.
+//
+// Check should be called periodically, to emit a bad event when the messages of the receiver stop. The solution
+// becomes good again after the configured number of consecutive good epochs.
+func (m *Monitor) Check() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.status.Good || !m.isStale(m.cfg.Now()) {
+		return
+	}
+	m.status.Good = false
+	m.status.Violation |= ViolationStale
+	m.goodEpochs = 0
+	m.emit(EventTypeBad, m.status.Violation, m.status.FixType, m.status.FixType)
+}
+
+// isStale returns true when no epoch has been evaluated within the maximum age.
+func (m *Monitor) isStale(now time.Time) bool {
+	return m.status.Good && now.Sub(m.lastEvaluation) > m.cfg.MaxAge
+}
+
+// Update the monitor with the current message of the scanner.
+//
+// Messages other than STAT, POS and DOPS are ignored.
+func (m *Monitor) Update(sc *erb.Scanner) {
+	switch sc.ID() {
+	case erb.IDSTAT:
+		m.UpdateSTAT(sc.STAT())
+	case erb.IDPOS:
+		m.UpdatePOS(sc.POS())
+	case erb.IDDOPS:
+		m.UpdateDOPS(sc.DOPS())
+	}
+}
+
+// UpdateSTAT updates the monitor with a STAT message.
+func (m *Monitor) UpdateSTAT(stat erb.STAT) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.startEpoch(stat.TimeGPS) {
+		return
+	}
+	m.stat, m.hasSTAT = stat, true
+	m.evaluateIfComplete()
+}
+
+// UpdatePOS updates the monitor with a POS message.
+func (m *Monitor) UpdatePOS(pos erb.POS) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.startEpoch(pos.TimeGPS) {
+		return
+	}
+	m.pos, m.hasPOS = pos, true
+	m.evaluateIfComplete()
+}
+
+// UpdateDOPS updates the monitor with a DOPS message.
+func (m *Monitor) UpdateDOPS(dops erb.DOPS) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.startEpoch(dops.TimeGPS) {
+		return
+	}
+	m.dops, m.hasDOPS = dops, true
+	m.evaluateIfComplete()
+}
+
+// startEpoch evaluates the current epoch if a message of a new epoch is received.
+//
+// Returns false for a late message of an epoch before the current epoch, which is ignored.
+func (m *Monitor) startEpoch(timeGPS uint32) bool {
+	if m.hasEpoch && timeGPS == m.timeGPS {
+		return true
+	}
+	if m.hasEpoch && erb.ElapsedGPS(m.timeGPS, timeGPS) < 0 {
+		return false
+	}
+	if m.hasEpoch && !m.evaluated {
+		m.evaluate()
+	}
+	m.hasEpoch = true
+	m.evaluated = false
+	m.timeGPS = timeGPS
+	m.hasSTAT, m.hasPOS, m.hasDOPS = false, false, false
+	return true
+}
+
+func (m *Monitor) evaluateIfComplete() {
+	if !m.evaluated && m.hasSTAT && m.hasPOS && m.hasDOPS {
+		m.evaluate()
+	}
+}
+
+// evaluate the current epoch and emit events.
+func (m *Monitor) evaluate() {
+	m.evaluated = true
+	m.lastEvaluation = m.cfg.Now()
+	violation := m.check()
+	m.status.TimeGPS = m.timeGPS
+	m.status.Violation = violation
+	if m.hasSTAT {
+		fixType := m.stat.FixType
+		if m.hasFixType && fixType != m.prevFixType {
+			eventType := EventTypeFixImproved
+			if fixType < m.prevFixType {
+				eventType = EventTypeFixDegraded
+			}
+			m.emit(eventType, violation, fixType, m.prevFixType)
+		}
+		m.status.FixType = fixType
+		m.prevFixType, m.hasFixType = fixType, true
+	}
+	if violation == 0 {
+		m.goodEpochs++
+		m.badEpochs = 0
+		if !m.status.Good && m.goodEpochs >= m.cfg.GoodEpochs {
+			m.status.Good = true
+			m.emit(EventTypeGood, violation, m.status.FixType, m.status.FixType)
+		}
+		return
+	}
+	m.badEpochs++
+	m.goodEpochs = 0
+	if m.status.Good && m.badEpochs >= m.cfg.BadEpochs {
+		m.status.Good = false
+		m.emit(EventTypeBad, violation, m.status.FixType, m.status.FixType)
+	}
+}
+
+// check the current epoch against the thresholds.
+func (m *Monitor) check() Violation {
+	var v Violation
+	if !m.hasSTAT || !m.hasPOS || !m.hasDOPS {
+		v |= ViolationMissingMessage
+	}
+	if m.hasSTAT {
+		if !m.stat.HasFix {
+			v |= ViolationNoFix
+		}
+		if !m.cfg.isAccepted(m.stat.FixType) {
+			v |= ViolationFixType
+		}
+		if m.stat.NumSVs < m.cfg.MinNumSVs {
+			v |= ViolationNumSVs
+		}
+	}
+	if m.hasPOS {
+		if m.cfg.MaxHorizontalAccuracyMillimeters > 0 &&
+			m.pos.HorizontalAccuracyMillimeters > m.cfg.MaxHorizontalAccuracyMillimeters {
+			v |= ViolationHorizontalAccuracy
+		}
+		if m.cfg.MaxVerticalAccuracyMillimeters > 0 &&
+			m.pos.VerticalAccuracyMillimeters > m.cfg.MaxVerticalAccuracyMillimeters {
+			v |= ViolationVerticalAccuracy
+		}
+	}
+	if m.hasDOPS && m.cfg.MaxPositionDOP > 0 && m.dops.Position > m.cfg.MaxPositionDOP {
+		v |= ViolationPositionDOP
+	}
+	return v
+}
+
+func (m *Monitor) emit(eventType EventType, violation Violation, fixType, prevFixType erb.FixType) {
+	if m.cfg.OnEvent == nil {
+		return
+	}
+	m.cfg.OnEvent(Event{
+		Type:            eventType,
+		TimeGPS:         m.timeGPS,
+		FixType:         fixType,
+		PreviousFixType: prevFixType,
+		Violation:       violation,
+	})
+}
@@ -0,0 +1,204 @@
+package integrity
+
+import (
+	"testing"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"gotest.tools/v3/assert"
+)
+
+type testEpoch struct {
+	fixType                       erb.FixType
+	numSVs                        uint8
+	horizontalAccuracyMillimeters uint32
+	positionDOP                   float64
+	skipDOPS                      bool
+}
+
+func (e testEpoch) update(m *Monitor, timeGPS uint32) {
+	m.UpdatePOS(erb.POS{TimeGPS: timeGPS, HorizontalAccuracyMillimeters: e.horizontalAccuracyMillimeters})
+	m.UpdateSTAT(erb.STAT{
+		TimeGPS: timeGPS,
+		FixType: e.fixType,
+		HasFix:  e.fixType != erb.FixTypeNoFix,
+		NumSVs:  e.numSVs,
+	})
+	if !e.skipDOPS {
+		m.UpdateDOPS(erb.DOPS{TimeGPS: timeGPS, Position: e.positionDOP})
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	rtk := testEpoch{fixType: erb.FixTypeRTK, numSVs: 12, horizontalAccuracyMillimeters: 14, positionDOP: 1.2}
+	float := testEpoch{fixType: erb.FixTypeFloat, numSVs: 12, horizontalAccuracyMillimeters: 400, positionDOP: 1.2}
+	fewSVs := rtk
+	fewSVs.numSVs = 4
+	highDOP := rtk
+	highDOP.positionDOP = 4
+	missingDOPS := rtk
+	missingDOPS.skipDOPS = true
+	for _, tt := range []struct {
+		name           string
+		cfg            Config
+		epochs         []testEpoch
+		expectedEvents []Event
+		expectedStatus Status
+	}{
+		{
+			name:   "good after consecutive good epochs",
+			cfg:    Config{GoodEpochs: 3},
+			epochs: []testEpoch{rtk, rtk, rtk},
+			expectedEvents: []Event{
+				{Type: EventTypeGood, TimeGPS: 2000, FixType: erb.FixTypeRTK, PreviousFixType: erb.FixTypeRTK},
+			},
+			expectedStatus: Status{Good: true, TimeGPS: 2000, FixType: erb.FixTypeRTK},
+		},
+		{
+			name:   "degraded to float",
+			cfg:    Config{MaxHorizontalAccuracyMillimeters: 50},
+			epochs: []testEpoch{rtk, float},
+			expectedEvents: []Event{
+				{Type: EventTypeGood, TimeGPS: 0, FixType: erb.FixTypeRTK, PreviousFixType: erb.FixTypeRTK},
+				{
+					Type:            EventTypeFixDegraded,
+					TimeGPS:         1000,
+					FixType:         erb.FixTypeFloat,
+					PreviousFixType: erb.FixTypeRTK,
+					Violation:       ViolationFixType | ViolationHorizontalAccuracy,
+				},
+				{
+					Type:            EventTypeBad,
+					TimeGPS:         1000,
+					FixType:         erb.FixTypeFloat,
+					PreviousFixType: erb.FixTypeFloat,
+					Violation:       ViolationFixType | ViolationHorizontalAccuracy,
+				},
+			},
+			expectedStatus: Status{
+				TimeGPS:   1000,
+				FixType:   erb.FixTypeFloat,
+				Violation: ViolationFixType | ViolationHorizontalAccuracy,
+			},
+		},
+		{
+			name:   "hysteresis on bad epochs",
+			cfg:    Config{MinNumSVs: 6, BadEpochs: 2},
+			epochs: []testEpoch{rtk, fewSVs, rtk, fewSVs},
+			expectedEvents: []Event{
+				{Type: EventTypeGood, TimeGPS: 0, FixType: erb.FixTypeRTK, PreviousFixType: erb.FixTypeRTK},
+			},
+			expectedStatus: Status{Good: true, TimeGPS: 3000, FixType: erb.FixTypeRTK, Violation: ViolationNumSVs},
+		},
+		{
+			name:   "float accepted",
+			cfg:    Config{AcceptedFixTypes: []erb.FixType{erb.FixTypeFloat, erb.FixTypeRTK}, MaxPositionDOP: 2.5},
+			epochs: []testEpoch{float, rtk, highDOP},
+			expectedEvents: []Event{
+				{Type: EventTypeGood, TimeGPS: 0, FixType: erb.FixTypeFloat, PreviousFixType: erb.FixTypeFloat},
+				{Type: EventTypeFixImproved, TimeGPS: 1000, FixType: erb.FixTypeRTK, PreviousFixType: erb.FixTypeFloat},
+				{
+					Type:            EventTypeBad,
+					TimeGPS:         2000,
+					FixType:         erb.FixTypeRTK,
+					PreviousFixType: erb.FixTypeRTK,
+					Violation:       ViolationPositionDOP,
+				},
+			},
+			expectedStatus: Status{TimeGPS: 2000, FixType: erb.FixTypeRTK, Violation: ViolationPositionDOP},
+		},
+		{
+			name:   "missing message evaluated on next epoch",
+			epochs: []testEpoch{rtk, missingDOPS, rtk},
+			expectedEvents: []Event{
+				{Type: EventTypeGood, TimeGPS: 0, FixType: erb.FixTypeRTK, PreviousFixType: erb.FixTypeRTK},
+				{
+					Type:            EventTypeBad,
+					TimeGPS:         1000,
+					FixType:         erb.FixTypeRTK,
+					PreviousFixType: erb.FixTypeRTK,
+					Violation:       ViolationMissingMessage,
+				},
+				{Type: EventTypeGood, TimeGPS: 2000, FixType: erb.FixTypeRTK, PreviousFixType: erb.FixTypeRTK},
+			},
+			expectedStatus: Status{Good: true, TimeGPS: 2000, FixType: erb.FixTypeRTK},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var events []Event
+			cfg := tt.cfg
+			cfg.OnEvent = func(event Event) {
+				events = append(events, event)
+			}
+			m := NewMonitor(cfg)
+			assert.Assert(t, !m.Status().Good)
+			for i, epoch := range tt.epochs {
+				epoch.update(m, uint32(i)*1000)
+			}
+			assert.DeepEqual(t, tt.expectedEvents, events)
+			assert.DeepEqual(t, tt.expectedStatus, m.Status())
+		})
+	}
+}
+
+func TestMonitor_LateMessage(t *testing.T) {
+	var events []Event
+	m := NewMonitor(Config{
+		OnEvent: func(event Event) {
+			events = append(events, event)
+		},
+	})
+	rtk := testEpoch{fixType: erb.FixTypeRTK, numSVs: 12}
+	rtk.update(m, 1000)
+	// the partial next epoch must not be evaluated as missing messages by a late message of the previous epoch
+	m.UpdatePOS(erb.POS{TimeGPS: 2000})
+	m.UpdateDOPS(erb.DOPS{TimeGPS: 1000})
+	rtk.update(m, 2000)
+	assert.DeepEqual(t, []Event{
+		{Type: EventTypeGood, TimeGPS: 1000, FixType: erb.FixTypeRTK, PreviousFixType: erb.FixTypeRTK},
+	}, events)
+	assert.DeepEqual(t, Status{Good: true, TimeGPS: 2000, FixType: erb.FixTypeRTK}, m.Status())
+}
+
+func TestMonitor_Stale(t *testing.T) {
+	now := time.Unix(0, 0)
+	var events []Event
+	m := NewMonitor(Config{
+		MaxAge: time.Second,
+		OnEvent: func(event Event) {
+			events = append(events, event)
+		},
+		Now: func() time.Time {
+			return now
+		},
+	})
+	rtk := testEpoch{fixType: erb.FixTypeRTK, numSVs: 12}
+	rtk.update(m, 1000)
+	now = now.Add(time.Second)
+	m.Check()
+	assert.Assert(t, m.Status().Good)
+	now = now.Add(time.Millisecond)
+	assert.DeepEqual(t, Status{TimeGPS: 1000, FixType: erb.FixTypeRTK, Violation: ViolationStale}, m.Status())
+	m.Check()
+	m.Check()
+	rtk.update(m, 2000)
+	assert.DeepEqual(t, []Event{
+		{Type: EventTypeGood, TimeGPS: 1000, FixType: erb.FixTypeRTK, PreviousFixType: erb.FixTypeRTK},
+		{
+			Type:            EventTypeBad,
+			TimeGPS:         1000,
+			FixType:         erb.FixTypeRTK,
+			PreviousFixType: erb.FixTypeRTK,
+			Violation:       ViolationStale,
+		},
+		{Type: EventTypeGood, TimeGPS: 2000, FixType: erb.FixTypeRTK, PreviousFixType: erb.FixTypeRTK},
+	}, events)
+	assert.DeepEqual(t, Status{Good: true, TimeGPS: 2000, FixType: erb.FixTypeRTK}, m.Status())
+}
+
+func TestViolation_String(t *testing.T) {
+	assert.Equal(t, "None", Violation(0).String())
+	assert.Equal(t, "FixType|PositionDOP", (ViolationFixType | ViolationPositionDOP).String())
+	assert.Assert(t, (ViolationFixType | ViolationPositionDOP).Has(ViolationPositionDOP))
+}
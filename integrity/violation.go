@@ -0,0 +1,55 @@
+package integrity
+
+import "strings"
+
+// Violation is a set of violated thresholds.
+type Violation uint8
+
+const (
+	// ViolationNoFix is violated when the receiver reports that position and velocity are invalid.
+	ViolationNoFix Violation = 1 << iota
+	// ViolationFixType is violated when the fix type is not accepted.
+	ViolationFixType
+	// ViolationNumSVs is violated when too few space vehicles are used.
+	ViolationNumSVs
+	// ViolationHorizontalAccuracy is violated when the horizontal accuracy estimate is too large.
+	ViolationHorizontalAccuracy
+	// ViolationVerticalAccuracy is violated when the vertical accuracy estimate is too large.
+	ViolationVerticalAccuracy
+	// ViolationPositionDOP is violated when the position dilution of precision is too large.
+	ViolationPositionDOP
+	// ViolationMissingMessage is violated when a STAT, POS or DOPS message has not been received.
+	ViolationMissingMessage
+	// ViolationStale is violated when no epoch has been evaluated within the maximum age.
+	ViolationStale
+)
+
+var violationNames = []string{
+	"NoFix",
+	"FixType",
+	"NumSVs",
+	"HorizontalAccuracy",
+	"VerticalAccuracy",
+	"PositionDOP",
+	"MissingMessage",
+	"Stale",
+}
+
+// Has returns true if all violations of other are in v.
+func (v Violation) Has(other Violation) bool {
+	return v&other == other
+}
+
+// String returns the names of the violations, separated by |.
+func (v Violation) String() string {
+	if v == 0 {
+		return "None"
+	}
+	var names []string
+	for i, name := range violationNames {
+		if v&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
@@ -0,0 +1,7 @@
+// Package metrics provides a Prometheus exporter for Reach receivers, that exposes the fix type, satellite count,
+// accuracy estimates, DOP and packet error counters observed in ERB streams, and whether the receivers are up.
+//
+// Metrics are served in the Prometheus text exposition format:
+//
+//	https://prometheus.io/docs/instrumenting/exposition_formats/
+package metrics
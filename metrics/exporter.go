@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"go.einride.tech/reach/erb"
+)
+
+// Exporter exports the metrics of Reach receivers in the Prometheus text exposition format.
+type Exporter struct {
+	cfg       ExporterConfig
+	mu        sync.Mutex
+	receivers map[string]*Receiver
+}
+
+// NewExporter returns a new Exporter without receivers.
+func NewExporter() *Exporter {
+	return NewExporterWithConfig(ExporterConfig{})
+}
+
+// NewExporterWithConfig returns a new Exporter without receivers, configured by cfg.
+func NewExporterWithConfig(cfg ExporterConfig) *Exporter {
+	cfg.setDefaults()
+	return &Exporter{cfg: cfg, receivers: map[string]*Receiver{}}
+}
+
+// Receiver returns the receiver with the provided name, which is created on first use.
+func (e *Exporter) Receiver(name string) *Receiver {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	r, ok := e.receivers[name]
+	if !ok {
+		r = newReceiver(name, e.cfg.Now)
+		e.receivers[name] = r
+	}
+	return r
+}
+
+// Remove the receiver with the provided name from the exporter.
+func (e *Exporter) Remove(name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.receivers, name)
+}
+
+// Write the metrics of all receivers to w in the Prometheus text exposition format.
+func (e *Exporter) Write(w io.Writer) error {
+	e.mu.Lock()
+	receivers := make([]*Receiver, 0, len(e.receivers))
+	for _, r := range e.receivers {
+		receivers = append(receivers, r)
+	}
+	e.mu.Unlock()
+	sort.Slice(receivers, func(i, j int) bool {
+		return receivers[i].name < receivers[j].name
+	})
+	m := newFamilies()
+	now := e.cfg.Now()
+	for _, r := range receivers {
+		r.collect(m, now, e.cfg.StaleTimeout)
+	}
+	return writeFamilies(w, m.all())
+}
+
+// ServeHTTP implements http.Handler.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	if r.Method == http.MethodHead {
+		return
+	}
+	_ = e.Write(w)
+}
+
+// families are the metric families of the exporter.
+type families struct {
+	packets            *family
+	checksumFailures   *family
+	discardedFrames    *family
+	up                 *family
+	lastMessage        *family
+	fixType            *family
+	hasFix             *family
+	satellites         *family
+	horizontalAccuracy *family
+	verticalAccuracy   *family
+	dop                *family
+}
+
+func newFamilies() *families {
+	return &families{
+		packets: &family{
+			name: "reach_packets_total",
+			help: "Number of received ERB packets.",
+			typ:  metricTypeCounter,
+		},
+		checksumFailures: &family{
+			name: "reach_checksum_failures_total",
+			help: "Number of discarded ERB frames with checksum mismatch.",
+			typ:  metricTypeCounter,
+		},
+		discardedFrames: &family{
+			name: "reach_discarded_frames_total",
+			help: "Number of discarded corrupt ERB frames.",
+			typ:  metricTypeCounter,
+		},
+		up: &family{
+			name: "reach_up",
+			help: "Whether an ERB message has been received within the stale timeout.",
+			typ:  metricTypeGauge,
+		},
+		lastMessage: &family{
+			name: "reach_last_message_timestamp_seconds",
+			help: "Unix time of the last received ERB message.",
+			typ:  metricTypeGauge,
+		},
+		fixType: &family{
+			name: "reach_fix_type",
+			help: "Fix type of the navigation solution (0: no fix, 1: single, 2: float, 3: RTK).",
+			typ:  metricTypeGauge,
+		},
+		hasFix: &family{
+			name: "reach_has_fix",
+			help: "Whether position and velocity are valid.",
+			typ:  metricTypeGauge,
+		},
+		satellites: &family{
+			name: "reach_satellites_used",
+			help: "Number of space vehicles used in the navigation solution.",
+			typ:  metricTypeGauge,
+		},
+		horizontalAccuracy: &family{
+			name: "reach_horizontal_accuracy_meters",
+			help: "Horizontal accuracy estimate of the navigation solution.",
+			typ:  metricTypeGauge,
+		},
+		verticalAccuracy: &family{
+			name: "reach_vertical_accuracy_meters",
+			help: "Vertical accuracy estimate of the navigation solution.",
+			typ:  metricTypeGauge,
+		},
+		dop: &family{
+			name: "reach_dop",
+			help: "Dilution of precision of the navigation solution.",
+			typ:  metricTypeGauge,
+		},
+	}
+}
+
+func (m *families) all() []*family {
+	return []*family{
+		m.packets,
+		m.checksumFailures,
+		m.discardedFrames,
+		m.up,
+		m.lastMessage,
+		m.fixType,
+		m.hasFix,
+		m.satellites,
+		m.horizontalAccuracy,
+		m.verticalAccuracy,
+		m.dop,
+	}
+}
+
+func sortedIDs(packets map[erb.ID]uint64) []erb.ID {
+	ids := make([]erb.ID, 0, len(packets))
+	for id := range packets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
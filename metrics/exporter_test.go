@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestExporter_ServeHTTP(t *testing.T) {
+	var buf bytes.Buffer
+	enc := erb.NewEncoder(&buf)
+	assert.NilError(t, enc.EncodePOS(erb.POS{TimeGPS: 800}))
+	assert.NilError(t, enc.EncodeSTAT(erb.STAT{TimeGPS: 1000, FixType: erb.FixTypeRTK, HasFix: true, NumSVs: 12}))
+	assert.NilError(t, enc.EncodePOS(erb.POS{
+		TimeGPS:                       1000,
+		HorizontalAccuracyMillimeters: 14,
+		VerticalAccuracyMillimeters:   21,
+	}))
+	assert.NilError(t, enc.EncodeDOPS(erb.DOPS{
+		TimeGPS:    1000,
+		Geometric:  1.5,
+		Position:   1.25,
+		Vertical:   1,
+		Horizontal: 0.75,
+	}))
+	now := time.Unix(1561361950, 0)
+	exporter := NewExporterWithConfig(ExporterConfig{
+		Now: func() time.Time {
+			return now
+		},
+	})
+	rover := exporter.Receiver("rover")
+	sc := erb.NewScanner(bytes.NewReader(buf.Bytes()))
+	for sc.Scan() {
+		rover.Update(sc)
+	}
+	rover.Discard(nil, erb.ErrChecksumMismatch)
+	assert.Assert(t, exporter.Receiver("rover") == rover)
+	base := exporter.Receiver(`base "1"`)
+	base.Discard(nil, erb.ErrChecksumMismatch)
+	base.Discard(nil, erb.ErrChecksumMismatch)
+	server := httptest.NewServer(exporter)
+	defer server.Close()
+	resp, err := http.Get(server.URL)
+	assert.NilError(t, err)
+	defer func() {
+		assert.NilError(t, resp.Body.Close())
+	}()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err)
+	golden.Assert(t, string(body), "metrics.golden")
+}
+
+func TestExporter_Stale(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NilError(t, erb.NewEncoder(&buf).EncodeSTAT(erb.STAT{FixType: erb.FixTypeRTK, HasFix: true, NumSVs: 12}))
+	start := time.Unix(1561361950, 0)
+	now := start
+	exporter := NewExporterWithConfig(ExporterConfig{
+		StaleTimeout: time.Second,
+		Now: func() time.Time {
+			return now
+		},
+	})
+	rover := exporter.Receiver("rover")
+	sc := erb.NewScanner(bytes.NewReader(buf.Bytes()))
+	for sc.Scan() {
+		rover.Update(sc)
+	}
+	write := func() string {
+		var output bytes.Buffer
+		assert.NilError(t, exporter.Write(&output))
+		return output.String()
+	}
+	now = start.Add(time.Second)
+	output := write()
+	assert.Assert(t, strings.Contains(output, `reach_up{receiver="rover"} 1`), output)
+	assert.Assert(t, strings.Contains(output, `reach_fix_type{receiver="rover"} 3`), output)
+	now = start.Add(time.Second + time.Millisecond)
+	output = write()
+	assert.Assert(t, strings.Contains(output, `reach_up{receiver="rover"} 0`), output)
+	const lastMessage = `reach_last_message_timestamp_seconds{receiver="rover"} 1.56136195e+09`
+	assert.Assert(t, strings.Contains(output, lastMessage), output)
+	assert.Assert(t, !strings.Contains(output, "reach_fix_type"), output)
+	assert.Assert(t, !strings.Contains(output, "reach_has_fix"), output)
+	assert.Assert(t, !strings.Contains(output, "reach_satellites_used"), output)
+}
+
+func TestExporter_ServeHTTP_MethodNotAllowed(t *testing.T) {
+	server := httptest.NewServer(NewExporter())
+	defer server.Close()
+	resp, err := http.Post(server.URL, "text/plain", nil)
+	assert.NilError(t, err)
+	assert.NilError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestExporter_Remove(t *testing.T) {
+	exporter := NewExporter()
+	exporter.Receiver("rover").Discard(nil, erb.ErrChecksumMismatch)
+	exporter.Remove("rover")
+	var buf bytes.Buffer
+	assert.NilError(t, exporter.Write(&buf))
+	assert.Equal(t, "", buf.String())
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import "time"
+
+// ExporterConfig configures an Exporter.
+type ExporterConfig struct {
+	// StaleTimeout is the time without received messages after which a receiver is considered down. Defaults to 5s.
+	//
+	// The solution metrics of a receiver that is down are not exported, since they no longer describe the receiver.
+	StaleTimeout time.Duration
+	// Now is an optional function returning the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+func (c *ExporterConfig) setDefaults() {
+	if c.StaleTimeout == 0 {
+		c.StaleTimeout = 5 * time.Second
+	}
+	if c.Now == nil {
+		c.Now = time.Now
+	}
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// contentType is the content type of the Prometheus text exposition format.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// metricType is the type of a metric family.
+type metricType string
+
+const (
+	metricTypeCounter metricType = "counter"
+	metricTypeGauge   metricType = "gauge"
+)
+
+// label is a label of a sample.
+type label struct {
+	name  string
+	value string
+}
+
+// sample is a sample of a metric family.
+type sample struct {
+	labels []label
+	value  float64
+}
+
+// family is a metric family.
+type family struct {
+	name    string
+	help    string
+	typ     metricType
+	samples []sample
+}
+
+// add a sample to the family.
+func (f *family) add(value float64, labels ...label) {
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+// writeFamilies writes metric families in the text exposition format. Families without samples are omitted.
+func writeFamilies(w io.Writer, families []*family) error {
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		if len(f.samples) == 0 {
+			continue
+		}
+		_, _ = bw.WriteString("# HELP " + f.name + " " + escapeHelp(f.help) + "\n")
+		_, _ = bw.WriteString("# TYPE " + f.name + " " + string(f.typ) + "\n")
+		for _, s := range f.samples {
+			_, _ = bw.WriteString(f.name)
+			if len(s.labels) > 0 {
+				_ = bw.WriteByte('{')
+				for i, l := range s.labels {
+					if i > 0 {
+						_ = bw.WriteByte(',')
+					}
+					_, _ = bw.WriteString(l.name + `="` + escapeLabelValue(l.value) + `"`)
+				}
+				_ = bw.WriteByte('}')
+			}
+			_, _ = bw.WriteString(" " + formatValue(s.value) + "\n")
+		}
+	}
+	return bw.Flush()
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
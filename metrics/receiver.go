@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// Receiver collects the metrics of a single Reach receiver.
+//
+// Update and Discard may be called concurrently with the exporter serving metrics.
+//
+// The receiver is considered down when no message has been received within the stale timeout of the exporter, and its
+// solution metrics are then not exported.
+type Receiver struct {
+	name             string
+	now              func() time.Time
+	mu               sync.Mutex
+	lastMessage      time.Time
+	packets          map[erb.ID]uint64
+	checksumFailures uint64
+	discardedFrames  uint64
+	stat             erb.STAT
+	pos              erb.POS
+	dops             erb.DOPS
+	hasSTAT          bool
+	hasPOS           bool
+	hasDOPS          bool
+}
+
+func newReceiver(name string, now func() time.Time) *Receiver {
+	return &Receiver{name: name, now: now, packets: map[erb.ID]uint64{}}
+}
+
+// Name returns the name of the receiver, used as the value of the receiver label of its metrics.
+func (r *Receiver) Name() string {
+	return r.name
+}
+
+// Update the receiver metrics with the current message of the scanner.
+func (r *Receiver) Update(sc *erb.Scanner) {
+	now := r.now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastMessage = now
+	r.packets[sc.ID()]++
+	switch sc.ID() {
+	case erb.IDSTAT:
+		r.stat, r.hasSTAT = sc.STAT(), true
+	case erb.IDPOS:
+		r.pos, r.hasPOS = sc.POS(), true
+	case erb.IDDOPS:
+		r.dops, r.hasDOPS = sc.DOPS(), true
+	}
+}
+
+// Discard adds a frame discarded by the scanner to the receiver metrics.
+//
+// Discard has the signature of erb.ScannerConfig.OnDiscard, and is intended to be used as the hook of a tolerant
+// scanner.
+func (r *Receiver) Discard(_ []byte, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.discardedFrames++
+	if errors.Is(err, erb.ErrChecksumMismatch) {
+		r.checksumFailures++
+	}
+}
+
+// collect the samples of the receiver into the metric families.
+func (r *Receiver) collect(m *families, now time.Time, staleTimeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	receiver := label{name: "receiver", value: r.name}
+	for _, id := range sortedIDs(r.packets) {
+		m.packets.add(float64(r.packets[id]), receiver, label{name: "id", value: id.String()})
+	}
+	m.checksumFailures.add(float64(r.checksumFailures), receiver)
+	m.discardedFrames.add(float64(r.discardedFrames), receiver)
+	up := !r.lastMessage.IsZero() && now.Sub(r.lastMessage) <= staleTimeout
+	m.up.add(boolValue(up), receiver)
+	if !r.lastMessage.IsZero() {
+		m.lastMessage.add(float64(r.lastMessage.UnixNano())/1e9, receiver)
+	}
+	if !up {
+		return
+	}
+	if r.hasSTAT {
+		m.fixType.add(float64(r.stat.FixType), receiver)
+		m.hasFix.add(boolValue(r.stat.HasFix), receiver)
+		m.satellites.add(float64(r.stat.NumSVs), receiver)
+	}
+	if r.hasPOS {
+		m.horizontalAccuracy.add(float64(r.pos.HorizontalAccuracyMillimeters)/1000, receiver)
+		m.verticalAccuracy.add(float64(r.pos.VerticalAccuracyMillimeters)/1000, receiver)
+	}
+	if r.hasDOPS {
+		m.dop.add(r.dops.Geometric, receiver, label{name: "type", value: "geometric"})
+		m.dop.add(r.dops.Position, receiver, label{name: "type", value: "position"})
+		m.dop.add(r.dops.Vertical, receiver, label{name: "type", value: "vertical"})
+		m.dop.add(r.dops.Horizontal, receiver, label{name: "type", value: "horizontal"})
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
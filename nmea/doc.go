@@ -0,0 +1,7 @@
+// Package nmea provides primitives for parsing NMEA 0183 sentences output by Emlid Reach receivers.
+//
+// The Scanner API mirrors the Scanner of the erb package, and the fix types of the NMEA sentences map onto
+// erb.FixType.
+//
+// Implementation is based on the NMEA 0183 standard version 4.10.
+package nmea
@@ -0,0 +1,12 @@
+package nmea
+
+// FixMode represents the fix mode of a GSA sentence.
+type FixMode uint8
+
+//go:generate stringer -type FixMode -trimprefix FixMode
+
+const (
+	FixModeNoFix FixMode = 1
+	FixMode2D    FixMode = 2
+	FixMode3D    FixMode = 3
+)
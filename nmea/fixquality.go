@@ -0,0 +1,34 @@
+package nmea
+
+import "go.einride.tech/reach/erb"
+
+// FixQuality represents the GPS quality indicator of a GGA sentence.
+type FixQuality uint8
+
+//go:generate stringer -type FixQuality -trimprefix FixQuality
+
+const (
+	FixQualityInvalid       FixQuality = 0
+	FixQualityGPS           FixQuality = 1
+	FixQualityDGPS          FixQuality = 2
+	FixQualityPPS           FixQuality = 3
+	FixQualityRTK           FixQuality = 4
+	FixQualityFloat         FixQuality = 5
+	FixQualityDeadReckoning FixQuality = 6
+	FixQualityManual        FixQuality = 7
+	FixQualitySimulation    FixQuality = 8
+)
+
+// FixType returns the ERB fix type corresponding to the fix quality.
+func (f FixQuality) FixType() erb.FixType {
+	switch f {
+	case FixQualityGPS, FixQualityDGPS, FixQualityPPS:
+		return erb.FixTypeSingle
+	case FixQualityFloat:
+		return erb.FixTypeFloat
+	case FixQualityRTK:
+		return erb.FixTypeRTK
+	default:
+		return erb.FixTypeNoFix
+	}
+}
@@ -0,0 +1,86 @@
+package nmea
+
+import (
+	"fmt"
+	"time"
+)
+
+// structure of GGA sentence.
+const (
+	lengthOfGGA                   = 15
+	indexOfGGATime                = 1
+	indexOfGGALatitude            = 2
+	indexOfGGALongitude           = 4
+	indexOfGGAFixQuality          = 6
+	indexOfGGANumSVs              = 7
+	indexOfGGAHDOP                = 8
+	indexOfGGAAltitude            = 9
+	indexOfGGAGeoidSeparation     = 11
+	indexOfGGADifferentialAge     = 13
+	indexOfGGADifferentialStation = 14
+)
+
+// GGA sentence contains time, position and fix related data.
+type GGA struct {
+	// TimeUTC is the UTC time of day of the position fix.
+	TimeUTC time.Duration
+	// Latitude component (degrees).
+	LatitudeDegrees float64
+	// Longitude component (degrees).
+	LongitudeDegrees float64
+	// FixQuality is the GPS quality indicator.
+	FixQuality FixQuality
+	// NumSVs is the number of space vehicles in use.
+	NumSVs uint8
+	// HDOP is the horizontal dilution of precision.
+	HDOP float64
+	// AltitudeMeanSeaLevel is the height above mean sea level (m).
+	AltitudeMeanSeaLevelMeters float64
+	// GeoidSeparation is the height of the geoid above the ellipsoid (m).
+	GeoidSeparationMeters float64
+	// DifferentialAge is the age of the differential corrections.
+	DifferentialAge time.Duration
+	// DifferentialStationID is the ID of the differential reference station.
+	DifferentialStationID uint16
+}
+
+// AppendSentence appends the GGA sentence with the provided talker ID, including checksum and line terminator, to b.
+//
+// HDOP, differential age and differential station ID are left empty when zero.
+func (g *GGA) AppendSentence(b []byte, talker string) []byte {
+	start := len(b)
+	b = append(b, startChar)
+	b = append(b, talker...)
+	b = append(b, "GGA,"...)
+	b = appendTimeOfDay(b, g.TimeUTC)
+	b = append(b, fieldSeparator)
+	b = appendCoordinate(b, g.LatitudeDegrees, 2, 'N', 'S')
+	b = append(b, fieldSeparator)
+	b = appendCoordinate(b, g.LongitudeDegrees, 3, 'E', 'W')
+	b = append(b, fmt.Sprintf(",%d,%02d,", g.FixQuality, g.NumSVs)...)
+	if g.HDOP != 0 {
+		b = append(b, fmt.Sprintf("%.1f", g.HDOP)...)
+	}
+	b = append(b, fmt.Sprintf(",%.3f,M,%.3f,M,", g.AltitudeMeanSeaLevelMeters, g.GeoidSeparationMeters)...)
+	if g.DifferentialAge != 0 {
+		b = append(b, fmt.Sprintf("%.1f", g.DifferentialAge.Seconds())...)
+	}
+	b = append(b, fieldSeparator)
+	if g.DifferentialStationID != 0 {
+		b = append(b, fmt.Sprintf("%04d", g.DifferentialStationID)...)
+	}
+	return appendChecksum(b, start)
+}
+
+func (g *GGA) unmarshalFields(f *fields) {
+	g.TimeUTC = f.timeOfDay(indexOfGGATime)
+	g.LatitudeDegrees = f.latitude(indexOfGGALatitude)
+	g.LongitudeDegrees = f.longitude(indexOfGGALongitude)
+	g.FixQuality = FixQuality(f.uint(indexOfGGAFixQuality, 8))
+	g.NumSVs = uint8(f.uint(indexOfGGANumSVs, 8))
+	g.HDOP = f.float(indexOfGGAHDOP)
+	g.AltitudeMeanSeaLevelMeters = f.float(indexOfGGAAltitude)
+	g.GeoidSeparationMeters = f.float(indexOfGGAGeoidSeparation)
+	g.DifferentialAge = time.Duration(f.float(indexOfGGADifferentialAge) * float64(time.Second))
+	g.DifferentialStationID = uint16(f.uint(indexOfGGADifferentialStation, 16))
+}
@@ -0,0 +1,51 @@
+package nmea
+
+// structure of GSA sentence.
+const (
+	lengthOfGSA         = 18
+	indexOfGSASelection = 1
+	indexOfGSAFixMode   = 2
+	indexOfGSASVIDs     = 3
+	maxGSASVIDs         = 12
+	indexOfGSAPDOP      = indexOfGSASVIDs + maxGSASVIDs
+	indexOfGSAHDOP      = indexOfGSAPDOP + 1
+	indexOfGSAVDOP      = indexOfGSAHDOP + 1
+	indexOfGSASystemID  = indexOfGSAVDOP + 1
+)
+
+// compile-time assertion on structure of GSA sentence.
+var _ [lengthOfGSA]struct{} = [indexOfGSAVDOP + 1]struct{}{}
+
+// GSA sentence contains the DOP and active satellites of the navigation solution.
+type GSA struct {
+	// Automatic is true when the 2D/3D fix mode is selected automatically.
+	Automatic bool
+	// FixMode is the fix mode.
+	FixMode FixMode
+	// SVIDs are the IDs of the space vehicles used in the solution.
+	SVIDs []uint16
+	// PDOP is the position dilution of precision.
+	PDOP float64
+	// HDOP is the horizontal dilution of precision.
+	HDOP float64
+	// VDOP is the vertical dilution of precision.
+	VDOP float64
+	// SystemID is the GNSS system ID (NMEA 4.10 and later).
+	SystemID uint8
+}
+
+func (g *GSA) unmarshalFields(f *fields) {
+	g.Automatic = f.byte(indexOfGSASelection) == 'A'
+	g.FixMode = FixMode(f.uint(indexOfGSAFixMode, 8))
+	g.SVIDs = nil
+	for i := indexOfGSASVIDs; i < indexOfGSASVIDs+maxGSASVIDs; i++ {
+		if f.string(i) == "" {
+			continue
+		}
+		g.SVIDs = append(g.SVIDs, uint16(f.uint(i, 16)))
+	}
+	g.PDOP = f.float(indexOfGSAPDOP)
+	g.HDOP = f.float(indexOfGSAHDOP)
+	g.VDOP = f.float(indexOfGSAVDOP)
+	g.SystemID = uint8(f.uint(indexOfGSASystemID, 8))
+}
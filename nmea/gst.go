@@ -0,0 +1,50 @@
+package nmea
+
+import "time"
+
+// structure of GST sentence.
+const (
+	lengthOfGST              = 9
+	indexOfGSTTime           = 1
+	indexOfGSTRMS            = 2
+	indexOfGSTSemiMajor      = 3
+	indexOfGSTSemiMinor      = 4
+	indexOfGSTOrientation    = 5
+	indexOfGSTLatitudeError  = 6
+	indexOfGSTLongitudeError = 7
+	indexOfGSTAltitudeError  = 8
+)
+
+// compile-time assertion on structure of GST sentence.
+var _ [lengthOfGST]struct{} = [indexOfGSTAltitudeError + 1]struct{}{}
+
+// GST sentence contains the pseudorange error statistics of the position fix.
+type GST struct {
+	// TimeUTC is the UTC time of day of the associated position fix.
+	TimeUTC time.Duration
+	// RMS is the RMS value of the standard deviation of the range inputs.
+	RMS float64
+	// SemiMajorErrorMeters is the standard deviation of the semi-major axis of the error ellipse (m).
+	SemiMajorErrorMeters float64
+	// SemiMinorErrorMeters is the standard deviation of the semi-minor axis of the error ellipse (m).
+	SemiMinorErrorMeters float64
+	// OrientationDegrees is the orientation of the semi-major axis of the error ellipse (degrees from true north).
+	OrientationDegrees float64
+	// LatitudeErrorMeters is the standard deviation of the latitude error (m).
+	LatitudeErrorMeters float64
+	// LongitudeErrorMeters is the standard deviation of the longitude error (m).
+	LongitudeErrorMeters float64
+	// AltitudeErrorMeters is the standard deviation of the altitude error (m).
+	AltitudeErrorMeters float64
+}
+
+func (g *GST) unmarshalFields(f *fields) {
+	g.TimeUTC = f.timeOfDay(indexOfGSTTime)
+	g.RMS = f.float(indexOfGSTRMS)
+	g.SemiMajorErrorMeters = f.float(indexOfGSTSemiMajor)
+	g.SemiMinorErrorMeters = f.float(indexOfGSTSemiMinor)
+	g.OrientationDegrees = f.float(indexOfGSTOrientation)
+	g.LatitudeErrorMeters = f.float(indexOfGSTLatitudeError)
+	g.LongitudeErrorMeters = f.float(indexOfGSTLongitudeError)
+	g.AltitudeErrorMeters = f.float(indexOfGSTAltitudeError)
+}
@@ -0,0 +1,72 @@
+package nmea
+
+// structure of GSV sentence.
+const (
+	lengthOfGSV                  = 4
+	indexOfGSVNumMessages        = 1
+	indexOfGSVMessageNumber      = 2
+	indexOfGSVNumSVsInView       = 3
+	indexOfGSVSatellites         = 4
+	lengthOfGSVSatellite         = 4
+	indexOfGSVSatelliteID        = 0
+	indexOfGSVSatelliteElevation = 1
+	indexOfGSVSatelliteAzimuth   = 2
+	indexOfGSVSatelliteSNR       = 3
+	maxGSVSatellites             = 4
+)
+
+// GSV sentence contains information about satellites in view.
+//
+// Satellites in view are reported over multiple GSV sentences, with up to 4 satellites per sentence.
+type GSV struct {
+	// NumMessages is the total number of GSV sentences in the group.
+	NumMessages uint8
+	// MessageNumber is the number of this GSV sentence within the group, starting at 1.
+	MessageNumber uint8
+	// NumSVsInView is the total number of space vehicles in view.
+	NumSVsInView uint8
+	// Satellites in view reported by this sentence.
+	Satellites []GSVSatellite
+	// SignalID is the GNSS signal ID (NMEA 4.10 and later).
+	SignalID uint8
+}
+
+// GSVSatellite contains information about a single satellite in view.
+type GSVSatellite struct {
+	// ID of the SV.
+	ID uint16
+	// Elevation of the SV (degrees).
+	ElevationDegrees float64
+	// Azimuth of the SV (degrees).
+	AzimuthDegrees float64
+	// SNR is the signal to noise ratio of the SV in dB-Hz.
+	SNR float64
+}
+
+func (g *GSV) unmarshalFields(f *fields) {
+	g.NumMessages = uint8(f.uint(indexOfGSVNumMessages, 8))
+	g.MessageNumber = uint8(f.uint(indexOfGSVMessageNumber, 8))
+	g.NumSVsInView = uint8(f.uint(indexOfGSVNumSVsInView, 8))
+	numSatellites := (len(f.values) - indexOfGSVSatellites) / lengthOfGSVSatellite
+	if numSatellites > maxGSVSatellites {
+		numSatellites = maxGSVSatellites
+	}
+	g.Satellites = make([]GSVSatellite, 0, numSatellites)
+	for i := 0; i < numSatellites; i++ {
+		offset := indexOfGSVSatellites + i*lengthOfGSVSatellite
+		if f.string(offset+indexOfGSVSatelliteID) == "" {
+			continue
+		}
+		g.Satellites = append(g.Satellites, GSVSatellite{
+			ID:               uint16(f.uint(offset+indexOfGSVSatelliteID, 16)),
+			ElevationDegrees: f.float(offset + indexOfGSVSatelliteElevation),
+			AzimuthDegrees:   f.float(offset + indexOfGSVSatelliteAzimuth),
+			SNR:              f.float(offset + indexOfGSVSatelliteSNR),
+		})
+	}
+	g.SignalID = 0
+	// an odd trailing field after the satellite blocks is the signal ID
+	if (len(f.values)-indexOfGSVSatellites)%lengthOfGSVSatellite == 1 {
+		g.SignalID = uint8(f.uint(len(f.values)-1, 8))
+	}
+}
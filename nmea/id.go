@@ -0,0 +1,46 @@
+package nmea
+
+// ID represents an NMEA sentence type.
+type ID uint8
+
+//go:generate stringer -type=ID -trimprefix=ID
+
+const (
+	// IDUnknown is the ID of unsupported sentences.
+	IDUnknown ID = iota
+	// IDGGA is the ID of the GGA sentence.
+	IDGGA
+	// IDRMC is the ID of the RMC sentence.
+	IDRMC
+	// IDGSA is the ID of the GSA sentence.
+	IDGSA
+	// IDGSV is the ID of the GSV sentence.
+	IDGSV
+	// IDVTG is the ID of the VTG sentence.
+	IDVTG
+	// IDGST is the ID of the GST sentence.
+	IDGST
+	// IDZDA is the ID of the ZDA sentence.
+	IDZDA
+)
+
+func parseID(sentenceType string) ID {
+	switch sentenceType {
+	case "GGA":
+		return IDGGA
+	case "RMC":
+		return IDRMC
+	case "GSA":
+		return IDGSA
+	case "GSV":
+		return IDGSV
+	case "VTG":
+		return IDVTG
+	case "GST":
+		return IDGST
+	case "ZDA":
+		return IDZDA
+	default:
+		return IDUnknown
+	}
+}
@@ -0,0 +1,34 @@
+package nmea
+
+import "go.einride.tech/reach/erb"
+
+// ModeIndicator represents the positioning system mode indicator of RMC and VTG sentences.
+type ModeIndicator uint8
+
+//go:generate stringer -type ModeIndicator -trimprefix ModeIndicator
+
+const (
+	ModeIndicatorAutonomous   ModeIndicator = 'A'
+	ModeIndicatorDifferential ModeIndicator = 'D'
+	ModeIndicatorEstimated    ModeIndicator = 'E'
+	ModeIndicatorFloat        ModeIndicator = 'F'
+	ModeIndicatorManual       ModeIndicator = 'M'
+	ModeIndicatorNotValid     ModeIndicator = 'N'
+	ModeIndicatorPrecise      ModeIndicator = 'P'
+	ModeIndicatorRTK          ModeIndicator = 'R'
+	ModeIndicatorSimulator    ModeIndicator = 'S'
+)
+
+// FixType returns the ERB fix type corresponding to the mode indicator.
+func (m ModeIndicator) FixType() erb.FixType {
+	switch m {
+	case ModeIndicatorAutonomous, ModeIndicatorDifferential, ModeIndicatorPrecise:
+		return erb.FixTypeSingle
+	case ModeIndicatorFloat:
+		return erb.FixTypeFloat
+	case ModeIndicatorRTK:
+		return erb.FixTypeRTK
+	default:
+		return erb.FixTypeNoFix
+	}
+}
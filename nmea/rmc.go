@@ -0,0 +1,57 @@
+package nmea
+
+import "time"
+
+// structure of RMC sentence.
+const (
+	lengthOfRMC                   = 12
+	indexOfRMCTime                = 1
+	indexOfRMCStatus              = 2
+	indexOfRMCLatitude            = 3
+	indexOfRMCLongitude           = 5
+	indexOfRMCSpeed               = 7
+	indexOfRMCCourse              = 8
+	indexOfRMCDate                = 9
+	indexOfRMCMagneticVariation   = 10
+	indexOfRMCMagneticVariationEW = 11
+	indexOfRMCModeIndicator       = 12
+)
+
+// RMC sentence contains the recommended minimum specific GNSS data.
+type RMC struct {
+	// Time is the UTC time of the position fix.
+	Time time.Time
+	// Valid is true when the data is valid.
+	Valid bool
+	// Latitude component (degrees).
+	LatitudeDegrees float64
+	// Longitude component (degrees).
+	LongitudeDegrees float64
+	// SpeedOverGround is the speed over ground (knots).
+	SpeedOverGroundKnots float64
+	// CourseOverGround is the true course over ground (degrees).
+	CourseOverGroundDegrees float64
+	// MagneticVariation is the magnetic variation, negative when westerly (degrees).
+	MagneticVariationDegrees float64
+	// ModeIndicator is the positioning system mode indicator.
+	ModeIndicator ModeIndicator
+}
+
+func (r *RMC) unmarshalFields(f *fields) {
+	timeOfDay := f.timeOfDay(indexOfRMCTime)
+	year, month, day := f.date(indexOfRMCDate)
+	r.Time = time.Time{}
+	if year != 0 {
+		r.Time = time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Add(timeOfDay)
+	}
+	r.Valid = f.byte(indexOfRMCStatus) == 'A'
+	r.LatitudeDegrees = f.latitude(indexOfRMCLatitude)
+	r.LongitudeDegrees = f.longitude(indexOfRMCLongitude)
+	r.SpeedOverGroundKnots = f.float(indexOfRMCSpeed)
+	r.CourseOverGroundDegrees = f.float(indexOfRMCCourse)
+	r.MagneticVariationDegrees = f.float(indexOfRMCMagneticVariation)
+	if f.byte(indexOfRMCMagneticVariationEW) == 'W' {
+		r.MagneticVariationDegrees = -r.MagneticVariationDegrees
+	}
+	r.ModeIndicator = ModeIndicator(f.byte(indexOfRMCModeIndicator))
+}
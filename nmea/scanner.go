@@ -0,0 +1,152 @@
+package nmea
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Scanner provides a convenient interface for reading and parsing NMEA sentences from a stream.
+type Scanner struct {
+	sc     *bufio.Scanner
+	err    error
+	fields fields
+	talker string
+	id     ID
+	gga    GGA
+	rmc    RMC
+	gsa    GSA
+	gsv    GSV
+	vtg    VTG
+	gst    GST
+	zda    ZDA
+}
+
+// NewScanner returns a new Scanner to read from r.
+func NewScanner(r io.Reader) *Scanner {
+	sc := bufio.NewScanner(r)
+	sc.Split(ScanSentences)
+	return &Scanner{sc: sc}
+}
+
+// Scan advances the Scanner to the next sentence, whose ID will then be
+// available through the ID method.
+func (c *Scanner) Scan() bool {
+	if c.err != nil {
+		return false
+	}
+	if ok := c.sc.Scan(); !ok {
+		c.err = c.sc.Err()
+		if c.err == nil {
+			c.err = io.EOF
+		}
+		return false
+	}
+	// assume the scan function has already validated the checksum
+	var sentenceType string
+	c.talker, sentenceType, c.fields.values = splitSentence(c.sc.Bytes())
+	c.id = parseID(sentenceType)
+	c.fields.id = c.id
+	c.fields.err = nil
+	if err := validateLength(c.id, len(c.fields.values)); err != nil {
+		c.err = err
+		return false
+	}
+	switch c.id {
+	case IDGGA:
+		c.gga.unmarshalFields(&c.fields)
+	case IDRMC:
+		c.rmc.unmarshalFields(&c.fields)
+	case IDGSA:
+		c.gsa.unmarshalFields(&c.fields)
+	case IDGSV:
+		c.gsv.unmarshalFields(&c.fields)
+	case IDVTG:
+		c.vtg.unmarshalFields(&c.fields)
+	case IDGST:
+		c.gst.unmarshalFields(&c.fields)
+	case IDZDA:
+		c.zda.unmarshalFields(&c.fields)
+	default:
+		// allow unknown sentences
+	}
+	if c.fields.err != nil {
+		c.err = c.fields.err
+		return false
+	}
+	return true
+}
+
+func validateLength(id ID, length int) error {
+	var expected int
+	switch id {
+	case IDGGA:
+		expected = lengthOfGGA
+	case IDRMC:
+		expected = lengthOfRMC
+	case IDGSA:
+		expected = lengthOfGSA
+	case IDGSV:
+		expected = lengthOfGSV
+	case IDVTG:
+		expected = lengthOfVTG
+	case IDGST:
+		expected = lengthOfGST
+	case IDZDA:
+		expected = lengthOfZDA
+	default:
+		return nil // allow unknown sentences
+	}
+	if length < expected {
+		return fmt.Errorf("validate %v sentence: illegal number of fields %d (expected minimum %d)", id, length, expected)
+	}
+	return nil
+}
+
+func (c *Scanner) Err() error {
+	if c.err == io.EOF {
+		return nil
+	}
+	return c.err
+}
+
+func (c *Scanner) ID() ID {
+	return c.id
+}
+
+// Talker returns the talker ID of the current sentence, for example "GP" for GPS or "GN" for multiple GNSS systems.
+func (c *Scanner) Talker() string {
+	return c.talker
+}
+
+func (c *Scanner) GGA() GGA {
+	return c.gga
+}
+
+func (c *Scanner) RMC() RMC {
+	return c.rmc
+}
+
+func (c *Scanner) GSA() GSA {
+	return c.gsa
+}
+
+func (c *Scanner) GSV() GSV {
+	return c.gsv
+}
+
+func (c *Scanner) VTG() VTG {
+	return c.vtg
+}
+
+func (c *Scanner) GST() GST {
+	return c.gst
+}
+
+func (c *Scanner) ZDA() ZDA {
+	return c.zda
+}
+
+func (c *Scanner) Bytes() []byte {
+	return c.sc.Bytes()
+}
@@ -0,0 +1,135 @@
+package nmea
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestScanner_Golden(t *testing.T) {
+	for _, tt := range []struct {
+		inputFile  string
+		goldenFile string
+	}{
+		{inputFile: "testdata/reach.nmea", goldenFile: "reach.nmea.golden"},
+	} {
+		tt := tt
+		t.Run(tt.inputFile, func(t *testing.T) {
+			data, err := ioutil.ReadFile(tt.inputFile)
+			assert.NilError(t, err)
+			sc := NewScanner(bytes.NewReader(data))
+			var buf bytes.Buffer
+			for sc.Scan() {
+				switch sc.ID() {
+				case IDGGA:
+					_, _ = fmt.Fprintf(&buf, "%s%v: %+v\n", sc.Talker(), sc.ID(), sc.GGA())
+				case IDRMC:
+					_, _ = fmt.Fprintf(&buf, "%s%v: %+v\n", sc.Talker(), sc.ID(), sc.RMC())
+				case IDGSA:
+					_, _ = fmt.Fprintf(&buf, "%s%v: %+v\n", sc.Talker(), sc.ID(), sc.GSA())
+				case IDGSV:
+					_, _ = fmt.Fprintf(&buf, "%s%v: %+v\n", sc.Talker(), sc.ID(), sc.GSV())
+				case IDVTG:
+					_, _ = fmt.Fprintf(&buf, "%s%v: %+v\n", sc.Talker(), sc.ID(), sc.VTG())
+				case IDGST:
+					_, _ = fmt.Fprintf(&buf, "%s%v: %+v\n", sc.Talker(), sc.ID(), sc.GST())
+				case IDZDA:
+					_, _ = fmt.Fprintf(&buf, "%s%v: %+v\n", sc.Talker(), sc.ID(), sc.ZDA())
+				default:
+					_, _ = fmt.Fprintf(&buf, "%v: %s\n", sc.ID(), sc.Bytes())
+				}
+			}
+			assert.NilError(t, sc.Err())
+			golden.Assert(t, buf.String(), tt.goldenFile)
+		})
+	}
+}
+
+func TestScanner_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		input         string
+		expectedError string
+	}{
+		{
+			name:          "checksum mismatch",
+			input:         "$GNZDA,113949.00,12,06,2021,00,00*7C\r\n",
+			expectedError: "checksum mismatch (expected 0x7b but got 0x7c)",
+		},
+		{
+			name:          "missing checksum",
+			input:         "$GNZDA,113949.00,12,06,2021,00,00\r\n",
+			expectedError: "missing checksum",
+		},
+		{
+			name:          "too few fields",
+			input:         "$GNZDA,113949.00,12,06*56\r\n",
+			expectedError: "validate ZDA sentence: illegal number of fields 4 (expected minimum 7)",
+		},
+		{
+			name:          "invalid field",
+			input:         "$GNGGA,113949.00,5746.6610077,X,01246.8323926,E,4,20,0.69,200.113,M,35.044,M,1.0,0000*74\r\n",
+			expectedError: "parse GGA field 3: invalid hemisphere 'X'",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			sc := NewScanner(strings.NewReader(tt.input))
+			assert.Assert(t, !sc.Scan())
+			assert.ErrorContains(t, sc.Err(), tt.expectedError)
+		})
+	}
+}
+
+func TestGGA_AppendSentence(t *testing.T) {
+	gga := GGA{
+		TimeUTC:                    23*time.Hour + 59*time.Minute + 59*time.Second + 996*time.Millisecond,
+		LatitudeDegrees:            57.999999999999,
+		LongitudeDegrees:           -11.9746,
+		FixQuality:                 FixQualityRTK,
+		NumSVs:                     9,
+		HDOP:                       0.8,
+		AltitudeMeanSeaLevelMeters: 12.25,
+		GeoidSeparationMeters:      33.25,
+		DifferentialAge:            1500 * time.Millisecond,
+		DifferentialStationID:      42,
+	}
+	sentence := gga.AppendSentence(nil, "GN")
+	assert.Equal(
+		t,
+		"$GNGGA,000000.00,5800.0000000,N,01158.4760000,W,4,09,0.8,12.250,M,33.250,M,1.5,0042*7E\r\n",
+		string(sentence),
+	)
+	sc := NewScanner(bytes.NewReader(sentence))
+	assert.Assert(t, sc.Scan(), sc.Err())
+	actual := sc.GGA()
+	assert.Equal(t, time.Duration(0), actual.TimeUTC)
+	assert.Equal(t, FixQualityRTK, actual.FixQuality)
+	assert.Equal(t, uint16(42), actual.DifferentialStationID)
+}
+
+func TestFixQuality_FixType(t *testing.T) {
+	for _, tt := range []struct {
+		fixQuality FixQuality
+		expected   erb.FixType
+	}{
+		{fixQuality: FixQualityInvalid, expected: erb.FixTypeNoFix},
+		{fixQuality: FixQualityGPS, expected: erb.FixTypeSingle},
+		{fixQuality: FixQualityDGPS, expected: erb.FixTypeSingle},
+		{fixQuality: FixQualityRTK, expected: erb.FixTypeRTK},
+		{fixQuality: FixQualityFloat, expected: erb.FixTypeFloat},
+		{fixQuality: FixQualityDeadReckoning, expected: erb.FixTypeNoFix},
+	} {
+		tt := tt
+		t.Run(tt.fixQuality.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.fixQuality.FixType())
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package nmea
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	startChar         = '$'
+	checksumChar      = '*'
+	fieldSeparator    = ','
+	lengthOfChecksum  = 2
+	lengthOfFormatter = 3
+)
+
+// ScanSentences is a split function for a bufio.Scanner that returns each NMEA sentence,
+// without the trailing line terminator.
+func ScanSentences(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	// scan until start of sentence
+	if len(data) == 0 {
+		return 0, nil, nil
+	}
+	if data[0] != startChar {
+		i := bytes.IndexByte(data, startChar)
+		if i == -1 {
+			return len(data), nil, nil
+		}
+		return i, nil, nil
+	}
+	// scan until end of sentence
+	i := bytes.IndexByte(data, '\n')
+	if i == -1 {
+		if atEOF {
+			return len(data), nil, nil // drop truncated sentence
+		}
+		return 0, nil, nil
+	}
+	sentence := bytes.TrimRight(data[:i], "\r")
+	if err := validateChecksum(sentence); err != nil {
+		return 0, nil, err
+	}
+	return i + 1, sentence, nil
+}
+
+func validateChecksum(sentence []byte) error {
+	i := bytes.LastIndexByte(sentence, checksumChar)
+	if i == -1 || len(sentence)-i-1 != lengthOfChecksum {
+		return fmt.Errorf("validate sentence %q: missing checksum", sentence)
+	}
+	var actual [1]byte
+	if _, err := hex.Decode(actual[:], sentence[i+1:]); err != nil {
+		return fmt.Errorf("validate sentence %q: invalid checksum: %w", sentence, err)
+	}
+	expected := checksum(sentence[1:i])
+	if expected != actual[0] {
+		return fmt.Errorf(
+			"validate sentence %q: checksum mismatch (expected 0x%02x but got 0x%02x)", sentence, expected, actual[0],
+		)
+	}
+	return nil
+}
+
+// appendChecksum appends the checksum and line terminator of the sentence starting at b[start] to b.
+func appendChecksum(b []byte, start int) []byte {
+	return append(b, fmt.Sprintf("%c%02X\r\n", checksumChar, checksum(b[start+1:]))...)
+}
+
+// appendCoordinate appends a coordinate in the format (d)ddmm.mmmmmmm and its hemisphere to b.
+func appendCoordinate(b []byte, degrees float64, lengthOfDegrees int, positive, negative byte) []byte {
+	hemisphere := positive
+	if degrees < 0 {
+		hemisphere = negative
+	}
+	// round before splitting, so that the minutes never round up to 60
+	const minuteResolution = 1e7
+	totalMinutes := math.Round(math.Abs(degrees) * 60 * minuteResolution)
+	wholeDegrees := math.Floor(totalMinutes / (60 * minuteResolution))
+	minutes := (totalMinutes - wholeDegrees*60*minuteResolution) / minuteResolution
+	return append(b, fmt.Sprintf("%0*d%010.7f,%c", lengthOfDegrees, int(wholeDegrees), minutes, hemisphere)...)
+}
+
+// appendTimeOfDay appends a time of day in the format hhmmss.ss to b.
+func appendTimeOfDay(b []byte, d time.Duration) []byte {
+	const day = 24 * time.Hour
+	d = d.Round(10*time.Millisecond) % day
+	if d < 0 {
+		d += day
+	}
+	return append(b, fmt.Sprintf(
+		"%02d%02d%02d.%02d",
+		d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second, d%time.Second/(10*time.Millisecond),
+	)...)
+}
+
+func checksum(data []byte) byte {
+	var result byte
+	for _, b := range data {
+		result ^= b
+	}
+	return result
+}
+
+// fields is a parser for the comma-separated fields of a sentence.
+//
+// Empty fields are parsed as zero values, and the first parse error is recorded in err.
+type fields struct {
+	id     ID
+	values []string
+	err    error
+}
+
+func (f *fields) setErr(i int, err error) {
+	if f.err == nil {
+		f.err = fmt.Errorf("parse %v field %d: %w", f.id, i, err)
+	}
+}
+
+func (f *fields) string(i int) string {
+	if i >= len(f.values) {
+		return ""
+	}
+	return f.values[i]
+}
+
+func (f *fields) byte(i int) byte {
+	s := f.string(i)
+	if s == "" {
+		return 0
+	}
+	if len(s) != 1 {
+		f.setErr(i, fmt.Errorf("invalid character %q", s))
+		return 0
+	}
+	return s[0]
+}
+
+func (f *fields) float(i int) float64 {
+	s := f.string(i)
+	if s == "" {
+		return 0
+	}
+	result, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		f.setErr(i, err)
+		return 0
+	}
+	return result
+}
+
+func (f *fields) uint(i int, bitSize int) uint64 {
+	s := f.string(i)
+	if s == "" {
+		return 0
+	}
+	result, err := strconv.ParseUint(s, 10, bitSize)
+	if err != nil {
+		f.setErr(i, err)
+		return 0
+	}
+	return result
+}
+
+func (f *fields) int(i int, bitSize int) int64 {
+	s := f.string(i)
+	if s == "" {
+		return 0
+	}
+	result, err := strconv.ParseInt(s, 10, bitSize)
+	if err != nil {
+		f.setErr(i, err)
+		return 0
+	}
+	return result
+}
+
+// latitude parses a latitude in the format ddmm.mmmm from field i and a hemisphere from field i+1.
+func (f *fields) latitude(i int) float64 {
+	return f.coordinate(i, 2, 'N', 'S')
+}
+
+// longitude parses a longitude in the format dddmm.mmmm from field i and a hemisphere from field i+1.
+func (f *fields) longitude(i int) float64 {
+	return f.coordinate(i, 3, 'E', 'W')
+}
+
+func (f *fields) coordinate(i int, lengthOfDegrees int, positive, negative byte) float64 {
+	s := f.string(i)
+	if s == "" {
+		return 0
+	}
+	if len(s) < lengthOfDegrees {
+		f.setErr(i, fmt.Errorf("invalid coordinate %q", s))
+		return 0
+	}
+	degrees, err := strconv.ParseUint(s[:lengthOfDegrees], 10, 8)
+	if err != nil {
+		f.setErr(i, err)
+		return 0
+	}
+	minutes, err := strconv.ParseFloat(s[lengthOfDegrees:], 64)
+	if err != nil {
+		f.setErr(i, err)
+		return 0
+	}
+	result := float64(degrees) + minutes/60
+	switch hemisphere := f.byte(i + 1); hemisphere {
+	case positive:
+		return result
+	case negative:
+		return -result
+	default:
+		f.setErr(i+1, fmt.Errorf("invalid hemisphere %q", hemisphere))
+		return 0
+	}
+}
+
+// timeOfDay parses a time of day in the format hhmmss.ss.
+func (f *fields) timeOfDay(i int) time.Duration {
+	s := f.string(i)
+	if s == "" {
+		return 0
+	}
+	if len(s) < len("hhmmss") {
+		f.setErr(i, fmt.Errorf("invalid time %q", s))
+		return 0
+	}
+	hours, errHours := strconv.ParseUint(s[0:2], 10, 8)
+	minutes, errMinutes := strconv.ParseUint(s[2:4], 10, 8)
+	seconds, errSeconds := strconv.ParseFloat(s[4:], 64)
+	if errHours != nil || errMinutes != nil || errSeconds != nil || hours > 23 || minutes > 59 || seconds >= 61 {
+		f.setErr(i, fmt.Errorf("invalid time %q", s))
+		return 0
+	}
+	return time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(math.Round(seconds*float64(time.Second/time.Millisecond)))*time.Millisecond
+}
+
+// date parses a date in the format ddmmyy.
+func (f *fields) date(i int) (year int, month time.Month, day int) {
+	s := f.string(i)
+	if s == "" {
+		return 0, 0, 0
+	}
+	if len(s) != len("ddmmyy") {
+		f.setErr(i, fmt.Errorf("invalid date %q", s))
+		return 0, 0, 0
+	}
+	dd, errDay := strconv.ParseUint(s[0:2], 10, 8)
+	mm, errMonth := strconv.ParseUint(s[2:4], 10, 8)
+	yy, errYear := strconv.ParseUint(s[4:6], 10, 8)
+	if errDay != nil || errMonth != nil || errYear != nil || mm < 1 || mm > 12 || dd < 1 || dd > 31 {
+		f.setErr(i, fmt.Errorf("invalid date %q", s))
+		return 0, 0, 0
+	}
+	// two-digit years are interpreted within the GPS era (1980-2079)
+	year = 2000 + int(yy)
+	if yy >= 80 {
+		year = 1900 + int(yy)
+	}
+	return year, time.Month(mm), int(dd)
+}
+
+// splitSentence splits a validated sentence into talker ID, sentence type and fields.
+func splitSentence(sentence []byte) (talker string, sentenceType string, values []string) {
+	body := string(sentence[1:bytes.LastIndexByte(sentence, checksumChar)])
+	values = strings.Split(body, string(fieldSeparator))
+	address := values[0]
+	if len(address) < lengthOfFormatter {
+		return "", address, values
+	}
+	talker, sentenceType = address[:len(address)-lengthOfFormatter], address[len(address)-lengthOfFormatter:]
+	return talker, sentenceType, values
+}
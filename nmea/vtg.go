@@ -0,0 +1,33 @@
+package nmea
+
+// structure of VTG sentence.
+const (
+	lengthOfVTG                   = 9
+	indexOfVTGCourseTrue          = 1
+	indexOfVTGCourseMagnetic      = 3
+	indexOfVTGSpeedKnots          = 5
+	indexOfVTGSpeedKilometersHour = 7
+	indexOfVTGModeIndicator       = 9
+)
+
+// VTG sentence contains the course over ground and ground speed.
+type VTG struct {
+	// CourseOverGroundTrue is the true course over ground (degrees).
+	CourseOverGroundTrueDegrees float64
+	// CourseOverGroundMagnetic is the magnetic course over ground (degrees).
+	CourseOverGroundMagneticDegrees float64
+	// SpeedOverGround is the speed over ground (knots).
+	SpeedOverGroundKnots float64
+	// SpeedOverGround is the speed over ground (km/h).
+	SpeedOverGroundKilometersPerHour float64
+	// ModeIndicator is the positioning system mode indicator.
+	ModeIndicator ModeIndicator
+}
+
+func (v *VTG) unmarshalFields(f *fields) {
+	v.CourseOverGroundTrueDegrees = f.float(indexOfVTGCourseTrue)
+	v.CourseOverGroundMagneticDegrees = f.float(indexOfVTGCourseMagnetic)
+	v.SpeedOverGroundKnots = f.float(indexOfVTGSpeedKnots)
+	v.SpeedOverGroundKilometersPerHour = f.float(indexOfVTGSpeedKilometersHour)
+	v.ModeIndicator = ModeIndicator(f.byte(indexOfVTGModeIndicator))
+}
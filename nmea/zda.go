@@ -0,0 +1,43 @@
+package nmea
+
+import (
+	"fmt"
+	"time"
+)
+
+// structure of ZDA sentence.
+const (
+	lengthOfZDA               = 7
+	indexOfZDATime            = 1
+	indexOfZDADay             = 2
+	indexOfZDAMonth           = 3
+	indexOfZDAYear            = 4
+	indexOfZDALocalZoneHours  = 5
+	indexOfZDALocalZoneMinute = 6
+)
+
+// ZDA sentence contains the UTC time and date, and the local time zone.
+type ZDA struct {
+	// Time is the UTC time.
+	Time time.Time
+	// LocalZoneHours is the local time zone offset from UTC (hours).
+	LocalZoneHours int8
+	// LocalZoneMinutes is the local time zone offset from UTC (minutes).
+	LocalZoneMinutes uint8
+}
+
+func (z *ZDA) unmarshalFields(f *fields) {
+	timeOfDay := f.timeOfDay(indexOfZDATime)
+	day := f.uint(indexOfZDADay, 8)
+	month := f.uint(indexOfZDAMonth, 8)
+	year := f.uint(indexOfZDAYear, 16)
+	z.Time = time.Time{}
+	if year != 0 {
+		if month < 1 || month > 12 || day < 1 || day > 31 {
+			f.setErr(indexOfZDADay, fmt.Errorf("invalid date %d-%d-%d", year, month, day))
+		}
+		z.Time = time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, time.UTC).Add(timeOfDay)
+	}
+	z.LocalZoneHours = int8(f.int(indexOfZDALocalZoneHours, 8))
+	z.LocalZoneMinutes = uint8(f.uint(indexOfZDALocalZoneMinute, 8))
+}
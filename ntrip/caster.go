@@ -0,0 +1,368 @@
+package ntrip
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// Event is an event of a Caster.
+type Event struct {
+	// Type of the event.
+	Type EventType
+	// Mountpoint requested by the client.
+	Mountpoint string
+	// RemoteAddr is the address of the client.
+	RemoteAddr net.Addr
+	// Username of the client, if authenticated.
+	Username string
+	// Err is the error that caused the client to disconnect, if any.
+	Err error
+}
+
+// Caster is an NTRIP caster, that serves correction streams to NTRIP version 1 and version 2 clients.
+//
+// Requests for the root path, and NTRIP version 1 requests for unknown mountpoints, are answered with the
+// sourcetable of the caster.
+type Caster struct {
+	cfg         CasterConfig
+	mu          sync.Mutex
+	mountpoints []string
+	streams     map[string]*Stream
+}
+
+// NewCaster returns a new Caster with the provided config.
+func NewCaster(cfg CasterConfig) *Caster {
+	cfg.setDefaults()
+	return &Caster{cfg: cfg, streams: map[string]*Stream{}}
+}
+
+// AddStream adds a stream to the caster, described by the provided sourcetable entry.
+//
+// The authentication of the entry defaults to "B" when the caster has users, and "N" otherwise.
+func (c *Caster) AddStream(entry StreamEntry) *Stream {
+	if entry.Authentication == "" {
+		entry.Authentication = "N"
+		if len(c.cfg.Users) > 0 {
+			entry.Authentication = "B"
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.streams[entry.Mountpoint]; ok {
+		s.mu.Lock()
+		s.entry = entry
+		s.mu.Unlock()
+		return s
+	}
+	s := &Stream{caster: c, entry: entry, clients: map[*casterClient]struct{}{}}
+	c.streams[entry.Mountpoint] = s
+	c.mountpoints = append(c.mountpoints, entry.Mountpoint)
+	return s
+}
+
+// Sourcetable returns the sourcetable of the caster, with the streams in the order they were added.
+func (c *Caster) Sourcetable() Sourcetable {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var result Sourcetable
+	for _, mountpoint := range c.mountpoints {
+		result.Streams = append(result.Streams, c.streams[mountpoint].Entry())
+	}
+	return result
+}
+
+// Serve accepts connections on the listener until the context is canceled, which closes the listener.
+func (c *Caster) Serve(ctx context.Context, lis net.Listener) error {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		_ = lis.Close()
+	}()
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("ntrip caster: serve: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.serveConn(ctx, conn)
+		}()
+	}
+}
+
+func (c *Caster) stream(mountpoint string) (*Stream, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.streams[mountpoint]
+	return s, ok
+}
+
+func (c *Caster) serveConn(ctx context.Context, conn net.Conn) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	if err := conn.SetReadDeadline(time.Now().Add(c.cfg.RequestTimeout)); err != nil {
+		return
+	}
+	br := bufio.NewReader(conn)
+	r, err := http.ReadRequest(br)
+	if err != nil {
+		return
+	}
+	version := Version1
+	if strings.EqualFold(r.Header.Get("Ntrip-Version"), "Ntrip/2.0") {
+		version = Version2
+	}
+	_ = conn.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout))
+	mountpoint := strings.TrimPrefix(r.URL.Path, "/")
+	s, ok := c.stream(mountpoint)
+	switch {
+	case mountpoint != "" && !ok && version == Version2:
+		_, _ = io.WriteString(conn, "HTTP/1.1 404 Not Found\r\nNtrip-Version: Ntrip/2.0\r\nConnection: close\r\n\r\n")
+		return
+	case !ok:
+		c.writeSourcetable(conn, version)
+		return
+	}
+	event := Event{Mountpoint: mountpoint, RemoteAddr: conn.RemoteAddr()}
+	if len(c.cfg.Users) > 0 {
+		username, password, ok := r.BasicAuth()
+		if !ok || !c.authenticate(username, password) {
+			status := "HTTP/1.0 401 Unauthorized\r\n"
+			if version == Version2 {
+				status = "HTTP/1.1 401 Unauthorized\r\nNtrip-Version: Ntrip/2.0\r\nConnection: close\r\n"
+			}
+			_, _ = fmt.Fprintf(conn, "%sWWW-Authenticate: Basic realm=\"/%s\"\r\n\r\n", status, mountpoint)
+			event.Type = EventTypeClientUnauthorized
+			c.emit(event)
+			return
+		}
+		event.Username = username
+	}
+	var w io.Writer = conn
+	if version == Version2 {
+		_, err = fmt.Fprintf(
+			conn,
+			"HTTP/1.1 200 OK\r\n"+
+				"Ntrip-Version: Ntrip/2.0\r\n"+
+				"Server: %s\r\n"+
+				"Content-Type: %s\r\n"+
+				"Transfer-Encoding: chunked\r\n"+
+				"Cache-Control: no-store, no-cache, max-age=0\r\n"+
+				"Pragma: no-cache\r\n"+
+				"Connection: close\r\n\r\n",
+			c.cfg.Server,
+			contentTypeData,
+		)
+		w = httputil.NewChunkedWriter(conn)
+	} else {
+		_, err = io.WriteString(conn, "ICY 200 OK\r\n")
+	}
+	if err != nil {
+		return
+	}
+	client := &casterClient{
+		event:   event,
+		queue:   make(chan []byte, c.cfg.QueueLength),
+		dropped: make(chan struct{}),
+	}
+	s.add(client)
+	event.Type = EventTypeClientConnected
+	c.emit(event)
+	// GGA sentences from the client are read and discarded, to detect when the client disconnects
+	readDone := make(chan error, 1)
+	go func() {
+		_ = conn.SetReadDeadline(time.Time{})
+		_, err := io.Copy(ioutil.Discard, br)
+		if err == nil {
+			err = io.EOF
+		}
+		readDone <- err
+	}()
+	err = c.forward(ctx, conn, w, client, readDone)
+	s.remove(client)
+	event.Type = EventTypeClientDisconnected
+	event.Err = err
+	c.emit(event)
+}
+
+// forward the queued writes of a stream to a client until the client disconnects or is dropped.
+func (c *Caster) forward(
+	ctx context.Context,
+	conn net.Conn,
+	w io.Writer,
+	client *casterClient,
+	readDone <-chan error,
+) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readDone:
+			return err
+		case <-client.dropped:
+			return fmt.Errorf("dropped after %d queued writes", c.cfg.QueueLength)
+		case data := <-client.queue:
+			if err := conn.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout)); err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (c *Caster) writeSourcetable(conn net.Conn, version Version) {
+	sourcetable := c.Sourcetable()
+	data, err := sourcetable.MarshalText()
+	if err != nil {
+		return
+	}
+	if version == Version2 {
+		_, _ = fmt.Fprintf(
+			conn,
+			"HTTP/1.1 200 OK\r\n"+
+				"Ntrip-Version: Ntrip/2.0\r\n"+
+				"Server: %s\r\n"+
+				"Content-Type: %s\r\n"+
+				"Content-Length: %d\r\n"+
+				"Connection: close\r\n\r\n",
+			c.cfg.Server,
+			contentTypeSourcetable,
+			len(data),
+		)
+	} else {
+		_, _ = fmt.Fprintf(
+			conn,
+			"SOURCETABLE 200 OK\r\nServer: %s\r\nContent-Type: text/plain\r\nContent-Length: %d\r\n\r\n",
+			c.cfg.Server,
+			len(data),
+		)
+	}
+	_, _ = conn.Write(data)
+}
+
+func (c *Caster) authenticate(username, password string) bool {
+	expected, ok := c.cfg.Users[username]
+	return ok && subtle.ConstantTimeCompare([]byte(expected), []byte(password)) == 1
+}
+
+func (c *Caster) emit(event Event) {
+	if c.cfg.OnEvent != nil {
+		c.cfg.OnEvent(event)
+	}
+}
+
+// casterClient is a client connected to a stream.
+type casterClient struct {
+	// event is the template for events of the client.
+	event Event
+	queue chan []byte
+	// dropped is closed when the client is dropped for not keeping up with the stream.
+	dropped chan struct{}
+}
+
+// Stream is a correction stream of a Caster.
+//
+// Each write to the stream is fanned out whole to the connected clients, so that clients connecting mid-stream
+// stay aligned to the messages of the stream when whole messages are written. Each client has a bounded queue of
+// writes, and clients that don't keep up with the stream are dropped, so that a slow client never blocks the stream
+// or its other clients.
+type Stream struct {
+	caster  *Caster
+	mu      sync.Mutex
+	entry   StreamEntry
+	clients map[*casterClient]struct{}
+}
+
+// Write the provided data to the clients of the stream.
+//
+// Write never blocks on clients and always succeeds.
+func (s *Stream) Write(p []byte) (int, error) {
+	data := append([]byte(nil), p...)
+	var dropped []*casterClient
+	s.mu.Lock()
+	for client := range s.clients {
+		select {
+		case client.queue <- data:
+		default:
+			delete(s.clients, client)
+			close(client.dropped)
+			dropped = append(dropped, client)
+		}
+	}
+	s.mu.Unlock()
+	for _, client := range dropped {
+		event := client.event
+		event.Type = EventTypeClientDropped
+		s.caster.emit(event)
+	}
+	return len(p), nil
+}
+
+// Update the sourcetable entry of the stream with the current message of the scanner.
+//
+// The position of the entry is updated from POS messages of the base station. Other messages are ignored.
+func (s *Stream) Update(sc *erb.Scanner) {
+	if sc.ID() != erb.IDPOS {
+		return
+	}
+	pos := sc.POS()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entry.LatitudeDegrees = pos.LatitudeDegrees
+	s.entry.LongitudeDegrees = pos.LongitudeDegrees
+}
+
+// Entry returns the sourcetable entry of the stream.
+func (s *Stream) Entry() StreamEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entry
+}
+
+// Clients returns the number of clients connected to the stream.
+func (s *Stream) Clients() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.clients)
+}
+
+func (s *Stream) add(client *casterClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clients[client] = struct{}{}
+}
+
+func (s *Stream) remove(client *casterClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clients, client)
+}
@@ -0,0 +1,261 @@
+package ntrip
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach"
+	"go.einride.tech/reach/erb"
+	"gotest.tools/v3/assert"
+)
+
+// testEvents records the events of a caster.
+type testEvents struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (e *testEvents) OnEvent(event Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, event)
+}
+
+func (e *testEvents) Events() []Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Event(nil), e.events...)
+}
+
+func (e *testEvents) Types() []EventType {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	result := make([]EventType, 0, len(e.events))
+	for _, event := range e.events {
+		result = append(result, event.Type)
+	}
+	return result
+}
+
+func serveTestCaster(ctx context.Context, t *testing.T, caster *Caster) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.NilError(t, err)
+	done := make(chan error, 1)
+	go func() {
+		done <- caster.Serve(ctx, lis)
+	}()
+	t.Cleanup(func() {
+		assert.NilError(t, <-done)
+	})
+	return lis.Addr().String()
+}
+
+// waitForClients waits until the stream has the provided number of clients.
+func waitForClients(t *testing.T, stream *Stream, n int) {
+	t.Helper()
+	for i := 0; i < 500 && stream.Clients() != n; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, n, stream.Clients())
+}
+
+func TestCaster(t *testing.T) {
+	for _, version := range []Version{Version1, Version2} {
+		version := version
+		t.Run(fmt.Sprintf("version %d", version), func(t *testing.T) {
+			frame := exampleFrame(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			var events testEvents
+			caster := NewCaster(CasterConfig{
+				Users:   map[string]string{"rover": "secret"},
+				OnEvent: events.OnEvent,
+			})
+			stream := caster.AddStream(StreamEntry{Mountpoint: "YARD", Format: "RTCM 3"})
+			address := serveTestCaster(ctx, t, caster)
+			defer cancel()
+			var wg sync.WaitGroup
+			writers := make([]*testWriter, 2)
+			for i := range writers {
+				clientCtx, cancelClient := context.WithCancel(ctx)
+				writers[i] = &testWriter{expected: 2 * len(frame), cancel: cancelClient}
+				client := NewClient(ClientConfig{
+					Address:    address,
+					Mountpoint: "YARD",
+					Username:   "rover",
+					Password:   "secret",
+					Version:    version,
+				})
+				wg.Add(1)
+				go func(w *testWriter) {
+					defer wg.Done()
+					_ = client.Run(clientCtx, w)
+				}(writers[i])
+			}
+			waitForClients(t, stream, len(writers))
+			_, err := stream.Write(frame)
+			assert.NilError(t, err)
+			_, err = stream.Write(frame)
+			assert.NilError(t, err)
+			wg.Wait()
+			for _, w := range writers {
+				assert.DeepEqual(t, append(append([]byte(nil), frame...), frame...), w.buf.Bytes())
+			}
+			waitForClients(t, stream, 0)
+			cancel()
+			for _, event := range events.Events() {
+				assert.Equal(t, "rover", event.Username)
+				assert.Equal(t, "YARD", event.Mountpoint)
+			}
+		})
+	}
+}
+
+func TestCaster_Unauthorized(t *testing.T) {
+	for _, version := range []Version{Version1, Version2} {
+		version := version
+		t.Run(fmt.Sprintf("version %d", version), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var events testEvents
+			caster := NewCaster(CasterConfig{Users: map[string]string{"rover": "secret"}, OnEvent: events.OnEvent})
+			caster.AddStream(StreamEntry{Mountpoint: "YARD"})
+			address := serveTestCaster(ctx, t, caster)
+			clientCtx, cancelClient := context.WithCancel(ctx)
+			var actual error
+			client := NewClient(ClientConfig{
+				Address:    address,
+				Mountpoint: "YARD",
+				Username:   "rover",
+				Password:   "wrong",
+				Version:    version,
+				OnStateChange: func(state reach.ConnectionState, err error) {
+					if state == reach.ConnectionStateDisconnected {
+						actual = err
+						cancelClient()
+					}
+				},
+			})
+			assert.Assert(t, errors.Is(client.Run(clientCtx, ioutil.Discard), context.Canceled))
+			assert.Assert(t, errors.Is(actual, ErrUnauthorized), actual)
+			assert.DeepEqual(t, []EventType{EventTypeClientUnauthorized}, events.Types())
+		})
+	}
+}
+
+func TestCaster_Sourcetable(t *testing.T) {
+	for _, version := range []Version{Version1, Version2} {
+		version := version
+		t.Run(fmt.Sprintf("version %d", version), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			caster := NewCaster(CasterConfig{Users: map[string]string{"rover": "secret"}})
+			stream := caster.AddStream(StreamEntry{Mountpoint: "YARD", Identifier: "Gothenburg", Format: "RTCM 3"})
+			var buf bytes.Buffer
+			assert.NilError(t, erb.NewEncoder(&buf).EncodePOS(erb.POS{LatitudeDegrees: 57.7089, LongitudeDegrees: 11.9746}))
+			sc := erb.NewScanner(&buf)
+			assert.Assert(t, sc.Scan())
+			stream.Update(sc)
+			address := serveTestCaster(ctx, t, caster)
+			client := NewClient(ClientConfig{Address: address, Version: version})
+			actual, err := client.FetchSourcetable(ctx)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, Sourcetable{
+				Streams: []StreamEntry{
+					{
+						Mountpoint:       "YARD",
+						Identifier:       "Gothenburg",
+						Format:           "RTCM 3",
+						LatitudeDegrees:  57.71,
+						LongitudeDegrees: 11.97,
+						Authentication:   "B",
+					},
+				},
+			}, actual)
+		})
+	}
+}
+
+func TestCaster_NotFound(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	caster := NewCaster(CasterConfig{})
+	caster.AddStream(StreamEntry{Mountpoint: "YARD"})
+	address := serveTestCaster(ctx, t, caster)
+	for _, tt := range []struct {
+		version  Version
+		expected error
+	}{
+		// version 1 casters answer with the sourcetable
+		{version: Version1, expected: ErrMountpointNotFound},
+		{version: Version2, expected: ErrMountpointNotFound},
+	} {
+		clientCtx, cancelClient := context.WithCancel(ctx)
+		var actual error
+		client := NewClient(ClientConfig{
+			Address:    address,
+			Mountpoint: "MISSING",
+			Version:    tt.version,
+			OnStateChange: func(state reach.ConnectionState, err error) {
+				if state == reach.ConnectionStateDisconnected {
+					actual = err
+					cancelClient()
+				}
+			},
+		})
+		assert.Assert(t, errors.Is(client.Run(clientCtx, ioutil.Discard), context.Canceled))
+		assert.Assert(t, errors.Is(actual, tt.expected), actual)
+	}
+}
+
+func TestCaster_DropSlowClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var events testEvents
+	caster := NewCaster(CasterConfig{QueueLength: 1, OnEvent: events.OnEvent})
+	stream := caster.AddStream(StreamEntry{Mountpoint: "YARD"})
+	address := serveTestCaster(ctx, t, caster)
+	// a slow client, that never reads the stream
+	slow, err := net.Dial("tcp", address)
+	assert.NilError(t, err)
+	defer func() {
+		_ = slow.Close()
+	}()
+	_, err = io.WriteString(slow, "GET /YARD HTTP/1.0\r\nUser-Agent: NTRIP test\r\n\r\n")
+	assert.NilError(t, err)
+	waitForClients(t, stream, 1)
+	data := make([]byte, 64*1024)
+	for i := 0; i < 10000 && stream.Clients() > 0; i++ {
+		_, err := stream.Write(data)
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, 0, stream.Clients())
+	for i := 0; i < 500 && len(events.Types()) < 3; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.DeepEqual(t, []EventType{
+		EventTypeClientConnected,
+		EventTypeClientDropped,
+		EventTypeClientDisconnected,
+	}, events.Types())
+}
+
+func TestSourcetable_MarshalText(t *testing.T) {
+	expected, err := ParseSourcetable(strings.NewReader(testSourcetable))
+	assert.NilError(t, err)
+	data, err := expected.MarshalText()
+	assert.NilError(t, err)
+	assert.Equal(t, testSourcetable, string(data))
+	actual, err := ParseSourcetable(bytes.NewReader(data))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, expected, actual)
+}
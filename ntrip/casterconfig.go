@@ -0,0 +1,37 @@
+package ntrip
+
+import "time"
+
+// CasterConfig configures a Caster.
+type CasterConfig struct {
+	// Users are the passwords of the users allowed to connect, by username. Authentication is disabled when empty.
+	Users map[string]string
+	// Server is the value of the Server header of responses. Defaults to "NTRIP go.einride.tech/reach".
+	Server string
+	// QueueLength is the maximum number of writes to a stream queued for a client, before the client is dropped for
+	// not keeping up with the stream. Defaults to 64.
+	QueueLength int
+	// RequestTimeout is the maximum time to wait for the request of a client. Defaults to 10s.
+	RequestTimeout time.Duration
+	// WriteTimeout is the maximum time to wait for a write to a client. Defaults to 10s.
+	WriteTimeout time.Duration
+	// OnEvent is an optional callback invoked with each event of the caster.
+	//
+	// OnEvent may be called concurrently from the goroutines of different clients.
+	OnEvent func(Event)
+}
+
+func (c *CasterConfig) setDefaults() {
+	if c.Server == "" {
+		c.Server = "NTRIP go.einride.tech/reach"
+	}
+	if c.QueueLength <= 0 {
+		c.QueueLength = 64
+	}
+	if c.RequestTimeout <= 0 {
+		c.RequestTimeout = 10 * time.Second
+	}
+	if c.WriteTimeout <= 0 {
+		c.WriteTimeout = 10 * time.Second
+	}
+}
@@ -0,0 +1,357 @@
+package ntrip
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httputil"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.einride.tech/reach"
+	"go.einride.tech/reach/erb"
+)
+
+// ErrUnauthorized is returned when the caster rejects the credentials of the client.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrMountpointNotFound is returned when the caster does not provide the requested mountpoint.
+var ErrMountpointNotFound = errors.New("mountpoint not found")
+
+// content types of NTRIP version 2 responses.
+const (
+	contentTypeSourcetable = "gnss/sourcetable"
+	contentTypeData        = "gnss/data"
+)
+
+// lengthOfReadBuffer is the size of the buffer for reading corrections from the caster.
+const lengthOfReadBuffer = 4096
+
+// Client is an NTRIP client, that forwards the correction stream of a mountpoint to a Reach and automatically
+// reconnects when the connection is lost.
+type Client struct {
+	cfg      ClientConfig
+	mu       sync.Mutex
+	position position
+	// positioned is closed when the first POS message is received.
+	positioned chan struct{}
+	state      reach.ConnectionState
+	backoff    time.Duration
+}
+
+// NewClient creates a new Client with the provided config.
+func NewClient(cfg ClientConfig) *Client {
+	cfg.setDefaults()
+	return &Client{cfg: cfg, positioned: make(chan struct{})}
+}
+
+// Update the client with the current message of the scanner.
+//
+// The latest POS, STAT and DOPS messages are used for building the GGA sentences uploaded to the caster. Other
+// messages are ignored. Update may be called concurrently with Run.
+func (c *Client) Update(sc *erb.Scanner) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hadPOS := c.position.hasPOS
+	c.position.update(sc)
+	if !hadPOS && c.position.hasPOS {
+		close(c.positioned)
+	}
+}
+
+// Run connects to the caster and forwards the correction stream of the mountpoint to w, typically the correction
+// input port of a Reach.
+//
+// Run connects and reconnects to the caster as needed, and only returns when the context is canceled or when writing
+// to w fails.
+func (c *Client) Run(ctx context.Context, w io.Writer) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := c.stream(ctx, w)
+		var errForward *forwardError
+		if errors.As(err, &errForward) {
+			c.setState(reach.ConnectionStateDisconnected, err)
+			return err
+		}
+		if ctx.Err() != nil {
+			c.setState(reach.ConnectionStateDisconnected, nil)
+			continue
+		}
+		c.setState(reach.ConnectionStateDisconnected, err)
+		c.sleepBackoff(ctx)
+	}
+}
+
+// FetchSourcetable fetches the sourcetable of the caster.
+func (c *Client) FetchSourcetable(ctx context.Context) (Sourcetable, error) {
+	conn, resp, err := c.connect(ctx, "/", nil)
+	if err != nil {
+		return Sourcetable{}, fmt.Errorf("ntrip client: fetch sourcetable: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if err := conn.SetReadDeadline(time.Now().Add(c.cfg.ReadTimeout)); err != nil {
+		return Sourcetable{}, fmt.Errorf("ntrip client: fetch sourcetable: %w", err)
+	}
+	result, err := ParseSourcetable(resp.body)
+	if err != nil {
+		return Sourcetable{}, fmt.Errorf("ntrip client: fetch sourcetable: %w", err)
+	}
+	return result, nil
+}
+
+// forwardError is an error forwarding corrections to the writer of Run.
+type forwardError struct {
+	err error
+}
+
+func (e *forwardError) Error() string {
+	return fmt.Sprintf("ntrip client: forward: %v", e.err)
+}
+
+func (e *forwardError) Unwrap() error {
+	return e.err
+}
+
+// stream the corrections of a single connection to w.
+func (c *Client) stream(ctx context.Context, w io.Writer) error {
+	c.setState(reach.ConnectionStateConnecting, nil)
+	var headerGGA []byte
+	if c.cfg.Version == Version2 {
+		headerGGA, _ = c.gga()
+	}
+	conn, resp, err := c.connect(ctx, "/"+c.cfg.Mountpoint, headerGGA)
+	if err != nil {
+		return fmt.Errorf("ntrip client: connect: %w", err)
+	}
+	if resp.sourcetable {
+		_ = conn.Close()
+		return fmt.Errorf("ntrip client: connect: %s: %w", c.cfg.Mountpoint, ErrMountpointNotFound)
+	}
+	c.setState(reach.ConnectionStateConnected, nil)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2)
+	// close the connection when the context is canceled, to interrupt blocking reads
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-stop:
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		c.uploadGGA(conn, headerGGA != nil, stop)
+	}()
+	defer func() {
+		close(stop)
+		_ = conn.Close()
+		wg.Wait()
+	}()
+	buf := make([]byte, lengthOfReadBuffer)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(c.cfg.ReadTimeout)); err != nil {
+			return fmt.Errorf("ntrip client: set read deadline: %w", err)
+		}
+		n, err := resp.body.Read(buf)
+		if n > 0 {
+			c.backoff = 0
+			if _, err := w.Write(buf[:n]); err != nil {
+				return &forwardError{err: err}
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("ntrip client: stream: %w", err)
+		}
+	}
+}
+
+// uploadGGA writes GGA sentences to the connection until stop is closed.
+//
+// Unless a GGA sentence was already sent in the request, the first sentence is written as soon as a position is
+// available, and the following sentences are written periodically.
+func (c *Client) uploadGGA(conn net.Conn, sentInRequest bool, stop <-chan struct{}) {
+	ticker := time.NewTicker(c.cfg.GGAInterval)
+	defer ticker.Stop()
+	positioned := c.positioned
+	if sentInRequest {
+		positioned = nil
+	}
+	for {
+		select {
+		case <-stop:
+			return
+		case <-positioned:
+			positioned = nil
+		case <-ticker.C:
+		}
+		gga, ok := c.gga()
+		if !ok {
+			continue
+		}
+		_ = conn.SetWriteDeadline(time.Now().Add(c.cfg.GGAInterval))
+		if _, err := conn.Write(gga); err != nil {
+			return
+		}
+	}
+}
+
+// gga returns a GGA sentence of the latest position, if any.
+func (c *Client) gga() ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.position.hasPOS {
+		return nil, false
+	}
+	return c.position.appendGGA(nil, time.Now()), true
+}
+
+// response is the response of a caster to a request.
+type response struct {
+	// sourcetable is true when the body is a sourcetable rather than a correction stream.
+	sourcetable bool
+	body        io.Reader
+}
+
+// connect to the caster and request the provided path.
+func (c *Client) connect(ctx context.Context, path string, gga []byte) (net.Conn, response, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, c.cfg.DialTimeout)
+	defer cancel()
+	conn, err := c.cfg.Dial(dialCtx, c.cfg.Address)
+	if err != nil {
+		return nil, response{}, err
+	}
+	if err := conn.SetDeadline(time.Now().Add(c.cfg.DialTimeout)); err != nil {
+		_ = conn.Close()
+		return nil, response{}, err
+	}
+	if _, err := io.WriteString(conn, c.request(path, gga)); err != nil {
+		_ = conn.Close()
+		return nil, response{}, err
+	}
+	resp, err := readResponse(bufio.NewReader(conn))
+	if err != nil {
+		_ = conn.Close()
+		return nil, response{}, err
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		_ = conn.Close()
+		return nil, response{}, err
+	}
+	return conn, resp, nil
+}
+
+// request returns the request for the provided path.
+func (c *Client) request(path string, gga []byte) string {
+	var b strings.Builder
+	switch c.cfg.Version {
+	case Version1:
+		_, _ = fmt.Fprintf(&b, "GET %s HTTP/1.0\r\n", path)
+	default:
+		_, _ = fmt.Fprintf(&b, "GET %s HTTP/1.1\r\n", path)
+		_, _ = fmt.Fprintf(&b, "Host: %s\r\n", c.cfg.Address)
+		b.WriteString("Ntrip-Version: Ntrip/2.0\r\n")
+	}
+	_, _ = fmt.Fprintf(&b, "User-Agent: %s\r\n", c.cfg.UserAgent)
+	if c.cfg.Username != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(c.cfg.Username + ":" + c.cfg.Password))
+		_, _ = fmt.Fprintf(&b, "Authorization: Basic %s\r\n", credentials)
+	}
+	if c.cfg.Version != Version1 {
+		if len(gga) > 0 {
+			_, _ = fmt.Fprintf(&b, "Ntrip-GGA: %s\r\n", strings.TrimRight(string(gga), "\r\n"))
+		}
+		b.WriteString("Connection: close\r\n")
+	}
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// readResponse reads the status line and headers of a response of an NTRIP version 1 or version 2 caster.
+func readResponse(br *bufio.Reader) (response, error) {
+	tp := textproto.NewReader(br)
+	status, err := tp.ReadLine()
+	if err != nil {
+		return response{}, fmt.Errorf("read response: %w", err)
+	}
+	switch {
+	case strings.HasPrefix(status, "ICY 200"):
+		return response{body: br}, nil
+	case strings.HasPrefix(status, "SOURCETABLE 200"):
+		if _, err := tp.ReadMIMEHeader(); err != nil {
+			return response{}, fmt.Errorf("read response: %w", err)
+		}
+		return response{sourcetable: true, body: br}, nil
+	case strings.HasPrefix(status, "HTTP/"):
+		values := strings.SplitN(status, " ", 3)
+		if len(values) < 2 {
+			return response{}, fmt.Errorf("read response: malformed status %q", status)
+		}
+		code, err := strconv.Atoi(values[1])
+		if err != nil {
+			return response{}, fmt.Errorf("read response: malformed status %q", status)
+		}
+		header, err := tp.ReadMIMEHeader()
+		if err != nil {
+			return response{}, fmt.Errorf("read response: %w", err)
+		}
+		switch code {
+		case 200:
+		case 401:
+			return response{}, fmt.Errorf("read response: %s: %w", status, ErrUnauthorized)
+		case 404:
+			return response{}, fmt.Errorf("read response: %s: %w", status, ErrMountpointNotFound)
+		default:
+			return response{}, fmt.Errorf("read response: unexpected status %q", status)
+		}
+		resp := response{
+			sourcetable: header.Get("Content-Type") == contentTypeSourcetable,
+			body:        br,
+		}
+		if strings.EqualFold(header.Get("Transfer-Encoding"), "chunked") {
+			resp.body = httputil.NewChunkedReader(br)
+		}
+		return resp, nil
+	default:
+		return response{}, fmt.Errorf("read response: unexpected status %q", status)
+	}
+}
+
+func (c *Client) sleepBackoff(ctx context.Context) {
+	if c.backoff == 0 {
+		c.backoff = c.cfg.MinBackoff
+	} else {
+		c.backoff *= 2
+		if c.backoff > c.cfg.MaxBackoff {
+			c.backoff = c.cfg.MaxBackoff
+		}
+	}
+	timer := time.NewTimer(c.backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func (c *Client) setState(state reach.ConnectionState, err error) {
+	if c.state == state {
+		return
+	}
+	c.state = state
+	if c.cfg.OnStateChange != nil {
+		c.cfg.OnStateChange(state, err)
+	}
+}
@@ -0,0 +1,439 @@
+package ntrip
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach"
+	"go.einride.tech/reach/erb"
+	"go.einride.tech/reach/nmea"
+	"gotest.tools/v3/assert"
+)
+
+// exampleStationARP is the example 1005 frame of the RTCM 3 standard.
+const exampleStationARP = "d300133ed7d30202980edeef34b4bd62ac0941986f33360b98"
+
+const testSourcetable = "CAS;caster.example.com;2101;Example;Example Inc;0;SWE;57.71;11.97;;0;\r\n" +
+	"NET;EXAMPLE;Example Inc;B;N;https://example.com;none;info@example.com;\r\n" +
+	"STR;YARD;Gothenburg;RTCM 3.3;1005(10),1077(1),1087(1);2;GPS+GLO;EXAMPLE;SWE;57.71;11.97;1;0;" +
+	"Emlid Reach RS2;none;B;N;9600;yard;base\r\n" +
+	"ENDSOURCETABLE\r\n"
+
+// testCaster is a stand-in NTRIP caster for testing.
+type testCaster struct {
+	t        *testing.T
+	lis      net.Listener
+	mu       sync.Mutex
+	requests []*http.Request
+	serve    func(t *testing.T, i int, r *http.Request, br *bufio.Reader, conn net.Conn)
+}
+
+func newTestCaster(
+	t *testing.T,
+	serve func(t *testing.T, i int, r *http.Request, br *bufio.Reader, conn net.Conn),
+) *testCaster {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.NilError(t, err)
+	c := &testCaster{t: t, lis: lis, serve: serve}
+	var wg sync.WaitGroup
+	t.Cleanup(func() {
+		_ = lis.Close()
+		wg.Wait()
+	})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer func() {
+					_ = conn.Close()
+				}()
+				br := bufio.NewReader(conn)
+				r, err := http.ReadRequest(br)
+				if err != nil {
+					return
+				}
+				c.mu.Lock()
+				c.requests = append(c.requests, r)
+				c.mu.Unlock()
+				c.serve(t, i, r, br, conn)
+			}(i)
+		}
+	}()
+	return c
+}
+
+func (c *testCaster) Address() string {
+	return c.lis.Addr().String()
+}
+
+func (c *testCaster) Requests() []*http.Request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*http.Request(nil), c.requests...)
+}
+
+// testWriter is a writer that cancels a context when an expected number of bytes has been written.
+type testWriter struct {
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	expected int
+	cancel   context.CancelFunc
+}
+
+func (w *testWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	n, err := w.buf.Write(p)
+	if w.buf.Len() >= w.expected {
+		w.cancel()
+	}
+	return n, err
+}
+
+func exampleFrame(t *testing.T) []byte {
+	t.Helper()
+	frame, err := hex.DecodeString(exampleStationARP)
+	assert.NilError(t, err)
+	return frame
+}
+
+func testScanner(t *testing.T) *erb.Scanner {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := erb.NewEncoder(&buf)
+	assert.NilError(t, enc.EncodeSTAT(erb.STAT{
+		TimeGPS: 113968400,
+		WeekGPS: 2059,
+		FixType: erb.FixTypeFloat,
+		HasFix:  true,
+		NumSVs:  14,
+	}))
+	assert.NilError(t, enc.EncodeDOPS(erb.DOPS{TimeGPS: 113968400, Horizontal: 0.8}))
+	assert.NilError(t, enc.EncodePOS(erb.POS{
+		TimeGPS:                    113968400,
+		LatitudeDegrees:            57.7089,
+		LongitudeDegrees:           -11.9746,
+		AltitudeEllipsoidMeters:    45.5,
+		AltitudeMeanSeaLevelMeters: 12.25,
+	}))
+	return erb.NewScanner(&buf)
+}
+
+func newTestClient(t *testing.T, cfg ClientConfig) *Client {
+	t.Helper()
+	client := NewClient(cfg)
+	sc := testScanner(t)
+	for sc.Scan() {
+		client.Update(sc)
+	}
+	assert.NilError(t, sc.Err())
+	return client
+}
+
+func TestClient_Run_Version2Chunked(t *testing.T) {
+	frame := exampleFrame(t)
+	caster := newTestCaster(t, func(t *testing.T, _ int, r *http.Request, _ *bufio.Reader, conn net.Conn) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "rover" || password != "secret" {
+			_, _ = io.WriteString(conn, "HTTP/1.1 401 Unauthorized\r\n\r\n")
+			return
+		}
+		_, _ = io.WriteString(
+			conn, "HTTP/1.1 200 OK\r\nContent-Type: gnss/data\r\nTransfer-Encoding: chunked\r\n\r\n",
+		)
+		cw := httputil.NewChunkedWriter(conn)
+		_, _ = cw.Write(frame[:10])
+		_, _ = cw.Write(frame[10:])
+		_, _ = io.Copy(ioutil.Discard, conn)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	w := &testWriter{expected: len(frame), cancel: cancel}
+	client := newTestClient(t, ClientConfig{
+		Address:    caster.Address(),
+		Mountpoint: "YARD",
+		Username:   "rover",
+		Password:   "secret",
+	})
+	assert.Assert(t, errors.Is(client.Run(ctx, w), context.Canceled))
+	assert.DeepEqual(t, frame, w.buf.Bytes())
+	requests := caster.Requests()
+	assert.Equal(t, 1, len(requests))
+	assert.Equal(t, "/YARD", requests[0].URL.Path)
+	assert.Equal(t, "Ntrip/2.0", requests[0].Header.Get("Ntrip-Version"))
+	assert.Equal(t, "NTRIP go.einride.tech/reach", requests[0].Header.Get("User-Agent"))
+	gga := parseGGA(t, requests[0].Header.Get("Ntrip-GGA")+"\r\n")
+	assert.Equal(t, nmea.FixQualityFloat, gga.FixQuality)
+}
+
+func TestClient_Run_Version1(t *testing.T) {
+	frame := exampleFrame(t)
+	ggaCh := make(chan string, 1)
+	caster := newTestCaster(t, func(t *testing.T, _ int, r *http.Request, br *bufio.Reader, conn net.Conn) {
+		_, _ = io.WriteString(conn, "ICY 200 OK\r\n")
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return
+		}
+		ggaCh <- line
+		_, _ = conn.Write(frame)
+		_, _ = io.Copy(ioutil.Discard, conn)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	w := &testWriter{expected: len(frame), cancel: cancel}
+	client := newTestClient(t, ClientConfig{
+		Address:    caster.Address(),
+		Mountpoint: "YARD",
+		Version:    Version1,
+	})
+	assert.Assert(t, errors.Is(client.Run(ctx, w), context.Canceled))
+	assert.DeepEqual(t, frame, w.buf.Bytes())
+	requests := caster.Requests()
+	assert.Equal(t, 1, len(requests))
+	assert.Equal(t, "HTTP/1.0", requests[0].Proto)
+	assert.Equal(t, "", requests[0].Header.Get("Ntrip-Version"))
+	gga := parseGGA(t, <-ggaCh)
+	assert.Equal(t, uint8(14), gga.NumSVs)
+}
+
+func TestClient_Run_Reconnect(t *testing.T) {
+	frame := exampleFrame(t)
+	caster := newTestCaster(t, func(t *testing.T, i int, r *http.Request, _ *bufio.Reader, conn net.Conn) {
+		_, _ = io.WriteString(conn, "HTTP/1.1 200 OK\r\nContent-Type: gnss/data\r\n\r\n")
+		// the first connection is closed after half a frame
+		if i == 0 {
+			_, _ = conn.Write(frame[:10])
+			return
+		}
+		_, _ = conn.Write(frame[10:])
+		_, _ = io.Copy(ioutil.Discard, conn)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	w := &testWriter{expected: len(frame), cancel: cancel}
+	var states []reach.ConnectionState
+	client := NewClient(ClientConfig{
+		Address:    caster.Address(),
+		Mountpoint: "YARD",
+		MinBackoff: time.Millisecond,
+		OnStateChange: func(state reach.ConnectionState, _ error) {
+			states = append(states, state)
+		},
+	})
+	assert.Assert(t, errors.Is(client.Run(ctx, w), context.Canceled))
+	assert.DeepEqual(t, frame, w.buf.Bytes())
+	assert.DeepEqual(t, []reach.ConnectionState{
+		reach.ConnectionStateConnecting,
+		reach.ConnectionStateConnected,
+		reach.ConnectionStateDisconnected,
+		reach.ConnectionStateConnecting,
+		reach.ConnectionStateConnected,
+		reach.ConnectionStateDisconnected,
+	}, states)
+}
+
+func TestClient_Run_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		response string
+		expected error
+	}{
+		{
+			name:     "unauthorized",
+			response: "HTTP/1.1 401 Unauthorized\r\nWWW-Authenticate: Basic realm=\"/YARD\"\r\n\r\n",
+			expected: ErrUnauthorized,
+		},
+		{
+			name:     "version 1 sourcetable",
+			response: "SOURCETABLE 200 OK\r\nContent-Type: text/plain\r\n\r\n" + testSourcetable,
+			expected: ErrMountpointNotFound,
+		},
+		{
+			name:     "not found",
+			response: "HTTP/1.1 404 Not Found\r\n\r\n",
+			expected: ErrMountpointNotFound,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			caster := newTestCaster(t, func(t *testing.T, _ int, _ *http.Request, _ *bufio.Reader, conn net.Conn) {
+				_, _ = io.WriteString(conn, tt.response)
+			})
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			var actual error
+			client := NewClient(ClientConfig{
+				Address:    caster.Address(),
+				Mountpoint: "YARD",
+				OnStateChange: func(state reach.ConnectionState, err error) {
+					if state == reach.ConnectionStateDisconnected {
+						actual = err
+						cancel()
+					}
+				},
+			})
+			assert.Assert(t, errors.Is(client.Run(ctx, ioutil.Discard), context.Canceled))
+			assert.Assert(t, errors.Is(actual, tt.expected), actual)
+		})
+	}
+}
+
+func TestClient_Run_ForwardError(t *testing.T) {
+	frame := exampleFrame(t)
+	caster := newTestCaster(t, func(t *testing.T, _ int, _ *http.Request, _ *bufio.Reader, conn net.Conn) {
+		_, _ = io.WriteString(conn, "ICY 200 OK\r\n")
+		_, _ = conn.Write(frame)
+		_, _ = io.Copy(ioutil.Discard, conn)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := NewClient(ClientConfig{Address: caster.Address(), Mountpoint: "YARD", Version: Version1})
+	err := client.Run(ctx, errWriter{})
+	assert.Assert(t, errors.Is(err, io.ErrClosedPipe), err)
+	assert.Assert(t, ctx.Err() == nil)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func TestClient_FetchSourcetable(t *testing.T) {
+	expected := Sourcetable{
+		Casters: []CasterEntry{
+			{
+				Host:             "caster.example.com",
+				Port:             2101,
+				Identifier:       "Example",
+				Operator:         "Example Inc",
+				Country:          "SWE",
+				LatitudeDegrees:  57.71,
+				LongitudeDegrees: 11.97,
+			},
+		},
+		Networks: []NetworkEntry{
+			{
+				Identifier:      "EXAMPLE",
+				Operator:        "Example Inc",
+				Authentication:  "B",
+				WebNetwork:      "https://example.com",
+				WebStream:       "none",
+				WebRegistration: "info@example.com",
+			},
+		},
+		Streams: []StreamEntry{
+			{
+				Mountpoint:       "YARD",
+				Identifier:       "Gothenburg",
+				Format:           "RTCM 3.3",
+				FormatDetails:    "1005(10),1077(1),1087(1)",
+				Carrier:          2,
+				NavSystem:        "GPS+GLO",
+				Network:          "EXAMPLE",
+				Country:          "SWE",
+				LatitudeDegrees:  57.71,
+				LongitudeDegrees: 11.97,
+				NMEA:             true,
+				Generator:        "Emlid Reach RS2",
+				Compression:      "none",
+				Authentication:   "B",
+				Bitrate:          9600,
+				Misc:             "yard;base",
+			},
+		},
+	}
+	for _, tt := range []struct {
+		version  Version
+		response string
+	}{
+		{
+			version:  Version1,
+			response: "SOURCETABLE 200 OK\r\nServer: Test\r\nContent-Type: text/plain\r\n\r\n" + testSourcetable,
+		},
+		{
+			version: Version2,
+			response: fmt.Sprintf(
+				"HTTP/1.1 200 OK\r\nContent-Type: gnss/sourcetable\r\nContent-Length: %d\r\n\r\n%s",
+				len(testSourcetable),
+				testSourcetable,
+			),
+		},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("version %d", tt.version), func(t *testing.T) {
+			caster := newTestCaster(t, func(t *testing.T, _ int, _ *http.Request, _ *bufio.Reader, conn net.Conn) {
+				_, _ = io.WriteString(conn, tt.response)
+			})
+			client := NewClient(ClientConfig{Address: caster.Address(), Version: tt.version})
+			actual, err := client.FetchSourcetable(context.Background())
+			assert.NilError(t, err)
+			assert.DeepEqual(t, expected, actual)
+			stream, ok := actual.Stream("YARD")
+			assert.Assert(t, ok)
+			assert.Equal(t, "Gothenburg", stream.Identifier)
+			assert.Equal(t, "/", caster.Requests()[0].URL.Path)
+		})
+	}
+}
+
+func TestParseSourcetable_Error(t *testing.T) {
+	_, err := ParseSourcetable(strings.NewReader("STR;YARD;Gothenburg;RTCM 3.3;;two\r\n"))
+	assert.Error(t, err, `parse sourcetable: STR field 5: strconv.Atoi: parsing "two": invalid syntax`)
+}
+
+func TestPosition_AppendGGA(t *testing.T) {
+	var p position
+	sc := testScanner(t)
+	for sc.Scan() {
+		p.update(sc)
+	}
+	assert.NilError(t, sc.Err())
+	sentence := string(p.appendGGA(nil, time.Time{}))
+	assert.Equal(t, "$GPGGA,073910.40,5742.5340000,N,01158.4760000,W,5,14,0.8,12.250,M,33.250,M,,*42\r\n", sentence)
+	gga := parseGGA(t, sentence)
+	assert.Equal(t, 7*time.Hour+39*time.Minute+10*time.Second+400*time.Millisecond, gga.TimeUTC)
+	assert.Assert(t, gga.LatitudeDegrees > 57.70889 && gga.LatitudeDegrees < 57.70891)
+	assert.Assert(t, gga.LongitudeDegrees > -11.97461 && gga.LongitudeDegrees < -11.97459)
+	assert.Equal(t, nmea.FixQualityFloat, gga.FixQuality)
+	assert.Equal(t, 0.8, gga.HDOP)
+	assert.Equal(t, 33.25, gga.GeoidSeparationMeters)
+}
+
+func TestPosition_AppendGGA_NoSTAT(t *testing.T) {
+	var p position
+	p.pos, p.hasPOS = erb.POS{TimeGPS: 113950400, LatitudeDegrees: 57.7, LongitudeDegrees: 11.9}, true
+	gga := parseGGA(t, string(p.appendGGA(nil, time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC))))
+	assert.Equal(t, nmea.FixQualityInvalid, gga.FixQuality)
+	assert.Equal(t, uint8(0), gga.NumSVs)
+}
+
+func parseGGA(t *testing.T, sentence string) nmea.GGA {
+	t.Helper()
+	sc := nmea.NewScanner(strings.NewReader(sentence))
+	assert.Assert(t, sc.Scan(), sc.Err())
+	assert.Equal(t, nmea.IDGGA, sc.ID())
+	return sc.GGA()
+}
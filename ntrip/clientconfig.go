@@ -0,0 +1,78 @@
+package ntrip
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"go.einride.tech/reach"
+)
+
+// ClientConfig configures a Client.
+type ClientConfig struct {
+	// Address of the NTRIP caster, on the form host:port.
+	Address string
+	// Mountpoint of the correction stream.
+	Mountpoint string
+	// Username for basic authentication with the caster. Authentication is disabled when empty.
+	Username string
+	// Password for basic authentication with the caster.
+	Password string
+	// Version of the NTRIP protocol. Defaults to Version2.
+	Version Version
+	// UserAgent of the client. Defaults to "NTRIP go.einride.tech/reach".
+	UserAgent string
+	// GGAInterval is the interval between uploads of GGA sentences to the caster. Defaults to 10s.
+	//
+	// GGA sentences are only uploaded after a POS message has been received, and report an invalid fix until a STAT
+	// message has been received.
+	GGAInterval time.Duration
+	// DialTimeout is the timeout for establishing a connection. Defaults to 5s.
+	DialTimeout time.Duration
+	// ReadTimeout is the maximum time to wait for corrections before the stream is considered stalled and the
+	// connection is re-established. Defaults to 10s.
+	ReadTimeout time.Duration
+	// MinBackoff is the initial time to wait before reconnecting. Defaults to 1s.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum time to wait before reconnecting. Defaults to 30s.
+	MaxBackoff time.Duration
+	// OnStateChange is an optional callback invoked when the connection state changes.
+	//
+	// When the state changes to disconnected, err is the error that caused the disconnect.
+	OnStateChange func(state reach.ConnectionState, err error)
+	// Dial is an optional function for dialing the caster. Defaults to dialing TCP.
+	Dial func(ctx context.Context, address string) (net.Conn, error)
+}
+
+func (c *ClientConfig) setDefaults() {
+	if c.Version == 0 {
+		c.Version = Version2
+	}
+	if c.UserAgent == "" {
+		c.UserAgent = "NTRIP go.einride.tech/reach"
+	}
+	if c.GGAInterval <= 0 {
+		c.GGAInterval = 10 * time.Second
+	}
+	if c.DialTimeout <= 0 {
+		c.DialTimeout = 5 * time.Second
+	}
+	if c.ReadTimeout <= 0 {
+		c.ReadTimeout = 10 * time.Second
+	}
+	if c.MinBackoff <= 0 {
+		c.MinBackoff = time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 30 * time.Second
+	}
+	if c.MaxBackoff < c.MinBackoff {
+		c.MaxBackoff = c.MinBackoff
+	}
+	if c.Dial == nil {
+		c.Dial = func(ctx context.Context, address string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "tcp", address)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Package ntrip provides an NTRIP client, for feeding corrections from an NTRIP caster to a Reach, and an NTRIP
+// caster, for sharing the corrections of a Reach base station with rovers.
+//
+// Both NTRIP version 1 and version 2 are supported, including chunked transfer encoding of version 2 streams and
+// upload of GGA sentences built from the ERB position of the Reach, as required by casters of virtual reference
+// station networks.
+//
+// Implementation is based on RTCM Standard 10410.1 (NTRIP version 2.0) and the NTRIP version 1.0 specification.
+package ntrip
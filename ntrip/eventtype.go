@@ -0,0 +1,17 @@
+package ntrip
+
+// EventType represents the type of a caster event.
+type EventType uint8
+
+//go:generate stringer -type EventType -trimprefix EventType
+
+const (
+	// EventTypeClientConnected is the event when a client is connected to a stream.
+	EventTypeClientConnected EventType = iota
+	// EventTypeClientDisconnected is the event when a client is disconnected from a stream.
+	EventTypeClientDisconnected
+	// EventTypeClientUnauthorized is the event when a client is rejected because of invalid credentials.
+	EventTypeClientUnauthorized
+	// EventTypeClientDropped is the event when a client is dropped for not keeping up with its stream.
+	EventTypeClientDropped
+)
@@ -0,0 +1,73 @@
+package ntrip
+
+import (
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"go.einride.tech/reach/nmea"
+)
+
+// position is the latest position of the Reach, for uploading as a GGA sentence.
+type position struct {
+	pos     erb.POS
+	hasPOS  bool
+	stat    erb.STAT
+	hasSTAT bool
+	hdop    float64
+	hasDOPS bool
+}
+
+// update the position with the current message of the scanner.
+func (p *position) update(sc *erb.Scanner) {
+	switch sc.ID() {
+	case erb.IDPOS:
+		p.pos, p.hasPOS = sc.POS(), true
+	case erb.IDSTAT:
+		p.stat, p.hasSTAT = sc.STAT(), true
+	case erb.IDDOPS:
+		p.hdop, p.hasDOPS = sc.DOPS().Horizontal, true
+	}
+}
+
+// appendGGA appends a GGA sentence of the position to b, with the provided time as fallback for the GPS week.
+//
+// The fix is reported as invalid until a STAT message has been received.
+func (p *position) appendGGA(b []byte, now time.Time) []byte {
+	weekGPS, timeGPS := erb.WeekAndTimeGPS(now)
+	if p.hasSTAT {
+		weekGPS, timeGPS = p.stat.WeekGPS, p.stat.TimeGPS
+	}
+	t := erb.UTCTime(erb.ResolveWeekGPS(weekGPS, timeGPS, p.pos.TimeGPS), p.pos.TimeGPS)
+	gga := nmea.GGA{
+		TimeUTC:                    t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)),
+		LatitudeDegrees:            p.pos.LatitudeDegrees,
+		LongitudeDegrees:           p.pos.LongitudeDegrees,
+		FixQuality:                 nmea.FixQualityInvalid,
+		AltitudeMeanSeaLevelMeters: p.pos.AltitudeMeanSeaLevelMeters,
+		GeoidSeparationMeters:      p.pos.AltitudeEllipsoidMeters - p.pos.AltitudeMeanSeaLevelMeters,
+	}
+	if p.hasSTAT {
+		gga.FixQuality, gga.NumSVs = fixQualityOf(p.stat), p.stat.NumSVs
+	}
+	if p.hasDOPS {
+		gga.HDOP = p.hdop
+	}
+	return gga.AppendSentence(b, "GP")
+}
+
+// fixQualityOf returns the GGA fix quality of a STAT message.
+func fixQualityOf(stat erb.STAT) nmea.FixQuality {
+	if !stat.HasFix {
+		return nmea.FixQualityInvalid
+	}
+	switch stat.FixType {
+	case erb.FixTypeRTK:
+		return nmea.FixQualityRTK
+	case erb.FixTypeFloat:
+		return nmea.FixQualityFloat
+	case erb.FixTypeSingle:
+		return nmea.FixQualityGPS
+	default:
+		return nmea.FixQualityInvalid
+	}
+}
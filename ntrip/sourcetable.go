@@ -0,0 +1,354 @@
+package ntrip
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// structure of sourcetable.
+const (
+	recordTypeStream     = "STR"
+	recordTypeCaster     = "CAS"
+	recordTypeNetwork    = "NET"
+	endOfSourcetable     = "ENDSOURCETABLE"
+	sourcetableSeparator = ";"
+)
+
+// Sourcetable is the sourcetable of an NTRIP caster.
+type Sourcetable struct {
+	// Casters are the CAS records of the sourcetable.
+	Casters []CasterEntry
+	// Networks are the NET records of the sourcetable.
+	Networks []NetworkEntry
+	// Streams are the STR records of the sourcetable.
+	Streams []StreamEntry
+}
+
+// Stream returns the stream entry of the provided mountpoint.
+func (s *Sourcetable) Stream(mountpoint string) (StreamEntry, bool) {
+	for _, stream := range s.Streams {
+		if stream.Mountpoint == mountpoint {
+			return stream, true
+		}
+	}
+	return StreamEntry{}, false
+}
+
+// MarshalText encodes the sourcetable in the NTRIP sourcetable format, terminated by an ENDSOURCETABLE line.
+func (s *Sourcetable) MarshalText() ([]byte, error) {
+	var b bytes.Buffer
+	for _, caster := range s.Casters {
+		_, _ = fmt.Fprintf(
+			&b,
+			"CAS;%s;%d;%s;%s;%s;%s;%.2f;%.2f;%s;%d;%s\r\n",
+			caster.Host,
+			caster.Port,
+			caster.Identifier,
+			caster.Operator,
+			formatBool(caster.NMEA),
+			caster.Country,
+			caster.LatitudeDegrees,
+			caster.LongitudeDegrees,
+			caster.FallbackHost,
+			caster.FallbackPort,
+			caster.Misc,
+		)
+	}
+	for _, network := range s.Networks {
+		_, _ = fmt.Fprintf(
+			&b,
+			"NET;%s;%s;%s;%s;%s;%s;%s;%s\r\n",
+			network.Identifier,
+			network.Operator,
+			network.Authentication,
+			formatFee(network.Fee),
+			network.WebNetwork,
+			network.WebStream,
+			network.WebRegistration,
+			network.Misc,
+		)
+	}
+	for _, stream := range s.Streams {
+		_, _ = fmt.Fprintf(
+			&b,
+			"STR;%s;%s;%s;%s;%d;%s;%s;%s;%.2f;%.2f;%s;%s;%s;%s;%s;%s;%d;%s\r\n",
+			stream.Mountpoint,
+			stream.Identifier,
+			stream.Format,
+			stream.FormatDetails,
+			stream.Carrier,
+			stream.NavSystem,
+			stream.Network,
+			stream.Country,
+			stream.LatitudeDegrees,
+			stream.LongitudeDegrees,
+			formatBool(stream.NMEA),
+			formatBool(stream.NetworkSolution),
+			stream.Generator,
+			stream.Compression,
+			stream.Authentication,
+			formatFee(stream.Fee),
+			stream.Bitrate,
+			stream.Misc,
+		)
+	}
+	b.WriteString(endOfSourcetable + "\r\n")
+	return b.Bytes(), nil
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func formatFee(fee bool) string {
+	if fee {
+		return "Y"
+	}
+	return "N"
+}
+
+// StreamEntry is a STR record of a sourcetable, which describes a correction stream.
+type StreamEntry struct {
+	// Mountpoint of the stream.
+	Mountpoint string
+	// Identifier is the source identifier, such as the name of a nearby city.
+	Identifier string
+	// Format of the stream, such as "RTCM 3.3".
+	Format string
+	// FormatDetails are the message types and update rates of the stream, such as "1005(10),1077(1)".
+	FormatDetails string
+	// Carrier is the carrier phase information of the stream: 0 for none, 1 for L1 and 2 for L1 and L2.
+	Carrier int
+	// NavSystem are the navigation systems of the stream, such as "GPS+GLO+GAL+BDS".
+	NavSystem string
+	// Network of the stream.
+	Network string
+	// Country is the ISO 3166 country code of the stream.
+	Country string
+	// LatitudeDegrees is the approximate latitude of the stream (degrees).
+	LatitudeDegrees float64
+	// LongitudeDegrees is the approximate longitude of the stream (degrees).
+	LongitudeDegrees float64
+	// NMEA is true when the caster requires GGA sentences from clients of the stream.
+	NMEA bool
+	// NetworkSolution is true when the stream is generated from a network of reference stations, rather than a single one.
+	NetworkSolution bool
+	// Generator is the hard- or software that generates the stream.
+	Generator string
+	// Compression is the compression or encryption algorithm of the stream, if any.
+	Compression string
+	// Authentication is the access protection of the stream: "N" for none, "B" for basic and "D" for digest.
+	Authentication string
+	// Fee is true when a fee is charged for the stream.
+	Fee bool
+	// Bitrate of the stream in bits per second.
+	Bitrate int
+	// Misc is miscellaneous information.
+	Misc string
+}
+
+// CasterEntry is a CAS record of a sourcetable, which describes a caster.
+type CasterEntry struct {
+	// Host of the caster.
+	Host string
+	// Port of the caster.
+	Port int
+	// Identifier of the caster.
+	Identifier string
+	// Operator of the caster.
+	Operator string
+	// NMEA is true when the caster accepts GGA sentences from clients.
+	NMEA bool
+	// Country is the ISO 3166 country code of the caster.
+	Country string
+	// LatitudeDegrees is the approximate latitude of the caster (degrees).
+	LatitudeDegrees float64
+	// LongitudeDegrees is the approximate longitude of the caster (degrees).
+	LongitudeDegrees float64
+	// FallbackHost is the host of a fallback caster.
+	FallbackHost string
+	// FallbackPort is the port of a fallback caster.
+	FallbackPort int
+	// Misc is miscellaneous information.
+	Misc string
+}
+
+// NetworkEntry is a NET record of a sourcetable, which describes a network of streams.
+type NetworkEntry struct {
+	// Identifier of the network.
+	Identifier string
+	// Operator of the network.
+	Operator string
+	// Authentication is the access protection of the network: "N" for none, "B" for basic and "D" for digest.
+	Authentication string
+	// Fee is true when a fee is charged for the streams of the network.
+	Fee bool
+	// WebNetwork is the web address of information about the network.
+	WebNetwork string
+	// WebStream is the web address of information about the streams of the network.
+	WebStream string
+	// WebRegistration is the web address or email for registration with the network.
+	WebRegistration string
+	// Misc is miscellaneous information.
+	Misc string
+}
+
+// ParseSourcetable parses a sourcetable from r, until the ENDSOURCETABLE line or the end of r.
+//
+// Records of unknown types are ignored.
+func ParseSourcetable(r io.Reader) (Sourcetable, error) {
+	var result Sourcetable
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if line == endOfSourcetable {
+			return result, nil
+		}
+		values := strings.Split(line, sourcetableSeparator)
+		f := fields{recordType: values[0], values: values}
+		switch values[0] {
+		case recordTypeStream:
+			result.Streams = append(result.Streams, f.stream())
+		case recordTypeCaster:
+			result.Casters = append(result.Casters, f.caster())
+		case recordTypeNetwork:
+			result.Networks = append(result.Networks, f.network())
+		default:
+			continue
+		}
+		if f.err != nil {
+			return Sourcetable{}, fmt.Errorf("parse sourcetable: %w", f.err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return Sourcetable{}, fmt.Errorf("parse sourcetable: %w", err)
+	}
+	return result, nil
+}
+
+// fields is a parser for the semicolon-separated fields of a sourcetable record.
+//
+// Missing and empty fields are parsed as zero values, and the first parse error is recorded in err.
+type fields struct {
+	recordType string
+	values     []string
+	err        error
+}
+
+func (f *fields) setErr(i int, err error) {
+	if f.err == nil {
+		f.err = fmt.Errorf("%s field %d: %w", f.recordType, i, err)
+	}
+}
+
+func (f *fields) string(i int) string {
+	if i >= len(f.values) {
+		return ""
+	}
+	return f.values[i]
+}
+
+// rest returns field i and all following fields, since the last field of a record may contain separators.
+func (f *fields) rest(i int) string {
+	if i >= len(f.values) {
+		return ""
+	}
+	return strings.Join(f.values[i:], sourcetableSeparator)
+}
+
+func (f *fields) int(i int) int {
+	s := f.string(i)
+	if s == "" {
+		return 0
+	}
+	result, err := strconv.Atoi(s)
+	if err != nil {
+		f.setErr(i, err)
+		return 0
+	}
+	return result
+}
+
+func (f *fields) float(i int) float64 {
+	s := f.string(i)
+	if s == "" {
+		return 0
+	}
+	result, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		f.setErr(i, err)
+		return 0
+	}
+	return result
+}
+
+func (f *fields) bool(i int) bool {
+	switch s := f.string(i); s {
+	case "", "0", "N":
+		return false
+	case "1", "Y":
+		return true
+	default:
+		f.setErr(i, fmt.Errorf("invalid flag %q", s))
+		return false
+	}
+}
+
+func (f *fields) stream() StreamEntry {
+	return StreamEntry{
+		Mountpoint:       f.string(1),
+		Identifier:       f.string(2),
+		Format:           f.string(3),
+		FormatDetails:    f.string(4),
+		Carrier:          f.int(5),
+		NavSystem:        f.string(6),
+		Network:          f.string(7),
+		Country:          f.string(8),
+		LatitudeDegrees:  f.float(9),
+		LongitudeDegrees: f.float(10),
+		NMEA:             f.bool(11),
+		NetworkSolution:  f.bool(12),
+		Generator:        f.string(13),
+		Compression:      f.string(14),
+		Authentication:   f.string(15),
+		Fee:              f.bool(16),
+		Bitrate:          f.int(17),
+		Misc:             f.rest(18),
+	}
+}
+
+func (f *fields) caster() CasterEntry {
+	return CasterEntry{
+		Host:             f.string(1),
+		Port:             f.int(2),
+		Identifier:       f.string(3),
+		Operator:         f.string(4),
+		NMEA:             f.bool(5),
+		Country:          f.string(6),
+		LatitudeDegrees:  f.float(7),
+		LongitudeDegrees: f.float(8),
+		FallbackHost:     f.string(9),
+		FallbackPort:     f.int(10),
+		Misc:             f.rest(11),
+	}
+}
+
+func (f *fields) network() NetworkEntry {
+	return NetworkEntry{
+		Identifier:      f.string(1),
+		Operator:        f.string(2),
+		Authentication:  f.string(3),
+		Fee:             f.bool(4),
+		WebNetwork:      f.string(5),
+		WebStream:       f.string(6),
+		WebRegistration: f.string(7),
+		Misc:            f.rest(8),
+	}
+}
@@ -0,0 +1,11 @@
+package ntrip
+
+// Version is a version of the NTRIP protocol.
+type Version uint8
+
+const (
+	// Version1 is NTRIP version 1.0, which uses a custom "ICY 200 OK" response to stream requests.
+	Version1 Version = 1
+	// Version2 is NTRIP version 2.0, which is HTTP/1.1 compliant.
+	Version2 Version = 2
+)
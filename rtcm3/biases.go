@@ -0,0 +1,120 @@
+package rtcm3
+
+import (
+	"fmt"
+	"math"
+)
+
+// structure of GLONASS biases message, in bits.
+const (
+	lengthOfGLONASSBiasesHeaderBits = 32
+	lengthOfGLONASSBiasesReserved   = 3
+	lengthOfGLONASSBiasesMask       = 4
+	lengthOfGLONASSBias             = 16
+)
+
+// scaleOfGLONASSBias is the resolution of the GLONASS code-phase biases (m).
+const scaleOfGLONASSBias = 0.02
+
+// GLONASSBiases message contains the GLONASS L1 and L2 code-phase biases of a reference station.
+type GLONASSBiases struct {
+	// ReferenceStationID is the ID of the reference station.
+	ReferenceStationID uint16
+	// Aligned is true when the code and phase observations of the station are aligned, and the biases are zero.
+	Aligned bool
+	// L1CAMeters is the L1 C/A code-phase bias (m).
+	L1CAMeters float64
+	// HasL1CA is true when the L1 C/A code-phase bias is included.
+	HasL1CA bool
+	// L1PMeters is the L1 P code-phase bias (m).
+	L1PMeters float64
+	// HasL1P is true when the L1 P code-phase bias is included.
+	HasL1P bool
+	// L2CAMeters is the L2 C/A code-phase bias (m).
+	L2CAMeters float64
+	// HasL2CA is true when the L2 C/A code-phase bias is included.
+	HasL2CA bool
+	// L2PMeters is the L2 P code-phase bias (m).
+	L2PMeters float64
+	// HasL2P is true when the L2 P code-phase bias is included.
+	HasL2P bool
+}
+
+// glonassBias refers to a bias field of a GLONASSBiases message.
+type glonassBias struct {
+	has    *bool
+	meters *float64
+}
+
+// biases returns the bias fields of the message, in the order of the signals mask.
+func (g *GLONASSBiases) biases() [lengthOfGLONASSBiasesMask]glonassBias {
+	return [lengthOfGLONASSBiasesMask]glonassBias{
+		{has: &g.HasL1CA, meters: &g.L1CAMeters},
+		{has: &g.HasL1P, meters: &g.L1PMeters},
+		{has: &g.HasL2CA, meters: &g.L2CAMeters},
+		{has: &g.HasL2P, meters: &g.L2PMeters},
+	}
+}
+
+func (g *GLONASSBiases) unmarshalPayload(b []byte) {
+	r := bitReader{b: b}
+	r.skip(lengthOfMessageType)
+	g.ReferenceStationID = uint16(r.uint(lengthOfReferenceStationID))
+	g.Aligned = r.bool()
+	r.skip(lengthOfGLONASSBiasesReserved)
+	mask := r.uint(lengthOfGLONASSBiasesMask)
+	for i, bias := range g.biases() {
+		*bias.has = mask&(1<<uint(lengthOfGLONASSBiasesMask-1-i)) != 0
+		*bias.meters = 0
+		if *bias.has {
+			*bias.meters = scaleOfGLONASSBias * float64(r.int(lengthOfGLONASSBias))
+		}
+	}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the payload of the message.
+func (g *GLONASSBiases) MarshalBinary() ([]byte, error) {
+	var w bitWriter
+	w.uint(lengthOfMessageType, uint64(MessageTypeGLONASSBiases))
+	w.uint(lengthOfReferenceStationID, uint64(g.ReferenceStationID))
+	w.bool(g.Aligned)
+	w.uint(lengthOfGLONASSBiasesReserved, 0)
+	biases := g.biases()
+	for _, bias := range biases {
+		w.bool(*bias.has)
+	}
+	for _, bias := range biases {
+		if *bias.has {
+			w.int(lengthOfGLONASSBias, int64(math.Round(*bias.meters/scaleOfGLONASSBias)))
+		}
+	}
+	return w.bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and parses the payload of the message.
+func (g *GLONASSBiases) UnmarshalBinary(b []byte) error {
+	if messageType := ParseMessageType(b); messageType != MessageTypeGLONASSBiases {
+		return fmt.Errorf("unmarshal GLONASS biases: unexpected message type %v", messageType)
+	}
+	if err := validatePayload(b); err != nil {
+		return err
+	}
+	g.unmarshalPayload(b)
+	return nil
+}
+
+func validatePayloadGLONASSBiases(payload []byte) error {
+	if err := validateLength(MessageTypeGLONASSBiases, len(payload), lengthOfGLONASSBiasesHeaderBits); err != nil {
+		return err
+	}
+	r := bitReader{b: payload, pos: lengthOfGLONASSBiasesHeaderBits - lengthOfGLONASSBiasesMask}
+	var numBiases int
+	for i := 0; i < lengthOfGLONASSBiasesMask; i++ {
+		if r.bool() {
+			numBiases++
+		}
+	}
+	return validateLength(
+		MessageTypeGLONASSBiases, len(payload), lengthOfGLONASSBiasesHeaderBits+numBiases*lengthOfGLONASSBias,
+	)
+}
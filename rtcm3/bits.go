@@ -0,0 +1,74 @@
+package rtcm3
+
+// bitReader reads big-endian bit fields from a payload.
+//
+// The length of the payload must be validated before reading.
+type bitReader struct {
+	b   []byte
+	pos int
+}
+
+// uint reads an unsigned field of n bits.
+func (r *bitReader) uint(n int) uint64 {
+	var v uint64
+	for i := 0; i < n; i++ {
+		v = v<<1 | uint64(r.b[r.pos/8]>>(7-uint(r.pos%8))&1)
+		r.pos++
+	}
+	return v
+}
+
+// int reads a two's complement signed field of n bits.
+func (r *bitReader) int(n int) int64 {
+	v := r.uint(n)
+	if v&(1<<uint(n-1)) != 0 {
+		return int64(v) - 1<<uint(n)
+	}
+	return int64(v)
+}
+
+// bool reads a single bit field.
+func (r *bitReader) bool() bool {
+	return r.uint(1) == 1
+}
+
+// skip n bits.
+func (r *bitReader) skip(n int) {
+	r.pos += n
+}
+
+// bitWriter writes big-endian bit fields to a payload.
+type bitWriter struct {
+	b   []byte
+	pos int
+}
+
+// uint writes an unsigned field of n bits.
+func (w *bitWriter) uint(n int, v uint64) {
+	for i := n - 1; i >= 0; i-- {
+		if w.pos/8 >= len(w.b) {
+			w.b = append(w.b, 0)
+		}
+		w.b[w.pos/8] |= byte(v>>uint(i)&1) << (7 - uint(w.pos%8))
+		w.pos++
+	}
+}
+
+// int writes a two's complement signed field of n bits.
+func (w *bitWriter) int(n int, v int64) {
+	w.uint(n, uint64(v)&(1<<uint(n)-1))
+}
+
+// bool writes a single bit field.
+func (w *bitWriter) bool(v bool) {
+	if v {
+		w.uint(1, 1)
+		return
+	}
+	w.uint(1, 0)
+}
+
+// bytes returns the written payload, padded with zero bits to a whole number of bytes.
+func (w *bitWriter) bytes() []byte {
+	return w.b
+}
@@ -0,0 +1,9 @@
+// Package rtcm3 provides primitives for parsing RTCM 3 correction streams, such as the corrections exchanged by a
+// Reach base and rover.
+//
+// The Scanner API mirrors the Scanner of the erb package. Typed decoders are provided for the station messages
+// 1005 and 1006, the MSM4 and MSM7 observation messages of GPS, GLONASS, Galileo and BeiDou, and the GLONASS
+// code-phase biases message 1230. Other messages are available as raw payloads.
+//
+// Implementation is based on RTCM Standard 10403.3.
+package rtcm3
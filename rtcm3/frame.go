@@ -0,0 +1,136 @@
+package rtcm3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// ErrCRCMismatch is the error of a frame with an invalid CRC.
+var ErrCRCMismatch = errors.New("CRC mismatch")
+
+const preamble = 0xd3
+
+const (
+	indexOfPreamble       = 0
+	lengthOfPreamble      = 1
+	indexOfPayloadLength  = indexOfPreamble + lengthOfPreamble
+	lengthOfPayloadLength = 2
+	indexOfPayload        = indexOfPayloadLength + lengthOfPayloadLength
+	lengthOfCRC           = 3
+	maxLengthOfPayload    = 1<<10 - 1
+	maxLengthOfFrame      = indexOfPayload + maxLengthOfPayload + lengthOfCRC
+)
+
+func calculateLengthOfFrame(lengthOfPayload int) int {
+	return indexOfPayload + lengthOfPayload + lengthOfCRC
+}
+
+// parseHeader returns the payload length of the frame header at the start of data.
+//
+// The 6 bits preceding the payload length are reserved and must be zero in a valid header.
+func parseHeader(data []byte) (lengthOfPayload int, ok bool) {
+	if data[indexOfPreamble] != preamble || data[indexOfPayloadLength]&0xfc != 0 {
+		return 0, false
+	}
+	return int(data[indexOfPayloadLength]&0x03)<<8 | int(data[indexOfPayloadLength+1]), true
+}
+
+// ScanFrames is a split function for a bufio.Scanner that returns each RTCM 3 frame.
+func ScanFrames(data []byte, _ bool) (advance int, token []byte, err error) {
+	if len(data) < indexOfPayload {
+		return 0, nil, nil
+	}
+	// scan until start of frame
+	lengthOfPayload, ok := parseHeader(data)
+	if !ok {
+		i := bytes.IndexByte(data[1:], preamble)
+		if i == -1 {
+			return len(data), nil, nil
+		}
+		return i + 1, nil, nil
+	}
+	lengthOfFrame := calculateLengthOfFrame(lengthOfPayload)
+	if len(data) < lengthOfFrame {
+		return 0, nil, nil
+	}
+	frame := data[:lengthOfFrame]
+	payload := frame[indexOfPayload : indexOfPayload+lengthOfPayload]
+	// verify CRC
+	expectedCRC := crc24q(frame[:indexOfPayload+lengthOfPayload])
+	actualCRC := uint32(frame[lengthOfFrame-3])<<16 | uint32(frame[lengthOfFrame-2])<<8 | uint32(frame[lengthOfFrame-1])
+	if expectedCRC != actualCRC {
+		return 0, nil, fmt.Errorf("%w (expected 0x%06x but got 0x%06x)", ErrCRCMismatch, expectedCRC, actualCRC)
+	}
+	if err := validatePayload(payload); err != nil {
+		return 0, nil, err
+	}
+	return lengthOfFrame, frame, nil
+}
+
+// FramePayload returns the payload of a frame returned by ScanFrames.
+func FramePayload(frame []byte) []byte {
+	return frame[indexOfPayload : len(frame)-lengthOfCRC]
+}
+
+// AppendFrame appends an RTCM 3 frame with the provided payload to b.
+func AppendFrame(b []byte, payload []byte) ([]byte, error) {
+	if len(payload) > maxLengthOfPayload {
+		return nil, fmt.Errorf(
+			"append frame: illegal payload length %d (expected maximum %d)", len(payload), maxLengthOfPayload,
+		)
+	}
+	start := len(b)
+	b = append(b, preamble, byte(len(payload)>>8), byte(len(payload)))
+	b = append(b, payload...)
+	crc := crc24q(b[start:])
+	return append(b, byte(crc>>16), byte(crc>>8), byte(crc)), nil
+}
+
+// crc24q returns the CRC-24Q checksum of data.
+func crc24q(data []byte) uint32 {
+	const polynomial = 0x1864cfb
+	var crc uint32
+	for _, b := range data {
+		crc ^= uint32(b) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= polynomial
+			}
+		}
+	}
+	return crc & 0xffffff
+}
+
+// validatePayload validates the length of a payload.
+func validatePayload(payload []byte) error {
+	switch {
+	case len(payload) == 0:
+		return nil // allow empty frames
+	case len(payload)*8 < lengthOfMessageType:
+		return fmt.Errorf("validate payload: illegal length %d (expected minimum 2)", len(payload))
+	}
+	messageType := ParseMessageType(payload)
+	switch {
+	case messageType.IsStationARP():
+		return validatePayloadStationARP(messageType, payload)
+	case messageType.IsMSM():
+		return validatePayloadMSM(messageType, payload)
+	case messageType == MessageTypeGLONASSBiases:
+		return validatePayloadGLONASSBiases(payload)
+	default:
+		return nil // allow unknown messages
+	}
+}
+
+// validateLength validates that a payload of a message type has a minimum length in bits.
+func validateLength(messageType MessageType, lengthOfPayload int, minBits int) error {
+	minLength := (minBits + 7) / 8
+	if lengthOfPayload < minLength {
+		return fmt.Errorf(
+			"validate %v payload: illegal length %d (expected minimum %d)", messageType, lengthOfPayload, minLength,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package rtcm3
+
+import (
+	"strconv"
+
+	"go.einride.tech/reach/erb"
+)
+
+// MessageType represents an RTCM 3 message type.
+type MessageType uint16
+
+const (
+	// MessageTypeStationARP is the type of the stationary RTK reference station ARP message.
+	MessageTypeStationARP MessageType = 1005
+	// MessageTypeStationARPWithHeight is the type of the stationary RTK reference station ARP with antenna height
+	// message.
+	MessageTypeStationARPWithHeight MessageType = 1006
+	// MessageTypeGPSMSM4 is the type of the GPS MSM4 observations message.
+	MessageTypeGPSMSM4 MessageType = 1074
+	// MessageTypeGPSMSM7 is the type of the GPS MSM7 observations message.
+	MessageTypeGPSMSM7 MessageType = 1077
+	// MessageTypeGLONASSMSM4 is the type of the GLONASS MSM4 observations message.
+	MessageTypeGLONASSMSM4 MessageType = 1084
+	// MessageTypeGLONASSMSM7 is the type of the GLONASS MSM7 observations message.
+	MessageTypeGLONASSMSM7 MessageType = 1087
+	// MessageTypeGalileoMSM4 is the type of the Galileo MSM4 observations message.
+	MessageTypeGalileoMSM4 MessageType = 1094
+	// MessageTypeGalileoMSM7 is the type of the Galileo MSM7 observations message.
+	MessageTypeGalileoMSM7 MessageType = 1097
+	// MessageTypeBeiDouMSM4 is the type of the BeiDou MSM4 observations message.
+	MessageTypeBeiDouMSM4 MessageType = 1124
+	// MessageTypeBeiDouMSM7 is the type of the BeiDou MSM7 observations message.
+	MessageTypeBeiDouMSM7 MessageType = 1127
+	// MessageTypeGLONASSBiases is the type of the GLONASS L1 and L2 code-phase biases message.
+	MessageTypeGLONASSBiases MessageType = 1230
+)
+
+// lengthOfMessageType is the length in bits of the message type at the start of each payload.
+const lengthOfMessageType = 12
+
+// String returns the message type number.
+func (t MessageType) String() string {
+	return strconv.Itoa(int(t))
+}
+
+// IsStationARP returns true for the station ARP messages 1005 and 1006.
+func (t MessageType) IsStationARP() bool {
+	return t == MessageTypeStationARP || t == MessageTypeStationARPWithHeight
+}
+
+// IsMSM returns true for the supported MSM4 and MSM7 observation messages.
+func (t MessageType) IsMSM() bool {
+	_, ok := t.Constellation()
+	return ok && (t.IsMSM4() || t.IsMSM7())
+}
+
+// IsMSM4 returns true for MSM4 observation messages.
+func (t MessageType) IsMSM4() bool {
+	return t.isMSMRange() && t%10 == 4
+}
+
+// IsMSM7 returns true for MSM7 observation messages.
+func (t MessageType) IsMSM7() bool {
+	return t.isMSMRange() && t%10 == 7
+}
+
+// isMSMRange returns true for message types in the range of MSM observation messages.
+func (t MessageType) isMSMRange() bool {
+	return t >= 1071 && t <= 1137
+}
+
+// Constellation returns the constellation of a supported MSM message.
+func (t MessageType) Constellation() (erb.SVType, bool) {
+	switch t / 10 {
+	case 107:
+		return erb.SVTypeGPS, true
+	case 108:
+		return erb.SVTypeGLONASS, true
+	case 109:
+		return erb.SVTypeGalileo, true
+	case 112:
+		return erb.SVTypeBeiDou, true
+	default:
+		return 0, false
+	}
+}
+
+// ParseMessageType returns the message type of a payload, or 0 when the payload is too short.
+func ParseMessageType(payload []byte) MessageType {
+	if len(payload) < 2 {
+		return 0
+	}
+	return MessageType(uint16(payload[0])<<4 | uint16(payload[1])>>4)
+}
@@ -0,0 +1,504 @@
+package rtcm3
+
+import (
+	"fmt"
+	"math"
+
+	"go.einride.tech/reach/erb"
+)
+
+// structure of MSM messages, in bits.
+const (
+	lengthOfEpochTime             = 30
+	lengthOfIssueOfDataStation    = 3
+	lengthOfMSMReserved           = 7
+	lengthOfClockSteering         = 2
+	lengthOfExternalClock         = 2
+	lengthOfSmoothingInterval     = 3
+	lengthOfSatelliteMask         = 64
+	lengthOfSignalMask            = 32
+	maxNumCells                   = 64
+	lengthOfMSMHeaderBits         = 169
+	lengthOfRoughRangeInteger     = 8
+	lengthOfRoughRangeModulo      = 10
+	lengthOfExtendedSatelliteInfo = 4
+	lengthOfRoughPhaseRangeRate   = 14
+	lengthOfMSM4FinePseudorange   = 15
+	lengthOfMSM4FinePhaseRange    = 22
+	lengthOfMSM4LockTimeIndicator = 4
+	lengthOfMSM4SignalStrength    = 6
+	lengthOfMSM7FinePseudorange   = 20
+	lengthOfMSM7FinePhaseRange    = 24
+	lengthOfMSM7LockTimeIndicator = 10
+	lengthOfMSM7SignalStrength    = 10
+	lengthOfFinePhaseRangeRate    = 15
+	lengthOfMSM4SatelliteBits     = lengthOfRoughRangeInteger + lengthOfRoughRangeModulo
+	lengthOfMSM7SatelliteBits     = lengthOfMSM4SatelliteBits + lengthOfExtendedSatelliteInfo + lengthOfRoughPhaseRangeRate
+	lengthOfHalfCycleAmbiguity    = 1
+	lengthOfMSM4SignalBits        = lengthOfMSM4FinePseudorange + lengthOfMSM4FinePhaseRange +
+		lengthOfMSM4LockTimeIndicator + lengthOfHalfCycleAmbiguity + lengthOfMSM4SignalStrength
+	lengthOfMSM7SignalBits = lengthOfMSM7FinePseudorange + lengthOfMSM7FinePhaseRange +
+		lengthOfMSM7LockTimeIndicator + lengthOfHalfCycleAmbiguity + lengthOfMSM7SignalStrength +
+		lengthOfFinePhaseRangeRate
+	lengthOfGLONASSDayOfWeek         = 3
+	lengthOfGLONASSTimeOfDay         = lengthOfEpochTime - lengthOfGLONASSDayOfWeek
+	invalidRoughRangeInteger         = 0xff
+	scaleOfRoughRangeModulo          = 1.0 / (1 << 10)
+	scaleOfMSM4FinePseudorange       = 1.0 / (1 << 24)
+	scaleOfMSM4FinePhaseRange        = 1.0 / (1 << 29)
+	scaleOfMSM7FinePseudorange       = 1.0 / (1 << 29)
+	scaleOfMSM7FinePhaseRange        = 1.0 / (1 << 31)
+	scaleOfMSM7SignalStrength        = 1.0 / (1 << 4)
+	scaleOfFinePhaseRangeRate        = 0.0001
+	speedOfLightMetersPerMillisecond = 299792.458
+)
+
+// MSMHeader is the header of an MSM observations message.
+type MSMHeader struct {
+	// MessageType of the message.
+	MessageType MessageType
+	// ReferenceStationID is the ID of the reference station.
+	ReferenceStationID uint16
+	// EpochTime is the time of the observations.
+	//
+	// For GPS and Galileo, the epoch time is the time of week in milliseconds. For BeiDou, the epoch time is the
+	// BeiDou time of week in milliseconds. For GLONASS, the epoch time contains the day of week and the time of day,
+	// see GLONASSTime.
+	EpochTime uint32
+	// MultipleMessage is true when more MSM messages follow for the same epoch.
+	MultipleMessage bool
+	// IssueOfDataStation is a counter of changes to the station setup.
+	IssueOfDataStation uint8
+	// ClockSteering indicates the clock steering of the receiver.
+	ClockSteering uint8
+	// ExternalClock indicates the use of an external clock.
+	ExternalClock uint8
+	// DivergenceFreeSmoothing is true when divergence-free smoothing is used.
+	DivergenceFreeSmoothing bool
+	// SmoothingInterval indicates the length of the smoothing interval.
+	SmoothingInterval uint8
+}
+
+// Constellation returns the constellation of the observations.
+func (h *MSMHeader) Constellation() erb.SVType {
+	constellation, _ := h.MessageType.Constellation()
+	return constellation
+}
+
+// GLONASSTime returns the day of week and the time of day in milliseconds of the epoch time of GLONASS observations.
+func (h *MSMHeader) GLONASSTime() (dayOfWeek uint8, timeOfDayMilliseconds uint32) {
+	return uint8(h.EpochTime >> lengthOfGLONASSTimeOfDay), h.EpochTime & (1<<lengthOfGLONASSTimeOfDay - 1)
+}
+
+// MSMSatellite is the satellite data of an MSM observations message.
+type MSMSatellite struct {
+	// ID of the satellite, from 1 to 64.
+	ID uint8
+	// RoughRangeMilliseconds is the rough range of the satellite (ms).
+	RoughRangeMilliseconds float64
+	// HasRoughRange is true when the rough range is valid.
+	HasRoughRange bool
+	// ExtendedInfo is extended satellite information in MSM7 messages.
+	//
+	// For GLONASS, the extended information is the frequency channel number plus 7.
+	ExtendedInfo uint8
+	// RoughPhaseRangeRateMetersPerSecond is the rough phase range rate of the satellite in MSM7 messages (m/s).
+	RoughPhaseRangeRateMetersPerSecond float64
+	// HasRoughPhaseRangeRate is true when the rough phase range rate is valid.
+	HasRoughPhaseRangeRate bool
+}
+
+// MSMSignal is the signal data of an MSM observations message.
+type MSMSignal struct {
+	// SatelliteID is the ID of the satellite of the signal.
+	SatelliteID uint8
+	// SignalID is the ID of the signal within its constellation, from 1 to 32.
+	SignalID uint8
+	// PseudorangeMeters is the full pseudorange of the signal (m).
+	PseudorangeMeters float64
+	// HasPseudorange is true when the pseudorange is valid.
+	HasPseudorange bool
+	// PhaseRangeMeters is the full phase range of the signal (m).
+	PhaseRangeMeters float64
+	// HasPhaseRange is true when the phase range is valid.
+	HasPhaseRange bool
+	// LockTimeIndicator is the phase range lock time indicator of the signal.
+	//
+	// The indicator has a resolution of 4 bits in MSM4 messages, and 10 bits in MSM7 messages.
+	LockTimeIndicator uint16
+	// HalfCycleAmbiguity is true when the phase range has a half-cycle ambiguity.
+	HalfCycleAmbiguity bool
+	// SignalStrength is the carrier to noise ratio of the signal in dB-Hz.
+	SignalStrength float64
+	// PhaseRangeRateMetersPerSecond is the full phase range rate of the signal in MSM7 messages (m/s).
+	PhaseRangeRateMetersPerSecond float64
+	// HasPhaseRangeRate is true when the phase range rate is valid.
+	HasPhaseRangeRate bool
+}
+
+// MSM is an MSM4 or MSM7 observations message.
+type MSM struct {
+	// Header of the message.
+	Header MSMHeader
+	// Satellites of the message, ordered by ID.
+	Satellites []MSMSatellite
+	// Signals of the message, ordered by satellite ID and signal ID.
+	Signals []MSMSignal
+}
+
+// msmLayout is the layout of the satellite and signal data of an MSM message, given by its masks.
+type msmLayout struct {
+	satellites []uint8
+	signals    []uint8
+	cells      []bool
+	numCells   int
+}
+
+// newMSMLayout returns the layout of an MSM message with the provided satellite and signal masks, without cells.
+func newMSMLayout(messageType MessageType, satelliteMask, signalMask uint64) (msmLayout, error) {
+	var l msmLayout
+	for i := 0; i < lengthOfSatelliteMask; i++ {
+		if satelliteMask&(1<<uint(lengthOfSatelliteMask-1-i)) != 0 {
+			l.satellites = append(l.satellites, uint8(i+1))
+		}
+	}
+	for i := 0; i < lengthOfSignalMask; i++ {
+		if signalMask&(1<<uint(lengthOfSignalMask-1-i)) != 0 {
+			l.signals = append(l.signals, uint8(i+1))
+		}
+	}
+	if n := len(l.satellites) * len(l.signals); n > maxNumCells {
+		return msmLayout{}, fmt.Errorf(
+			"validate %v payload: illegal number of cells %d (expected maximum %d)", messageType, n, maxNumCells,
+		)
+	}
+	return l, nil
+}
+
+// parseMSMLayout parses the masks of an MSM message, with r positioned at the satellite mask.
+func parseMSMLayout(messageType MessageType, r *bitReader) (msmLayout, error) {
+	satelliteMask := r.uint(lengthOfSatelliteMask)
+	signalMask := r.uint(lengthOfSignalMask)
+	l, err := newMSMLayout(messageType, satelliteMask, signalMask)
+	if err != nil {
+		return msmLayout{}, err
+	}
+	lengthOfCellMask := len(l.satellites) * len(l.signals)
+	if err := validateLength(messageType, len(r.b), lengthOfMSMHeaderBits+lengthOfCellMask); err != nil {
+		return msmLayout{}, err
+	}
+	l.cells = make([]bool, lengthOfCellMask)
+	for i := range l.cells {
+		l.cells[i] = r.bool()
+		if l.cells[i] {
+			l.numCells++
+		}
+	}
+	return l, nil
+}
+
+// lengthOfData returns the length in bits of the satellite and signal data.
+func (l *msmLayout) lengthOfData(messageType MessageType) int {
+	if messageType.IsMSM7() {
+		return len(l.satellites)*lengthOfMSM7SatelliteBits + l.numCells*lengthOfMSM7SignalBits
+	}
+	return len(l.satellites)*lengthOfMSM4SatelliteBits + l.numCells*lengthOfMSM4SignalBits
+}
+
+func unmarshalMSMHeader(h *MSMHeader, r *bitReader) {
+	h.MessageType = MessageType(r.uint(lengthOfMessageType))
+	h.ReferenceStationID = uint16(r.uint(lengthOfReferenceStationID))
+	h.EpochTime = uint32(r.uint(lengthOfEpochTime))
+	h.MultipleMessage = r.bool()
+	h.IssueOfDataStation = uint8(r.uint(lengthOfIssueOfDataStation))
+	r.skip(lengthOfMSMReserved)
+	h.ClockSteering = uint8(r.uint(lengthOfClockSteering))
+	h.ExternalClock = uint8(r.uint(lengthOfExternalClock))
+	h.DivergenceFreeSmoothing = r.bool()
+	h.SmoothingInterval = uint8(r.uint(lengthOfSmoothingInterval))
+}
+
+func (m *MSM) unmarshalPayload(b []byte) {
+	r := bitReader{b: b}
+	unmarshalMSMHeader(&m.Header, &r)
+	// assume the payload has already been validated
+	l, _ := parseMSMLayout(m.Header.MessageType, &r)
+	msm7 := m.Header.MessageType.IsMSM7()
+	m.Satellites = make([]MSMSatellite, len(l.satellites))
+	for i, id := range l.satellites {
+		m.Satellites[i].ID = id
+		roughRangeInteger := r.uint(lengthOfRoughRangeInteger)
+		m.Satellites[i].HasRoughRange = roughRangeInteger != invalidRoughRangeInteger
+		m.Satellites[i].RoughRangeMilliseconds = float64(roughRangeInteger)
+	}
+	if msm7 {
+		for i := range m.Satellites {
+			m.Satellites[i].ExtendedInfo = uint8(r.uint(lengthOfExtendedSatelliteInfo))
+		}
+	}
+	for i := range m.Satellites {
+		m.Satellites[i].RoughRangeMilliseconds += scaleOfRoughRangeModulo * float64(r.uint(lengthOfRoughRangeModulo))
+		if !m.Satellites[i].HasRoughRange {
+			m.Satellites[i].RoughRangeMilliseconds = 0
+		}
+	}
+	if msm7 {
+		for i := range m.Satellites {
+			rate := r.int(lengthOfRoughPhaseRangeRate)
+			m.Satellites[i].HasRoughPhaseRangeRate = rate != invalidSigned(lengthOfRoughPhaseRangeRate)
+			if m.Satellites[i].HasRoughPhaseRangeRate {
+				m.Satellites[i].RoughPhaseRangeRateMetersPerSecond = float64(rate)
+			}
+		}
+	}
+	m.Signals = make([]MSMSignal, 0, l.numCells)
+	satellites := make([]*MSMSatellite, 0, l.numCells)
+	for i, ok := range l.cells {
+		if !ok {
+			continue
+		}
+		satellite := &m.Satellites[i/len(l.signals)]
+		satellites = append(satellites, satellite)
+		m.Signals = append(m.Signals, MSMSignal{SatelliteID: satellite.ID, SignalID: l.signals[i%len(l.signals)]})
+	}
+	lengthOfFinePseudorange, scaleOfFinePseudorange := lengthOfMSM4FinePseudorange, scaleOfMSM4FinePseudorange
+	lengthOfFinePhaseRange, scaleOfFinePhaseRange := lengthOfMSM4FinePhaseRange, scaleOfMSM4FinePhaseRange
+	lengthOfLockTimeIndicator := lengthOfMSM4LockTimeIndicator
+	lengthOfSignalStrength, scaleOfSignalStrength := lengthOfMSM4SignalStrength, 1.0
+	if msm7 {
+		lengthOfFinePseudorange, scaleOfFinePseudorange = lengthOfMSM7FinePseudorange, scaleOfMSM7FinePseudorange
+		lengthOfFinePhaseRange, scaleOfFinePhaseRange = lengthOfMSM7FinePhaseRange, scaleOfMSM7FinePhaseRange
+		lengthOfLockTimeIndicator = lengthOfMSM7LockTimeIndicator
+		lengthOfSignalStrength, scaleOfSignalStrength = lengthOfMSM7SignalStrength, scaleOfMSM7SignalStrength
+	}
+	for i := range m.Signals {
+		fine := r.int(lengthOfFinePseudorange)
+		m.Signals[i].HasPseudorange = satellites[i].HasRoughRange && fine != invalidSigned(lengthOfFinePseudorange)
+		if m.Signals[i].HasPseudorange {
+			m.Signals[i].PseudorangeMeters = speedOfLightMetersPerMillisecond *
+				(satellites[i].RoughRangeMilliseconds + scaleOfFinePseudorange*float64(fine))
+		}
+	}
+	for i := range m.Signals {
+		fine := r.int(lengthOfFinePhaseRange)
+		m.Signals[i].HasPhaseRange = satellites[i].HasRoughRange && fine != invalidSigned(lengthOfFinePhaseRange)
+		if m.Signals[i].HasPhaseRange {
+			m.Signals[i].PhaseRangeMeters = speedOfLightMetersPerMillisecond *
+				(satellites[i].RoughRangeMilliseconds + scaleOfFinePhaseRange*float64(fine))
+		}
+	}
+	for i := range m.Signals {
+		m.Signals[i].LockTimeIndicator = uint16(r.uint(lengthOfLockTimeIndicator))
+	}
+	for i := range m.Signals {
+		m.Signals[i].HalfCycleAmbiguity = r.bool()
+	}
+	for i := range m.Signals {
+		m.Signals[i].SignalStrength = scaleOfSignalStrength * float64(r.uint(lengthOfSignalStrength))
+	}
+	if msm7 {
+		for i := range m.Signals {
+			fine := r.int(lengthOfFinePhaseRangeRate)
+			m.Signals[i].HasPhaseRangeRate = satellites[i].HasRoughPhaseRangeRate &&
+				fine != invalidSigned(lengthOfFinePhaseRangeRate)
+			if m.Signals[i].HasPhaseRangeRate {
+				m.Signals[i].PhaseRangeRateMetersPerSecond = satellites[i].RoughPhaseRangeRateMetersPerSecond +
+					scaleOfFinePhaseRangeRate*float64(fine)
+			}
+		}
+	}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the payload of the message.
+//
+// The masks of the message are derived from the IDs of the satellites and signals.
+func (m *MSM) MarshalBinary() ([]byte, error) {
+	messageType := m.Header.MessageType
+	if !messageType.IsMSM() {
+		return nil, fmt.Errorf("marshal MSM: unsupported message type %v", messageType)
+	}
+	msm7 := messageType.IsMSM7()
+	var satelliteMask uint64
+	satellites := make(map[uint8]*MSMSatellite, len(m.Satellites))
+	for i := range m.Satellites {
+		id := m.Satellites[i].ID
+		if id < 1 || id > lengthOfSatelliteMask {
+			return nil, fmt.Errorf("marshal %v: illegal satellite ID %d", messageType, id)
+		}
+		satelliteMask |= 1 << uint(lengthOfSatelliteMask-id)
+		satellites[id] = &m.Satellites[i]
+	}
+	var signalMask uint64
+	type cell struct{ satelliteID, signalID uint8 }
+	signals := make(map[cell]*MSMSignal, len(m.Signals))
+	for i := range m.Signals {
+		s := &m.Signals[i]
+		if s.SignalID < 1 || s.SignalID > lengthOfSignalMask {
+			return nil, fmt.Errorf("marshal %v: illegal signal ID %d", messageType, s.SignalID)
+		}
+		if _, ok := satellites[s.SatelliteID]; !ok {
+			return nil, fmt.Errorf("marshal %v: signal of missing satellite %d", messageType, s.SatelliteID)
+		}
+		signalMask |= 1 << uint(lengthOfSignalMask-s.SignalID)
+		signals[cell{satelliteID: s.SatelliteID, signalID: s.SignalID}] = s
+	}
+	var w bitWriter
+	w.uint(lengthOfMessageType, uint64(messageType))
+	w.uint(lengthOfReferenceStationID, uint64(m.Header.ReferenceStationID))
+	w.uint(lengthOfEpochTime, uint64(m.Header.EpochTime))
+	w.bool(m.Header.MultipleMessage)
+	w.uint(lengthOfIssueOfDataStation, uint64(m.Header.IssueOfDataStation))
+	w.uint(lengthOfMSMReserved, 0)
+	w.uint(lengthOfClockSteering, uint64(m.Header.ClockSteering))
+	w.uint(lengthOfExternalClock, uint64(m.Header.ExternalClock))
+	w.bool(m.Header.DivergenceFreeSmoothing)
+	w.uint(lengthOfSmoothingInterval, uint64(m.Header.SmoothingInterval))
+	w.uint(lengthOfSatelliteMask, satelliteMask)
+	w.uint(lengthOfSignalMask, signalMask)
+	l, err := newMSMLayout(messageType, satelliteMask, signalMask)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %v: %w", messageType, err)
+	}
+	ordered := make([]*MSMSignal, 0, len(signals))
+	orderedSatellites := make([]*MSMSatellite, 0, len(signals))
+	for _, satelliteID := range l.satellites {
+		for _, signalID := range l.signals {
+			s, ok := signals[cell{satelliteID: satelliteID, signalID: signalID}]
+			w.bool(ok)
+			if ok {
+				ordered = append(ordered, s)
+				orderedSatellites = append(orderedSatellites, satellites[satelliteID])
+			}
+		}
+	}
+	sorted := make([]*MSMSatellite, 0, len(l.satellites))
+	for _, id := range l.satellites {
+		sorted = append(sorted, satellites[id])
+	}
+	for _, s := range sorted {
+		if !s.HasRoughRange {
+			w.uint(lengthOfRoughRangeInteger, invalidRoughRangeInteger)
+			continue
+		}
+		w.uint(lengthOfRoughRangeInteger, uint64(math.Floor(roundRoughRange(s.RoughRangeMilliseconds))))
+	}
+	if msm7 {
+		for _, s := range sorted {
+			w.uint(lengthOfExtendedSatelliteInfo, uint64(s.ExtendedInfo))
+		}
+	}
+	for _, s := range sorted {
+		roughRange := roundRoughRange(s.RoughRangeMilliseconds)
+		w.uint(lengthOfRoughRangeModulo, uint64(math.Round((roughRange-math.Floor(roughRange))/scaleOfRoughRangeModulo)))
+	}
+	if msm7 {
+		for _, s := range sorted {
+			rate := invalidSigned(lengthOfRoughPhaseRangeRate)
+			if s.HasRoughPhaseRangeRate {
+				rate = int64(math.Round(s.RoughPhaseRangeRateMetersPerSecond))
+			}
+			w.int(lengthOfRoughPhaseRangeRate, rate)
+		}
+	}
+	lengthOfFinePseudorange, scaleOfFinePseudorange := lengthOfMSM4FinePseudorange, scaleOfMSM4FinePseudorange
+	lengthOfFinePhaseRange, scaleOfFinePhaseRange := lengthOfMSM4FinePhaseRange, scaleOfMSM4FinePhaseRange
+	lengthOfLockTimeIndicator := lengthOfMSM4LockTimeIndicator
+	lengthOfSignalStrength, scaleOfSignalStrength := lengthOfMSM4SignalStrength, 1.0
+	if msm7 {
+		lengthOfFinePseudorange, scaleOfFinePseudorange = lengthOfMSM7FinePseudorange, scaleOfMSM7FinePseudorange
+		lengthOfFinePhaseRange, scaleOfFinePhaseRange = lengthOfMSM7FinePhaseRange, scaleOfMSM7FinePhaseRange
+		lengthOfLockTimeIndicator = lengthOfMSM7LockTimeIndicator
+		lengthOfSignalStrength, scaleOfSignalStrength = lengthOfMSM7SignalStrength, scaleOfMSM7SignalStrength
+	}
+	for i, s := range ordered {
+		fine, err := fineRange(
+			s.HasPseudorange, s.PseudorangeMeters, orderedSatellites[i], lengthOfFinePseudorange, scaleOfFinePseudorange,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("marshal %v: pseudorange of satellite %d: %w", messageType, s.SatelliteID, err)
+		}
+		w.int(lengthOfFinePseudorange, fine)
+	}
+	for i, s := range ordered {
+		fine, err := fineRange(
+			s.HasPhaseRange, s.PhaseRangeMeters, orderedSatellites[i], lengthOfFinePhaseRange, scaleOfFinePhaseRange,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("marshal %v: phase range of satellite %d: %w", messageType, s.SatelliteID, err)
+		}
+		w.int(lengthOfFinePhaseRange, fine)
+	}
+	for _, s := range ordered {
+		w.uint(lengthOfLockTimeIndicator, uint64(s.LockTimeIndicator))
+	}
+	for _, s := range ordered {
+		w.bool(s.HalfCycleAmbiguity)
+	}
+	for _, s := range ordered {
+		w.uint(lengthOfSignalStrength, uint64(math.Round(s.SignalStrength/scaleOfSignalStrength)))
+	}
+	if msm7 {
+		for i, s := range ordered {
+			fine := invalidSigned(lengthOfFinePhaseRangeRate)
+			if s.HasPhaseRangeRate && orderedSatellites[i].HasRoughPhaseRangeRate {
+				fine = int64(math.Round(
+					(s.PhaseRangeRateMetersPerSecond - math.Round(orderedSatellites[i].RoughPhaseRangeRateMetersPerSecond)) /
+						scaleOfFinePhaseRangeRate,
+				))
+			}
+			w.int(lengthOfFinePhaseRangeRate, fine)
+		}
+	}
+	if len(w.bytes()) > maxLengthOfPayload {
+		return nil, fmt.Errorf("marshal %v: illegal payload length %d", messageType, len(w.bytes()))
+	}
+	return w.bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and parses the payload of the message.
+func (m *MSM) UnmarshalBinary(b []byte) error {
+	if messageType := ParseMessageType(b); !messageType.IsMSM() {
+		return fmt.Errorf("unmarshal MSM: unsupported message type %v", messageType)
+	}
+	if err := validatePayload(b); err != nil {
+		return err
+	}
+	m.unmarshalPayload(b)
+	return nil
+}
+
+// roundRoughRange rounds a rough range to the resolution of the rough range fields.
+func roundRoughRange(roughRangeMilliseconds float64) float64 {
+	return math.Round(roughRangeMilliseconds/scaleOfRoughRangeModulo) * scaleOfRoughRangeModulo
+}
+
+// fineRange returns the fine range of a full range relative to the rough range of its satellite.
+func fineRange(ok bool, meters float64, satellite *MSMSatellite, length int, scale float64) (int64, error) {
+	if !ok || !satellite.HasRoughRange {
+		return invalidSigned(length), nil
+	}
+	fine := int64(math.Round(
+		(meters/speedOfLightMetersPerMillisecond - roundRoughRange(satellite.RoughRangeMilliseconds)) / scale,
+	))
+	if fine <= invalidSigned(length) || fine >= -invalidSigned(length) {
+		return 0, fmt.Errorf("fine range out of bounds of rough range")
+	}
+	return fine, nil
+}
+
+// invalidSigned returns the value indicating an invalid signed field of n bits, which is the minimum value.
+func invalidSigned(n int) int64 {
+	return -1 << uint(n-1)
+}
+
+func validatePayloadMSM(messageType MessageType, payload []byte) error {
+	if err := validateLength(messageType, len(payload), lengthOfMSMHeaderBits); err != nil {
+		return err
+	}
+	r := bitReader{b: payload, pos: lengthOfMSMHeaderBits - lengthOfSatelliteMask - lengthOfSignalMask}
+	l, err := parseMSMLayout(messageType, &r)
+	if err != nil {
+		return err
+	}
+	return validateLength(messageType, len(payload), r.pos+l.lengthOfData(messageType))
+}
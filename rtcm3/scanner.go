@@ -0,0 +1,151 @@
+package rtcm3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Scanner provides a convenient interface for reading and parsing RTCM 3 messages from a stream.
+type Scanner struct {
+	sc          *bufio.Scanner
+	cfg         ScannerConfig
+	err         error
+	payload     []byte
+	messageType MessageType
+	stationARP  StationARP
+	msm         MSM
+	biases      GLONASSBiases
+}
+
+// NewScanner returns a new Scanner to read from r.
+func NewScanner(r io.Reader) *Scanner {
+	return NewScannerWithConfig(r, ScannerConfig{})
+}
+
+// NewScannerWithConfig returns a new Scanner to read from r, configured by cfg.
+func NewScannerWithConfig(r io.Reader, cfg ScannerConfig) *Scanner {
+	c := &Scanner{sc: bufio.NewScanner(r), cfg: cfg}
+	c.sc.Buffer(make([]byte, 0, maxLengthOfFrame), maxLengthOfFrame)
+	c.sc.Split(NewSplitFunc(cfg))
+	return c
+}
+
+// NewSplitFunc returns a split function for a bufio.Scanner that returns each RTCM 3 frame, configured by cfg.
+//
+// Unlike ScanFrames, the split function discards corrupt frames and resynchronizes when cfg.Resync is enabled.
+func NewSplitFunc(cfg ScannerConfig) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		return scanFrames(cfg, data, atEOF)
+	}
+}
+
+func scanFrames(cfg ScannerConfig, data []byte, atEOF bool) (int, []byte, error) {
+	var advance int
+	for {
+		n, token, err := scanFrame(cfg, data[advance:], atEOF)
+		advance += n
+		// at EOF the bufio.Scanner stops unless a token is returned, so keep skipping until the next frame
+		if err != nil || token != nil || n == 0 || !atEOF {
+			return advance, token, err
+		}
+	}
+}
+
+func scanFrame(cfg ScannerConfig, data []byte, atEOF bool) (advance int, token []byte, err error) {
+	advance, token, err = ScanFrames(data, atEOF)
+	if cfg.Resync && atEOF && err == nil && advance == 0 && len(data) > 0 {
+		// a false preamble with a corrupt length may hide frames before the end of the stream
+		err = fmt.Errorf("truncated frame (%d bytes at end of stream)", len(data))
+	}
+	if err != nil && cfg.Resync {
+		if cfg.OnDiscard != nil {
+			cfg.OnDiscard(data[:discardedFrameLength(data)], err)
+		}
+		// skip the preamble of the discarded frame and resynchronize on the next preamble
+		advance, token, err = 1, nil, nil
+	}
+	return advance, token, err
+}
+
+// discardedFrameLength returns the length of the discarded frame at the start of data.
+func discardedFrameLength(data []byte) int {
+	if len(data) < indexOfPayload {
+		return len(data)
+	}
+	lengthOfPayload, _ := parseHeader(data)
+	if n := calculateLengthOfFrame(lengthOfPayload); n < len(data) {
+		return n
+	}
+	return len(data)
+}
+
+// Scan advances the Scanner to the next message, whose type will then be
+// available through the MessageType method.
+func (c *Scanner) Scan() bool {
+	if c.err != nil {
+		return false
+	}
+	if ok := c.sc.Scan(); !ok {
+		c.err = c.sc.Err()
+		if c.err == nil {
+			c.err = io.EOF
+		}
+		return false
+	}
+	c.payload = FramePayload(c.sc.Bytes())
+	c.messageType = ParseMessageType(c.payload)
+	// assume the scan function has already validated payloads
+	switch {
+	case c.messageType.IsStationARP():
+		c.stationARP.unmarshalPayload(c.payload)
+	case c.messageType.IsMSM():
+		c.msm.unmarshalPayload(c.payload)
+	case c.messageType == MessageTypeGLONASSBiases:
+		c.biases.unmarshalPayload(c.payload)
+	default:
+		// allow unknown messages
+	}
+	return true
+}
+
+func (c *Scanner) Err() error {
+	if c.err == io.EOF {
+		return nil
+	}
+	return c.err
+}
+
+// MessageType returns the type of the current message.
+func (c *Scanner) MessageType() MessageType {
+	return c.messageType
+}
+
+// StationARP returns the current station ARP message, when the message type is 1005 or 1006.
+func (c *Scanner) StationARP() StationARP {
+	return c.stationARP
+}
+
+// MSM returns the current MSM observations message, when the message type is a supported MSM4 or MSM7 message.
+func (c *Scanner) MSM() MSM {
+	return c.msm
+}
+
+// GLONASSBiases returns the current GLONASS code-phase biases message, when the message type is 1230.
+func (c *Scanner) GLONASSBiases() GLONASSBiases {
+	return c.biases
+}
+
+// Payload returns the payload of the current message.
+//
+// The payload is only valid until the next call to Scan.
+func (c *Scanner) Payload() []byte {
+	return c.payload
+}
+
+// Bytes returns the full frame of the current message.
+//
+// The frame is only valid until the next call to Scan.
+func (c *Scanner) Bytes() []byte {
+	return c.sc.Bytes()
+}
@@ -0,0 +1,281 @@
+package rtcm3
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/hex"
+	"errors"
+	"math"
+	"testing"
+
+	"go.einride.tech/reach/erb"
+	"gotest.tools/v3/assert"
+)
+
+// exampleStationARP is the example 1005 frame of the RTCM 3 standard.
+const exampleStationARP = "d300133ed7d30202980edeef34b4bd62ac0941986f33360b98"
+
+func TestCRC24Q(t *testing.T) {
+	assert.Equal(t, uint32(0xcde703), crc24q([]byte("123456789")))
+}
+
+func TestScanner_StationARP(t *testing.T) {
+	data, err := hex.DecodeString(exampleStationARP)
+	assert.NilError(t, err)
+	sc := NewScanner(bytes.NewReader(data))
+	assert.Assert(t, sc.Scan())
+	assert.Equal(t, MessageTypeStationARP, sc.MessageType())
+	station := sc.StationARP()
+	assert.Equal(t, uint16(2003), station.ReferenceStationID)
+	assert.Assert(t, station.GPS)
+	assert.Assert(t, math.Abs(station.XMeters-1114104.5999) < 1e-6)
+	assert.Assert(t, math.Abs(station.YMeters-(-4850729.7108)) < 1e-6)
+	assert.Assert(t, math.Abs(station.ZMeters-3975521.4643) < 1e-6)
+	assert.DeepEqual(t, data, sc.Bytes())
+	assert.Assert(t, !sc.Scan())
+	assert.NilError(t, sc.Err())
+	// the example re-encodes to the same frame
+	payload, err := station.MarshalBinary()
+	assert.NilError(t, err)
+	frame, err := AppendFrame(nil, payload)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, data, frame)
+}
+
+func TestScanner_StationARPWithHeight(t *testing.T) {
+	expected := StationARP{
+		MessageType:         MessageTypeStationARPWithHeight,
+		ReferenceStationID:  12,
+		GPS:                 true,
+		GLONASS:             true,
+		Galileo:             true,
+		XMeters:             3370658.5474,
+		YMeters:             711877.1873,
+		ZMeters:             5349787.0012,
+		AntennaHeightMeters: 1.5,
+	}
+	sc := NewScanner(bytes.NewReader(marshalFrame(t, &expected)))
+	assert.Assert(t, sc.Scan())
+	actual := sc.StationARP()
+	actual.XMeters = roundMillimeters(actual.XMeters)
+	actual.YMeters = roundMillimeters(actual.YMeters)
+	actual.ZMeters = roundMillimeters(actual.ZMeters)
+	expected.XMeters = roundMillimeters(expected.XMeters)
+	expected.YMeters = roundMillimeters(expected.YMeters)
+	expected.ZMeters = roundMillimeters(expected.ZMeters)
+	assert.DeepEqual(t, expected, actual)
+	assert.Assert(t, !sc.Scan())
+	assert.NilError(t, sc.Err())
+}
+
+func TestScanner_GLONASSBiases(t *testing.T) {
+	expected := GLONASSBiases{
+		ReferenceStationID: 12,
+		L1CAMeters:         -1.5,
+		HasL1CA:            true,
+		L2PMeters:          2.24,
+		HasL2P:             true,
+	}
+	sc := NewScanner(bytes.NewReader(marshalFrame(t, &expected)))
+	assert.Assert(t, sc.Scan())
+	actual := sc.GLONASSBiases()
+	actual.L2PMeters = roundMillimeters(actual.L2PMeters)
+	assert.DeepEqual(t, expected, actual)
+	assert.Assert(t, !sc.Scan())
+	assert.NilError(t, sc.Err())
+}
+
+func TestMSM(t *testing.T) {
+	for _, messageType := range []MessageType{
+		MessageTypeGPSMSM4,
+		MessageTypeGPSMSM7,
+		MessageTypeGLONASSMSM4,
+		MessageTypeGLONASSMSM7,
+		MessageTypeGalileoMSM4,
+		MessageTypeGalileoMSM7,
+		MessageTypeBeiDouMSM4,
+		MessageTypeBeiDouMSM7,
+	} {
+		messageType := messageType
+		t.Run(messageType.String(), func(t *testing.T) {
+			msm7 := messageType.IsMSM7()
+			expected := MSM{
+				Header: MSMHeader{
+					MessageType:        messageType,
+					ReferenceStationID: 12,
+					EpochTime:          113968400,
+					MultipleMessage:    true,
+					IssueOfDataStation: 3,
+				},
+				Satellites: []MSMSatellite{
+					{ID: 3, RoughRangeMilliseconds: 70.5, HasRoughRange: true},
+					{ID: 12, RoughRangeMilliseconds: 75.25, HasRoughRange: true},
+					{ID: 30},
+				},
+				Signals: []MSMSignal{
+					{
+						SatelliteID:        3,
+						SignalID:           2,
+						PseudorangeMeters:  21135400.1,
+						HasPseudorange:     true,
+						PhaseRangeMeters:   21135400.6,
+						HasPhaseRange:      true,
+						LockTimeIndicator:  5,
+						HalfCycleAmbiguity: true,
+						SignalStrength:     44,
+					},
+					{
+						SatelliteID:       3,
+						SignalID:          16,
+						PseudorangeMeters: 21135401.4,
+						HasPseudorange:    true,
+						SignalStrength:    38,
+					},
+					{SatelliteID: 12, SignalID: 2, PseudorangeMeters: 22559390.5, HasPseudorange: true, SignalStrength: 40},
+					{SatelliteID: 30, SignalID: 16, SignalStrength: 20},
+				},
+			}
+			if msm7 {
+				expected.Satellites[0].ExtendedInfo = 8
+				expected.Satellites[0].RoughPhaseRangeRateMetersPerSecond = -512
+				expected.Satellites[0].HasRoughPhaseRangeRate = true
+				expected.Signals[0].PhaseRangeRateMetersPerSecond = -512.123
+				expected.Signals[0].HasPhaseRangeRate = true
+				expected.Signals[0].LockTimeIndicator = 600
+				expected.Signals[1].SignalStrength = 38.5625
+			}
+			payload, err := expected.MarshalBinary()
+			assert.NilError(t, err)
+			var actual MSM
+			assert.NilError(t, actual.UnmarshalBinary(payload))
+			for i := range actual.Signals {
+				// the fine pseudorange of MSM4 has a resolution of about 2 cm
+				actual.Signals[i].PseudorangeMeters = math.Round(actual.Signals[i].PseudorangeMeters*10) / 10
+				actual.Signals[i].PhaseRangeMeters = math.Round(actual.Signals[i].PhaseRangeMeters*10) / 10
+				actual.Signals[i].PhaseRangeRateMetersPerSecond = roundMillimeters(
+					actual.Signals[i].PhaseRangeRateMetersPerSecond,
+				)
+			}
+			assert.DeepEqual(t, expected, actual)
+			assert.Equal(t, messageType, ParseMessageType(payload))
+		})
+	}
+}
+
+func TestMSMHeader_GLONASSTime(t *testing.T) {
+	h := MSMHeader{MessageType: MessageTypeGLONASSMSM7, EpochTime: 3<<27 | 45000000}
+	dayOfWeek, timeOfDay := h.GLONASSTime()
+	assert.Equal(t, uint8(3), dayOfWeek)
+	assert.Equal(t, uint32(45000000), timeOfDay)
+	assert.Equal(t, erb.SVTypeGLONASS, h.Constellation())
+}
+
+func TestMSM_MarshalBinary_TooManyCells(t *testing.T) {
+	msm := MSM{Header: MSMHeader{MessageType: MessageTypeGPSMSM4}}
+	for i := uint8(1); i <= 17; i++ {
+		msm.Satellites = append(msm.Satellites, MSMSatellite{ID: i})
+	}
+	for i := uint8(1); i <= 4; i++ {
+		msm.Signals = append(msm.Signals, MSMSignal{SatelliteID: 1, SignalID: i})
+	}
+	_, err := msm.MarshalBinary()
+	assert.ErrorContains(t, err, "illegal number of cells 68")
+}
+
+func TestScanner_CRCMismatch(t *testing.T) {
+	data, err := hex.DecodeString(exampleStationARP)
+	assert.NilError(t, err)
+	data[10] ^= 0xff
+	sc := NewScanner(bytes.NewReader(data))
+	assert.Assert(t, !sc.Scan())
+	assert.Assert(t, errors.Is(sc.Err(), ErrCRCMismatch))
+}
+
+func TestScanner_Resync(t *testing.T) {
+	frame, err := hex.DecodeString(exampleStationARP)
+	assert.NilError(t, err)
+	corrupt := append([]byte(nil), frame...)
+	corrupt[10] ^= 0xff
+	var data []byte
+	data = append(data, 0x01, 0xd3, 0xff) // garbage with a false preamble
+	data = append(data, corrupt...)
+	data = append(data, frame...)
+	data = append(data, frame[:10]...) // truncated frame
+	var discarded int
+	sc := NewScannerWithConfig(bytes.NewReader(data), ScannerConfig{
+		Resync: true,
+		OnDiscard: func(_ []byte, err error) {
+			discarded++
+		},
+	})
+	var scanned int
+	for sc.Scan() {
+		assert.Equal(t, MessageTypeStationARP, sc.MessageType())
+		scanned++
+	}
+	assert.NilError(t, sc.Err())
+	assert.Equal(t, 1, scanned)
+	assert.Assert(t, discarded >= 2)
+}
+
+func TestNewSplitFunc(t *testing.T) {
+	frame, err := hex.DecodeString(exampleStationARP)
+	assert.NilError(t, err)
+	corrupt := append([]byte(nil), frame...)
+	corrupt[10] ^= 0xff
+	var discarded int
+	split := NewSplitFunc(ScannerConfig{
+		Resync: true,
+		OnDiscard: func([]byte, error) {
+			discarded++
+		},
+	})
+	data := append(corrupt, frame...)
+	advance, token := 0, []byte(nil)
+	for token == nil {
+		n, frame, err := split(data[advance:], true)
+		assert.NilError(t, err)
+		assert.Assert(t, n > 0)
+		advance, token = advance+n, frame
+	}
+	assert.Assert(t, discarded >= 1)
+	assert.Equal(t, len(data), advance)
+	assert.Equal(t, MessageTypeStationARP, ParseMessageType(FramePayload(token)))
+}
+
+func TestScanner_UnknownMessage(t *testing.T) {
+	var w bitWriter
+	w.uint(lengthOfMessageType, 1019)
+	w.uint(32, 0xdeadbeef)
+	frame, err := AppendFrame(nil, w.bytes())
+	assert.NilError(t, err)
+	sc := NewScanner(bytes.NewReader(frame))
+	assert.Assert(t, sc.Scan())
+	assert.Equal(t, MessageType(1019), sc.MessageType())
+	assert.DeepEqual(t, w.bytes(), sc.Payload())
+}
+
+func TestScanner_IllegalLength(t *testing.T) {
+	var w bitWriter
+	w.uint(lengthOfMessageType, uint64(MessageTypeStationARP))
+	w.uint(32, 0)
+	frame, err := AppendFrame(nil, w.bytes())
+	assert.NilError(t, err)
+	sc := NewScanner(bytes.NewReader(frame))
+	assert.Assert(t, !sc.Scan())
+	assert.Error(t, sc.Err(), "validate 1005 payload: illegal length 6 (expected minimum 19)")
+}
+
+func marshalFrame(t *testing.T, m encoding.BinaryMarshaler) []byte {
+	t.Helper()
+	payload, err := m.MarshalBinary()
+	assert.NilError(t, err)
+	frame, err := AppendFrame(nil, payload)
+	assert.NilError(t, err)
+	return frame
+}
+
+// roundMillimeters rounds a value in meters to the closest millimeter, for comparison of decoded values.
+func roundMillimeters(meters float64) float64 {
+	return math.Round(meters*1000) / 1000
+}
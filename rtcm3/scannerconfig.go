@@ -0,0 +1,14 @@
+package rtcm3
+
+// ScannerConfig configures a Scanner.
+type ScannerConfig struct {
+	// Resync enables a tolerant mode, where corrupt frames are discarded instead of stopping the scanner.
+	//
+	// After a corrupt frame, the scanner resynchronizes on the next preamble. Since the preamble may occur in the
+	// payload of frames, the tolerant mode is recommended when joining a stream mid-frame.
+	Resync bool
+	// OnDiscard is an optional hook invoked with each frame discarded in tolerant mode and the cause of the discard.
+	//
+	// The frame is only valid for the duration of the call.
+	OnDiscard func(frame []byte, err error)
+}
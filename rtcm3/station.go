@@ -0,0 +1,123 @@
+package rtcm3
+
+import (
+	"fmt"
+	"math"
+)
+
+// structure of station ARP messages, in bits.
+const (
+	lengthOfStationARPBits           = 152
+	lengthOfStationARPWithHeightBits = lengthOfStationARPBits + lengthOfAntennaHeight
+	lengthOfReferenceStationID       = 12
+	lengthOfITRFRealizationYear      = 6
+	lengthOfECEF                     = 38
+	lengthOfQuarterCycleIndicator    = 2
+	lengthOfAntennaHeight            = 16
+)
+
+// scaleOfStationARP is the resolution of the station coordinates and antenna height (m).
+const scaleOfStationARP = 0.0001
+
+// StationARP message contains the earth-centered, earth-fixed coordinates of the antenna reference point (ARP) of
+// a stationary RTK reference station.
+//
+// The message types 1005 and 1006 share the layout, with an additional antenna height in 1006.
+type StationARP struct {
+	// MessageType is 1005, or 1006 when the antenna height is included.
+	MessageType MessageType
+	// ReferenceStationID is the ID of the reference station.
+	ReferenceStationID uint16
+	// ITRFRealizationYear is reserved for the ITRF realization year.
+	ITRFRealizationYear uint8
+	// GPS is true when the station provides GPS service.
+	GPS bool
+	// GLONASS is true when the station provides GLONASS service.
+	GLONASS bool
+	// Galileo is true when the station provides Galileo service.
+	Galileo bool
+	// ComputedReferenceStation is true for a non-physical or computed reference station.
+	ComputedReferenceStation bool
+	// XMeters is the ECEF X coordinate of the ARP (m).
+	XMeters float64
+	// YMeters is the ECEF Y coordinate of the ARP (m).
+	YMeters float64
+	// ZMeters is the ECEF Z coordinate of the ARP (m).
+	ZMeters float64
+	// SingleReceiverOscillator is true when all raw data observations of the station are measured at the same instant.
+	SingleReceiverOscillator bool
+	// QuarterCycleIndicator indicates the phase correction of the GPS quarter cycle offset.
+	QuarterCycleIndicator uint8
+	// AntennaHeightMeters is the height of the ARP above the marker (m), in 1006 messages.
+	AntennaHeightMeters float64
+}
+
+func (s *StationARP) unmarshalPayload(b []byte) {
+	r := bitReader{b: b}
+	s.MessageType = MessageType(r.uint(lengthOfMessageType))
+	s.ReferenceStationID = uint16(r.uint(lengthOfReferenceStationID))
+	s.ITRFRealizationYear = uint8(r.uint(lengthOfITRFRealizationYear))
+	s.GPS = r.bool()
+	s.GLONASS = r.bool()
+	s.Galileo = r.bool()
+	s.ComputedReferenceStation = r.bool()
+	s.XMeters = scaleOfStationARP * float64(r.int(lengthOfECEF))
+	s.SingleReceiverOscillator = r.bool()
+	r.skip(1) // reserved
+	s.YMeters = scaleOfStationARP * float64(r.int(lengthOfECEF))
+	s.QuarterCycleIndicator = uint8(r.uint(lengthOfQuarterCycleIndicator))
+	s.ZMeters = scaleOfStationARP * float64(r.int(lengthOfECEF))
+	s.AntennaHeightMeters = 0
+	if s.MessageType == MessageTypeStationARPWithHeight {
+		s.AntennaHeightMeters = scaleOfStationARP * float64(r.uint(lengthOfAntennaHeight))
+	}
+}
+
+func (s *StationARP) marshalPayload(w *bitWriter) {
+	w.uint(lengthOfMessageType, uint64(s.MessageType))
+	w.uint(lengthOfReferenceStationID, uint64(s.ReferenceStationID))
+	w.uint(lengthOfITRFRealizationYear, uint64(s.ITRFRealizationYear))
+	w.bool(s.GPS)
+	w.bool(s.GLONASS)
+	w.bool(s.Galileo)
+	w.bool(s.ComputedReferenceStation)
+	w.int(lengthOfECEF, int64(math.Round(s.XMeters/scaleOfStationARP)))
+	w.bool(s.SingleReceiverOscillator)
+	w.bool(false) // reserved
+	w.int(lengthOfECEF, int64(math.Round(s.YMeters/scaleOfStationARP)))
+	w.uint(lengthOfQuarterCycleIndicator, uint64(s.QuarterCycleIndicator))
+	w.int(lengthOfECEF, int64(math.Round(s.ZMeters/scaleOfStationARP)))
+	if s.MessageType == MessageTypeStationARPWithHeight {
+		w.uint(lengthOfAntennaHeight, uint64(math.Round(s.AntennaHeightMeters/scaleOfStationARP)))
+	}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the payload of the message.
+func (s *StationARP) MarshalBinary() ([]byte, error) {
+	if !s.MessageType.IsStationARP() {
+		return nil, fmt.Errorf("marshal station ARP: unexpected message type %v", s.MessageType)
+	}
+	var w bitWriter
+	s.marshalPayload(&w)
+	return w.bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and parses the payload of the message.
+func (s *StationARP) UnmarshalBinary(b []byte) error {
+	if err := validatePayload(b); err != nil {
+		return err
+	}
+	if messageType := ParseMessageType(b); !messageType.IsStationARP() {
+		return fmt.Errorf("unmarshal station ARP: unexpected message type %v", messageType)
+	}
+	s.unmarshalPayload(b)
+	return nil
+}
+
+func validatePayloadStationARP(messageType MessageType, payload []byte) error {
+	minBits := lengthOfStationARPBits
+	if messageType == MessageTypeStationARPWithHeight {
+		minBits = lengthOfStationARPWithHeightBits
+	}
+	return validateLength(messageType, len(payload), minBits)
+}
@@ -0,0 +1,19 @@
+package rtklink
+
+// Cause represents the suspected cause of a degraded fix type.
+type Cause uint8
+
+//go:generate stringer -type Cause -trimprefix Cause
+
+const (
+	// CauseNone is the cause when the fix type did not degrade, or when the correction link looks healthy.
+	CauseNone Cause = iota
+	// CauseNoCorrections is the cause when no corrections have been received.
+	CauseNoCorrections
+	// CauseStaleCorrections is the cause when the age of the last correction exceeds the maximum age.
+	CauseStaleCorrections
+	// CauseBaseStationChanged is the cause when the reference station ID of the corrections recently changed.
+	CauseBaseStationChanged
+	// CauseBasePositionChanged is the cause when the antenna reference point of the base station recently moved.
+	CauseBasePositionChanged
+)
@@ -0,0 +1,35 @@
+package rtklink
+
+import "time"
+
+// Config configures a Monitor.
+type Config struct {
+	// MaxCorrectionAge is the maximum age of the last correction before the corrections are considered stale.
+	// Defaults to 5s.
+	MaxCorrectionAge time.Duration
+	// BasePositionToleranceMeters is the maximum movement of the base station position that is not considered a change
+	// of position. Defaults to 0.05 m.
+	BasePositionToleranceMeters float64
+	// BaseChangeWindow is the time after a change of base station or base station position during which a degraded
+	// fix type is attributed to the change. Defaults to 60s.
+	BaseChangeWindow time.Duration
+	// OnEvent is an optional callback invoked with each event of the monitor.
+	OnEvent func(Event)
+	// Now is an optional function for the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+func (c *Config) setDefaults() {
+	if c.MaxCorrectionAge <= 0 {
+		c.MaxCorrectionAge = 5 * time.Second
+	}
+	if c.BasePositionToleranceMeters <= 0 {
+		c.BasePositionToleranceMeters = 0.05
+	}
+	if c.BaseChangeWindow <= 0 {
+		c.BaseChangeWindow = time.Minute
+	}
+	if c.Now == nil {
+		c.Now = time.Now
+	}
+}
@@ -0,0 +1,5 @@
+// Package rtklink provides health monitoring of the RTK correction link of a Reach rover.
+//
+// The corrections forwarded to the rover, for example by an NTRIP client, are correlated with the fix type of its
+// navigation solution, to diagnose why RTK is lost, such as "float because corrections are 12 s old".
+package rtklink
@@ -0,0 +1,19 @@
+package rtklink
+
+// EventType represents the type of a monitor event.
+type EventType uint8
+
+//go:generate stringer -type EventType -trimprefix EventType
+
+const (
+	// EventTypeCorrectionsStale is the event when the age of the last correction exceeds the maximum age.
+	EventTypeCorrectionsStale EventType = iota
+	// EventTypeCorrectionsResumed is the event when corrections are received after being stale.
+	EventTypeCorrectionsResumed
+	// EventTypeBaseStationChanged is the event when the reference station ID of the corrections changes.
+	EventTypeBaseStationChanged
+	// EventTypeBasePositionChanged is the event when the antenna reference point of the base station moves.
+	EventTypeBasePositionChanged
+	// EventTypeFixTypeChanged is the event when the fix type of the rover changes.
+	EventTypeFixTypeChanged
+)
@@ -0,0 +1,396 @@
+package rtklink
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"go.einride.tech/reach/rtcm3"
+)
+
+// Event is an event of a Monitor.
+type Event struct {
+	// Type of the event.
+	Type EventType
+	// Time is the time at which the event was detected.
+	Time time.Time
+	// TimeGPS is the time of week in milliseconds of the navigation epoch that caused the event, for fix type events.
+	TimeGPS uint32
+	// FixType is the fix type of the rover.
+	FixType erb.FixType
+	// PreviousFixType is the previous fix type of the rover, for fix type events.
+	PreviousFixType erb.FixType
+	// CorrectionAge is the age of the last correction, or the time since the creation of the monitor when no
+	// corrections have been received.
+	CorrectionAge time.Duration
+	// HasCorrections is true when corrections have been received.
+	HasCorrections bool
+	// ReferenceStationID is the ID of the reference station of the corrections.
+	ReferenceStationID uint16
+	// PreviousReferenceStationID is the previous ID of the reference station, for base station events.
+	PreviousReferenceStationID uint16
+	// BasePositionChangeMeters is the movement of the base station position, for base position events.
+	BasePositionChangeMeters float64
+	// Cause is the suspected cause of a degraded fix type, for fix type events.
+	Cause Cause
+}
+
+// Diagnosis returns a human-readable diagnosis of the event, such as "float because corrections are 12 s old".
+func (e Event) Diagnosis() string {
+	switch e.Type {
+	case EventTypeCorrectionsStale:
+		if !e.HasCorrections {
+			return fmt.Sprintf("no corrections received in %s", formatAge(e.CorrectionAge))
+		}
+		return fmt.Sprintf("corrections are %s old", formatAge(e.CorrectionAge))
+	case EventTypeCorrectionsResumed:
+		return fmt.Sprintf("corrections resumed after %s", formatAge(e.CorrectionAge))
+	case EventTypeBaseStationChanged:
+		return fmt.Sprintf(
+			"base station changed from %d to %d", e.PreviousReferenceStationID, e.ReferenceStationID,
+		)
+	case EventTypeBasePositionChanged:
+		return fmt.Sprintf("base station %d moved %.2f m", e.ReferenceStationID, e.BasePositionChangeMeters)
+	case EventTypeFixTypeChanged:
+		fixType := formatFixType(e.FixType)
+		switch e.Cause {
+		case CauseNoCorrections:
+			return fixType + " because no corrections have been received"
+		case CauseStaleCorrections:
+			return fmt.Sprintf("%s because corrections are %s old", fixType, formatAge(e.CorrectionAge))
+		case CauseBaseStationChanged:
+			return fmt.Sprintf(
+				"%s because the base station changed from %d to %d",
+				fixType,
+				e.PreviousReferenceStationID,
+				e.ReferenceStationID,
+			)
+		case CauseBasePositionChanged:
+			return fmt.Sprintf(
+				"%s because base station %d moved %.2f m", fixType, e.ReferenceStationID, e.BasePositionChangeMeters,
+			)
+		}
+		if !e.HasCorrections {
+			return fixType + " without corrections"
+		}
+		return fmt.Sprintf("%s with corrections %s old", fixType, formatAge(e.CorrectionAge))
+	default:
+		return e.Type.String()
+	}
+}
+
+// Stats are the statistics of a Monitor.
+type Stats struct {
+	// Bytes is the number of forwarded correction bytes.
+	Bytes uint64
+	// Messages is the number of forwarded RTCM 3 messages.
+	Messages uint64
+	// MessageTypes is the number of forwarded RTCM 3 messages of each message type.
+	MessageTypes map[rtcm3.MessageType]uint64
+	// DiscardedFrames is the number of RTCM 3 frames discarded because of CRC mismatches or invalid payloads.
+	DiscardedFrames uint64
+	// LastCorrection is the time at which the last RTCM 3 message was forwarded.
+	LastCorrection time.Time
+	// CorrectionAge is the age of the last correction.
+	CorrectionAge time.Duration
+	// HasCorrections is true when corrections have been received.
+	HasCorrections bool
+	// Stale is true when the age of the last correction exceeds the maximum age.
+	Stale bool
+	// ReferenceStationID is the ID of the reference station of the corrections.
+	ReferenceStationID uint16
+	// HasReferenceStation is true when the ID of the reference station is known.
+	HasReferenceStation bool
+	// BaseXMeters is the ECEF X coordinate of the antenna reference point of the base station (m).
+	BaseXMeters float64
+	// BaseYMeters is the ECEF Y coordinate of the antenna reference point of the base station (m).
+	BaseYMeters float64
+	// BaseZMeters is the ECEF Z coordinate of the antenna reference point of the base station (m).
+	BaseZMeters float64
+	// HasBasePosition is true when the position of the base station is known.
+	HasBasePosition bool
+	// BaseStationChanges is the number of changes of reference station ID.
+	BaseStationChanges uint64
+	// BasePositionChanges is the number of changes of base station position.
+	BasePositionChanges uint64
+	// FixType is the current fix type of the rover.
+	FixType erb.FixType
+	// HasFixType is true when the fix type of the rover is known.
+	HasFixType bool
+}
+
+// Monitor monitors the RTK correction link of a Reach rover.
+//
+// The monitor is an io.Writer, that should be written the corrections forwarded to the rover, for example through an
+// io.MultiWriter. RTCM 3 frames are scanned from the written bytes, and the reference station ID and base station
+// position are tracked from the MSM and station messages. The fix type of the rover is tracked from the STAT messages
+// of its ERB stream, and each change of fix type is reported with the state of the correction link.
+//
+// Stale corrections are detected when messages are received, and when Check is called. Write, Update and Check may be
+// called from different goroutines.
+type Monitor struct {
+	mu                 sync.Mutex
+	cfg                Config
+	start              time.Time
+	split              bufio.SplitFunc
+	buf                []byte
+	stats              Stats
+	previousStationID  uint16
+	lastStationChange  time.Time
+	hasStationChange   bool
+	positionChange     float64
+	lastPositionChange time.Time
+	hasPositionChange  bool
+}
+
+// NewMonitor returns a new Monitor with the provided config.
+func NewMonitor(cfg Config) *Monitor {
+	cfg.setDefaults()
+	m := &Monitor{
+		cfg:   cfg,
+		start: cfg.Now(),
+		stats: Stats{MessageTypes: map[rtcm3.MessageType]uint64{}},
+	}
+	m.split = rtcm3.NewSplitFunc(rtcm3.ScannerConfig{
+		Resync: true,
+		OnDiscard: func([]byte, error) {
+			m.stats.DiscardedFrames++
+		},
+	})
+	return m
+}
+
+// Write corrections forwarded to the rover to the monitor.
+//
+// Write always succeeds.
+func (m *Monitor) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	now := m.cfg.Now()
+	m.stats.Bytes += uint64(len(p))
+	m.buf = append(m.buf, p...)
+	var events []Event
+	i := 0
+	for i < len(m.buf) {
+		// the split function resynchronizes after corrupt frames, and never fails
+		advance, frame, _ := m.split(m.buf[i:], false)
+		if advance == 0 {
+			break
+		}
+		if frame != nil {
+			events = m.handleFrame(now, frame, events)
+		}
+		i += advance
+	}
+	m.buf = m.buf[:copy(m.buf, m.buf[i:])]
+	m.mu.Unlock()
+	m.emit(events)
+	return len(p), nil
+}
+
+// Update the monitor with the current message of the scanner.
+//
+// Messages other than STAT are ignored.
+func (m *Monitor) Update(sc *erb.Scanner) {
+	if sc.ID() == erb.IDSTAT {
+		m.UpdateSTAT(sc.STAT())
+	}
+}
+
+// UpdateSTAT updates the monitor with a STAT message of the rover.
+func (m *Monitor) UpdateSTAT(stat erb.STAT) {
+	m.mu.Lock()
+	now := m.cfg.Now()
+	events := m.checkStale(now, nil)
+	previous, hadFixType := m.stats.FixType, m.stats.HasFixType
+	m.stats.FixType, m.stats.HasFixType = stat.FixType, true
+	if hadFixType && stat.FixType != previous {
+		event := m.newEvent(EventTypeFixTypeChanged, now)
+		event.TimeGPS = stat.TimeGPS
+		event.PreviousFixType = previous
+		if stat.FixType < previous {
+			event.Cause = m.cause(now)
+		}
+		switch event.Cause {
+		case CauseBaseStationChanged:
+			event.PreviousReferenceStationID = m.previousStationID
+		case CauseBasePositionChanged:
+			event.BasePositionChangeMeters = m.positionChange
+		}
+		events = append(events, event)
+	}
+	m.mu.Unlock()
+	m.emit(events)
+}
+
+// Check the monitor for stale corrections.
+//
+// Check should be called periodically, to detect stale corrections when no messages are received.
+func (m *Monitor) Check() {
+	m.mu.Lock()
+	events := m.checkStale(m.cfg.Now(), nil)
+	m.mu.Unlock()
+	m.emit(events)
+}
+
+// Stats returns the current statistics of the monitor.
+func (m *Monitor) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := m.stats
+	stats.MessageTypes = make(map[rtcm3.MessageType]uint64, len(m.stats.MessageTypes))
+	for messageType, count := range m.stats.MessageTypes {
+		stats.MessageTypes[messageType] = count
+	}
+	stats.CorrectionAge = m.correctionAge(m.cfg.Now())
+	return stats
+}
+
+func (m *Monitor) handleFrame(now time.Time, frame []byte, events []Event) []Event {
+	if m.stats.Stale {
+		m.stats.Stale = false
+		events = append(events, m.newEvent(EventTypeCorrectionsResumed, now))
+	}
+	m.stats.Messages++
+	m.stats.LastCorrection = now
+	m.stats.HasCorrections = true
+	payload := rtcm3.FramePayload(frame)
+	if len(payload) == 0 {
+		return events
+	}
+	messageType := rtcm3.ParseMessageType(payload)
+	m.stats.MessageTypes[messageType]++
+	switch {
+	case messageType.IsStationARP():
+		var station rtcm3.StationARP
+		if err := station.UnmarshalBinary(payload); err != nil {
+			return events
+		}
+		events = m.updateStationID(now, station.ReferenceStationID, events)
+		events = m.updateBasePosition(now, station, events)
+	case messageType.IsMSM():
+		var msm rtcm3.MSM
+		if err := msm.UnmarshalBinary(payload); err != nil {
+			return events
+		}
+		events = m.updateStationID(now, msm.Header.ReferenceStationID, events)
+	}
+	return events
+}
+
+func (m *Monitor) updateStationID(now time.Time, id uint16, events []Event) []Event {
+	if m.stats.HasReferenceStation && id != m.stats.ReferenceStationID {
+		m.previousStationID = m.stats.ReferenceStationID
+		m.stats.ReferenceStationID = id
+		m.stats.BaseStationChanges++
+		m.stats.HasBasePosition = false
+		m.lastStationChange, m.hasStationChange = now, true
+		event := m.newEvent(EventTypeBaseStationChanged, now)
+		event.PreviousReferenceStationID = m.previousStationID
+		return append(events, event)
+	}
+	m.stats.ReferenceStationID, m.stats.HasReferenceStation = id, true
+	return events
+}
+
+func (m *Monitor) updateBasePosition(now time.Time, station rtcm3.StationARP, events []Event) []Event {
+	if m.stats.HasBasePosition {
+		change := math.Sqrt(
+			square(station.XMeters-m.stats.BaseXMeters) +
+				square(station.YMeters-m.stats.BaseYMeters) +
+				square(station.ZMeters-m.stats.BaseZMeters),
+		)
+		if change > m.cfg.BasePositionToleranceMeters {
+			m.stats.BasePositionChanges++
+			m.positionChange = change
+			m.lastPositionChange, m.hasPositionChange = now, true
+			event := m.newEvent(EventTypeBasePositionChanged, now)
+			event.BasePositionChangeMeters = change
+			events = append(events, event)
+		}
+	}
+	m.stats.BaseXMeters, m.stats.BaseYMeters, m.stats.BaseZMeters = station.XMeters, station.YMeters, station.ZMeters
+	m.stats.HasBasePosition = true
+	return events
+}
+
+func (m *Monitor) checkStale(now time.Time, events []Event) []Event {
+	if m.stats.Stale || m.correctionAge(now) <= m.cfg.MaxCorrectionAge {
+		return events
+	}
+	m.stats.Stale = true
+	return append(events, m.newEvent(EventTypeCorrectionsStale, now))
+}
+
+// cause returns the suspected cause of a degraded fix type.
+func (m *Monitor) cause(now time.Time) Cause {
+	switch {
+	case !m.stats.HasCorrections:
+		return CauseNoCorrections
+	case m.correctionAge(now) > m.cfg.MaxCorrectionAge:
+		return CauseStaleCorrections
+	case m.hasStationChange && now.Sub(m.lastStationChange) <= m.cfg.BaseChangeWindow:
+		return CauseBaseStationChanged
+	case m.hasPositionChange && now.Sub(m.lastPositionChange) <= m.cfg.BaseChangeWindow:
+		return CauseBasePositionChanged
+	default:
+		return CauseNone
+	}
+}
+
+// correctionAge returns the age of the last correction, or the time since the creation of the monitor when no
+// corrections have been received.
+func (m *Monitor) correctionAge(now time.Time) time.Duration {
+	if !m.stats.HasCorrections {
+		return now.Sub(m.start)
+	}
+	return now.Sub(m.stats.LastCorrection)
+}
+
+// newEvent returns a new event with the current state of the correction link.
+func (m *Monitor) newEvent(eventType EventType, now time.Time) Event {
+	return Event{
+		Type:               eventType,
+		Time:               now,
+		FixType:            m.stats.FixType,
+		CorrectionAge:      m.correctionAge(now),
+		HasCorrections:     m.stats.HasCorrections,
+		ReferenceStationID: m.stats.ReferenceStationID,
+	}
+}
+
+func (m *Monitor) emit(events []Event) {
+	if m.cfg.OnEvent == nil {
+		return
+	}
+	for _, event := range events {
+		m.cfg.OnEvent(event)
+	}
+}
+
+func square(x float64) float64 {
+	return x * x
+}
+
+// formatAge formats an age in whole seconds, such as "12 s".
+func formatAge(age time.Duration) string {
+	return fmt.Sprintf("%d s", int64(age.Round(time.Second)/time.Second))
+}
+
+// formatFixType formats a fix type for a diagnosis, such as "float".
+func formatFixType(fixType erb.FixType) string {
+	switch fixType {
+	case erb.FixTypeNoFix:
+		return "no fix"
+	case erb.FixTypeSingle:
+		return "single"
+	case erb.FixTypeFloat:
+		return "float"
+	case erb.FixTypeRTK:
+		return "RTK"
+	default:
+		return fixType.String()
+	}
+}
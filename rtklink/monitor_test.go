@@ -0,0 +1,202 @@
+package rtklink
+
+import (
+	"encoding"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"go.einride.tech/reach/rtcm3"
+	"gotest.tools/v3/assert"
+)
+
+func marshalFrame(t *testing.T, m encoding.BinaryMarshaler) []byte {
+	t.Helper()
+	payload, err := m.MarshalBinary()
+	assert.NilError(t, err)
+	frame, err := rtcm3.AppendFrame(nil, payload)
+	assert.NilError(t, err)
+	return frame
+}
+
+func stationFrame(t *testing.T, id uint16, xMeters float64) []byte {
+	t.Helper()
+	return marshalFrame(t, &rtcm3.StationARP{
+		MessageType:        rtcm3.MessageTypeStationARP,
+		ReferenceStationID: id,
+		GPS:                true,
+		XMeters:            xMeters,
+		YMeters:            711877.1873,
+		ZMeters:            5349787.0012,
+	})
+}
+
+func msmFrame(t *testing.T, id uint16) []byte {
+	t.Helper()
+	return marshalFrame(t, &rtcm3.MSM{
+		Header: rtcm3.MSMHeader{MessageType: rtcm3.MessageTypeGPSMSM4, ReferenceStationID: id},
+	})
+}
+
+// testStep is a step of a monitor test, performed at an elapsed time since the start of the test.
+type testStep struct {
+	elapsed time.Duration
+	write   []byte
+	fixType *erb.FixType
+	check   bool
+}
+
+func fixType(f erb.FixType) *erb.FixType {
+	return &f
+}
+
+func TestMonitor(t *testing.T) {
+	for _, tt := range []struct {
+		name              string
+		steps             func(t *testing.T) []testStep
+		expectedDiagnoses []string
+		expectedCauses    []Cause
+	}{
+		{
+			name: "stale corrections",
+			steps: func(t *testing.T) []testStep {
+				return []testStep{
+					{elapsed: 0, write: stationFrame(t, 2003, 3370658.5474)},
+					{elapsed: time.Second, fixType: fixType(erb.FixTypeRTK)},
+					{elapsed: 12 * time.Second, fixType: fixType(erb.FixTypeFloat)},
+					{elapsed: 13 * time.Second, write: msmFrame(t, 2003)},
+					{elapsed: 14 * time.Second, fixType: fixType(erb.FixTypeRTK)},
+				}
+			},
+			expectedDiagnoses: []string{
+				"corrections are 12 s old",
+				"float because corrections are 12 s old",
+				"corrections resumed after 13 s",
+				"RTK with corrections 1 s old",
+			},
+			expectedCauses: []Cause{CauseNone, CauseStaleCorrections, CauseNone, CauseNone},
+		},
+		{
+			name: "no corrections",
+			steps: func(t *testing.T) []testStep {
+				return []testStep{
+					{elapsed: 0, fixType: fixType(erb.FixTypeSingle)},
+					{elapsed: time.Second, fixType: fixType(erb.FixTypeNoFix)},
+					{elapsed: 10 * time.Second, check: true},
+				}
+			},
+			expectedDiagnoses: []string{
+				"no fix because no corrections have been received",
+				"no corrections received in 10 s",
+			},
+			expectedCauses: []Cause{CauseNoCorrections, CauseNone},
+		},
+		{
+			name: "base station changed",
+			steps: func(t *testing.T) []testStep {
+				return []testStep{
+					{elapsed: 0, write: stationFrame(t, 2003, 3370658.5474)},
+					{elapsed: 0, write: msmFrame(t, 2003)},
+					{elapsed: 0, fixType: fixType(erb.FixTypeRTK)},
+					{elapsed: time.Second, write: msmFrame(t, 2004)},
+					{elapsed: 2 * time.Second, fixType: fixType(erb.FixTypeFloat)},
+				}
+			},
+			expectedDiagnoses: []string{
+				"base station changed from 2003 to 2004",
+				"float because the base station changed from 2003 to 2004",
+			},
+			expectedCauses: []Cause{CauseNone, CauseBaseStationChanged},
+		},
+		{
+			name: "base position changed",
+			steps: func(t *testing.T) []testStep {
+				return []testStep{
+					{elapsed: 0, write: stationFrame(t, 2003, 3370658.5474)},
+					{elapsed: 0, fixType: fixType(erb.FixTypeRTK)},
+					{elapsed: time.Second, write: stationFrame(t, 2003, 3370658.5474+0.01)},
+					{elapsed: 2 * time.Second, write: stationFrame(t, 2003, 3370658.5474+1.5)},
+					{elapsed: 3 * time.Second, fixType: fixType(erb.FixTypeFloat)},
+					{elapsed: 2 * time.Minute, write: msmFrame(t, 2003)},
+					{elapsed: 2 * time.Minute, fixType: fixType(erb.FixTypeRTK)},
+					{elapsed: 2 * time.Minute, fixType: fixType(erb.FixTypeFloat)},
+				}
+			},
+			expectedDiagnoses: []string{
+				"base station 2003 moved 1.49 m",
+				"float because base station 2003 moved 1.49 m",
+				// corrections are written before the STAT messages, and are not stale
+				"RTK with corrections 0 s old",
+				"float with corrections 0 s old",
+			},
+			expectedCauses: []Cause{CauseNone, CauseBasePositionChanged, CauseNone, CauseNone},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Unix(0, 0).UTC()
+			now := start
+			var diagnoses []string
+			var causes []Cause
+			m := NewMonitor(Config{
+				Now: func() time.Time {
+					return now
+				},
+				OnEvent: func(event Event) {
+					diagnoses = append(diagnoses, event.Diagnosis())
+					causes = append(causes, event.Cause)
+				},
+			})
+			for _, step := range tt.steps(t) {
+				now = start.Add(step.elapsed)
+				switch {
+				case step.write != nil:
+					n, err := m.Write(step.write)
+					assert.NilError(t, err)
+					assert.Equal(t, len(step.write), n)
+				case step.fixType != nil:
+					m.UpdateSTAT(erb.STAT{FixType: *step.fixType, HasFix: true})
+				case step.check:
+					m.Check()
+				}
+			}
+			assert.DeepEqual(t, tt.expectedDiagnoses, diagnoses)
+			assert.DeepEqual(t, tt.expectedCauses, causes)
+		})
+	}
+}
+
+func TestMonitor_Write(t *testing.T) {
+	station := stationFrame(t, 2003, 3370658.5474)
+	msm := msmFrame(t, 2003)
+	corrupt := append([]byte(nil), station...)
+	corrupt[10] ^= 0xff
+	var data []byte
+	data = append(data, 0x01, 0x02) // garbage
+	data = append(data, station...)
+	data = append(data, msm...)
+	data = append(data, corrupt...)
+	m := NewMonitor(Config{})
+	// write in small chunks, splitting the frames
+	for i := 0; i < len(data); i += 7 {
+		end := i + 7
+		if end > len(data) {
+			end = len(data)
+		}
+		_, err := m.Write(data[i:end])
+		assert.NilError(t, err)
+	}
+	stats := m.Stats()
+	assert.Equal(t, uint64(len(data)), stats.Bytes)
+	assert.Equal(t, uint64(2), stats.Messages)
+	assert.Equal(t, uint64(1), stats.DiscardedFrames)
+	assert.DeepEqual(t, map[rtcm3.MessageType]uint64{
+		rtcm3.MessageTypeStationARP: 1,
+		rtcm3.MessageTypeGPSMSM4:    1,
+	}, stats.MessageTypes)
+	assert.Assert(t, stats.HasCorrections)
+	assert.Assert(t, stats.HasReferenceStation)
+	assert.Equal(t, uint16(2003), stats.ReferenceStationID)
+	assert.Assert(t, stats.HasBasePosition)
+	assert.Assert(t, stats.BaseXMeters > 3370658.5 && stats.BaseXMeters < 3370658.6)
+}
@@ -0,0 +1,21 @@
+package sattrack
+
+// Config configures a Tracker.
+type Config struct {
+	// HistoryLength is the number of observations kept in the history of each satellite. Defaults to 60.
+	HistoryLength int
+	// CycleSlipThresholdCycles is the maximum deviation of the carrier phase from its prediction by the Doppler shift,
+	// or by the carrier phase rate of the previous epochs, before a cycle slip is suspected. Defaults to 1 cycle.
+	CycleSlipThresholdCycles float64
+	// OnEvent is an optional callback invoked with each event of the tracker.
+	OnEvent func(Event)
+}
+
+func (c *Config) setDefaults() {
+	if c.HistoryLength <= 0 {
+		c.HistoryLength = 60
+	}
+	if c.CycleSlipThresholdCycles <= 0 {
+		c.CycleSlipThresholdCycles = 1
+	}
+}
@@ -0,0 +1,3 @@
+// Package sattrack provides a satellite tracker, that maintains the state of each satellite observed by a Reach
+// across navigation epochs.
+package sattrack
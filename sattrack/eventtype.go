@@ -0,0 +1,15 @@
+package sattrack
+
+// EventType represents the type of a tracker event.
+type EventType uint8
+
+//go:generate stringer -type EventType -trimprefix EventType
+
+const (
+	// EventTypeAcquired is the event when a satellite is observed after not being observed in the previous epoch.
+	EventTypeAcquired EventType = iota
+	// EventTypeLost is the event when a satellite observed in the previous epoch is no longer observed.
+	EventTypeLost
+	// EventTypeCycleSlip is the event when a cycle slip is suspected from a carrier phase discontinuity.
+	EventTypeCycleSlip
+)
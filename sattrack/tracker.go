@@ -0,0 +1,318 @@
+package sattrack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// Key identifies a satellite.
+type Key struct {
+	// Type of the satellite.
+	Type erb.SVType
+	// ID of the satellite within its constellation.
+	ID uint8
+}
+
+// String returns the key on the form GPS-12.
+func (k Key) String() string {
+	return fmt.Sprintf("%v-%d", k.Type, k.ID)
+}
+
+// Observation is an observation of a satellite in a navigation epoch.
+type Observation struct {
+	// TimeGPS is the time of week in milliseconds of the navigation epoch.
+	TimeGPS uint32
+	// SignalStrength in dB-Hz.
+	SignalStrength float64
+	// CarrierPhase in cycles.
+	CarrierPhase float64
+	// DopplerFrequencyHz is the Doppler shift of the carrier.
+	DopplerFrequencyHz float64
+	// AzimuthDegrees is the azimuth of the satellite (degrees).
+	AzimuthDegrees float64
+	// ElevationDegrees is the elevation of the satellite (degrees).
+	ElevationDegrees float64
+}
+
+// Satellite is the tracked state of a satellite.
+type Satellite struct {
+	Key
+	// FirstSeenTimeGPS is the time of week in milliseconds of the epoch in which the satellite was first observed.
+	FirstSeenTimeGPS uint32
+	// FirstSeen is the UTC time of the epoch in which the satellite was first observed.
+	//
+	// FirstSeen is zero when no STAT message with the GPS week had been received.
+	FirstSeen time.Time
+	// LastSeenTimeGPS is the time of week in milliseconds of the epoch in which the satellite was last observed.
+	LastSeenTimeGPS uint32
+	// LastSeen is the UTC time of the epoch in which the satellite was last observed.
+	//
+	// LastSeen is zero when no STAT message with the GPS week had been received.
+	LastSeen time.Time
+	// Epochs is the number of epochs in which the satellite has been observed.
+	Epochs uint64
+	// Visible is true when the satellite was observed in the last epoch.
+	Visible bool
+	// CycleSlips is the number of suspected cycle slips.
+	CycleSlips uint64
+	// CycleSlipSuspected is true when a cycle slip was suspected in the last observation of the satellite.
+	CycleSlipSuspected bool
+	// History of observations of the satellite, oldest first.
+	History []Observation
+}
+
+// Last returns the last observation of the satellite.
+func (s *Satellite) Last() Observation {
+	if len(s.History) == 0 {
+		return Observation{}
+	}
+	return s.History[len(s.History)-1]
+}
+
+// Summary is a summary of the satellites of a constellation.
+type Summary struct {
+	// Type of the constellation.
+	Type erb.SVType
+	// Tracked is the number of satellites observed since the start of tracking.
+	Tracked int
+	// Visible is the number of satellites observed in the last epoch.
+	Visible int
+	// MeanSignalStrength is the mean signal strength in dB-Hz of the visible satellites.
+	MeanSignalStrength float64
+	// CycleSlips is the number of suspected cycle slips of the satellites.
+	CycleSlips uint64
+}
+
+// Event is an event of a Tracker.
+type Event struct {
+	// Type of the event.
+	Type EventType
+	// Key of the satellite.
+	Key Key
+	// TimeGPS is the time of week in milliseconds of the navigation epoch that caused the event.
+	TimeGPS uint32
+	// DeviationCycles is the deviation of the carrier phase from its prediction, for cycle slip events.
+	DeviationCycles float64
+}
+
+type satelliteState struct {
+	sat          Satellite
+	phaseRate    float64
+	hasPhase     bool
+	hasPhaseRate bool
+}
+
+// Tracker tracks the state of satellites across navigation epochs, from the SVI messages of a Reach.
+//
+// Cycle slips are suspected when the carrier phase of a satellite deviates from a prediction by the mean Doppler shift
+// of its current and previous observations, which follows the dynamics of the receiver. When the Doppler shift is not
+// available, the carrier phase is predicted by the carrier phase rate of the previous two observations. The phase
+// continuity of a satellite is reset when it is not observed in an epoch, or when its carrier phase is not available.
+//
+// The Doppler shift is assumed to be positive for approaching satellites, for which the carrier phase decreases, as
+// in RINEX observations.
+type Tracker struct {
+	cfg         Config
+	satellites  map[Key]*satelliteState
+	timeGPS     uint32
+	hasEpoch    bool
+	weekGPS     uint16
+	weekTimeGPS uint32
+	hasWeekGPS  bool
+}
+
+// NewTracker returns a new Tracker with the provided config.
+func NewTracker(cfg Config) *Tracker {
+	cfg.setDefaults()
+	return &Tracker{cfg: cfg, satellites: map[Key]*satelliteState{}}
+}
+
+// Update the tracker with the current message of the scanner.
+//
+// The SV messages of an SVI message are consumed from the scanner. Messages other than STAT and SVI are ignored.
+func (t *Tracker) Update(sc *erb.Scanner) {
+	switch sc.ID() {
+	case erb.IDSTAT:
+		t.UpdateSTAT(sc.STAT())
+	case erb.IDSVI:
+		svs := make([]erb.SV, 0, sc.SVI().NumSVs)
+		for sc.ScanSVI() {
+			svs = append(svs, sc.SV())
+		}
+		t.UpdateSVI(sc.SVI(), svs)
+	}
+}
+
+// UpdateSTAT updates the GPS week of the tracker from a STAT message.
+func (t *Tracker) UpdateSTAT(stat erb.STAT) {
+	t.weekGPS, t.weekTimeGPS, t.hasWeekGPS = stat.WeekGPS, stat.TimeGPS, true
+}
+
+// UpdateSVI updates the tracker with the satellites observed in a navigation epoch.
+//
+// Repeated updates for the same epoch are ignored.
+func (t *Tracker) UpdateSVI(svi erb.SVI, svs []erb.SV) {
+	if t.hasEpoch && svi.TimeGPS == t.timeGPS {
+		return
+	}
+	t.timeGPS, t.hasEpoch = svi.TimeGPS, true
+	var epochTime time.Time
+	if t.hasWeekGPS {
+		epochTime = erb.UTCTime(erb.ResolveWeekGPS(t.weekGPS, t.weekTimeGPS, svi.TimeGPS), svi.TimeGPS)
+	}
+	observed := make(map[Key]struct{}, len(svs))
+	for _, sv := range svs {
+		key := Key{Type: sv.Type, ID: sv.ID}
+		if _, ok := observed[key]; ok {
+			continue
+		}
+		observed[key] = struct{}{}
+		t.observe(key, svi.TimeGPS, epochTime, sv)
+	}
+	for _, key := range t.sortedKeys() {
+		s := t.satellites[key]
+		if _, ok := observed[key]; ok || !s.sat.Visible {
+			continue
+		}
+		s.sat.Visible = false
+		s.hasPhase, s.hasPhaseRate = false, false
+		t.emit(Event{Type: EventTypeLost, Key: key, TimeGPS: svi.TimeGPS})
+	}
+}
+
+func (t *Tracker) observe(key Key, timeGPS uint32, epochTime time.Time, sv erb.SV) {
+	s, ok := t.satellites[key]
+	if !ok {
+		s = &satelliteState{sat: Satellite{Key: key, FirstSeenTimeGPS: timeGPS, FirstSeen: epochTime}}
+		t.satellites[key] = s
+	}
+	if !s.sat.Visible {
+		s.sat.Visible = true
+		t.emit(Event{Type: EventTypeAcquired, Key: key, TimeGPS: timeGPS})
+	}
+	s.sat.CycleSlipSuspected = false
+	switch {
+	case sv.CarrierPhase == 0:
+		s.hasPhase, s.hasPhaseRate = false, false
+	case s.hasPhase:
+		last := s.sat.Last()
+		dt := erb.ElapsedGPS(last.TimeGPS, timeGPS).Seconds()
+		if dt <= 0 {
+			s.hasPhaseRate = false
+			break
+		}
+		var predicted float64
+		var hasPrediction bool
+		switch {
+		case sv.DopplerFrequencyHz != 0 && last.DopplerFrequencyHz != 0:
+			predicted = last.CarrierPhase - (last.DopplerFrequencyHz+sv.DopplerFrequencyHz)/2*dt
+			hasPrediction = true
+		case s.hasPhaseRate:
+			predicted = last.CarrierPhase + s.phaseRate*dt
+			hasPrediction = true
+		}
+		if hasPrediction {
+			deviation := math.Abs(sv.CarrierPhase - predicted)
+			if deviation > t.cfg.CycleSlipThresholdCycles {
+				s.sat.CycleSlips++
+				s.sat.CycleSlipSuspected = true
+				s.hasPhaseRate = false
+				t.emit(Event{Type: EventTypeCycleSlip, Key: key, TimeGPS: timeGPS, DeviationCycles: deviation})
+				break
+			}
+		}
+		s.phaseRate, s.hasPhaseRate = (sv.CarrierPhase-last.CarrierPhase)/dt, true
+	default:
+		s.hasPhase = true
+	}
+	s.sat.LastSeenTimeGPS = timeGPS
+	s.sat.LastSeen = epochTime
+	s.sat.Epochs++
+	observation := Observation{
+		TimeGPS:            timeGPS,
+		SignalStrength:     sv.SignalStrength,
+		CarrierPhase:       sv.CarrierPhase,
+		DopplerFrequencyHz: sv.DopplerFrequencyHz,
+		AzimuthDegrees:     sv.AzimuthDegrees,
+		ElevationDegrees:   sv.ElevationDegrees,
+	}
+	if len(s.sat.History) < t.cfg.HistoryLength {
+		s.sat.History = append(s.sat.History, observation)
+	} else {
+		copy(s.sat.History, s.sat.History[1:])
+		s.sat.History[len(s.sat.History)-1] = observation
+	}
+}
+
+// Satellite returns the state of the satellite with the provided key.
+func (t *Tracker) Satellite(key Key) (Satellite, bool) {
+	s, ok := t.satellites[key]
+	if !ok {
+		return Satellite{}, false
+	}
+	return s.copy(), true
+}
+
+// Satellites returns the state of all tracked satellites, ordered by type and ID.
+func (t *Tracker) Satellites() []Satellite {
+	keys := t.sortedKeys()
+	result := make([]Satellite, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, t.satellites[key].copy())
+	}
+	return result
+}
+
+// Summaries returns summaries of the tracked constellations, ordered by type.
+func (t *Tracker) Summaries() []Summary {
+	var result []Summary
+	for _, key := range t.sortedKeys() {
+		s := t.satellites[key]
+		if len(result) == 0 || result[len(result)-1].Type != key.Type {
+			result = append(result, Summary{Type: key.Type})
+		}
+		summary := &result[len(result)-1]
+		summary.Tracked++
+		summary.CycleSlips += s.sat.CycleSlips
+		if s.sat.Visible {
+			summary.Visible++
+			summary.MeanSignalStrength += s.sat.Last().SignalStrength
+		}
+	}
+	for i := range result {
+		if result[i].Visible > 0 {
+			result[i].MeanSignalStrength /= float64(result[i].Visible)
+		}
+	}
+	return result
+}
+
+func (t *Tracker) sortedKeys() []Key {
+	keys := make([]Key, 0, len(t.satellites))
+	for key := range t.satellites {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Type != keys[j].Type {
+			return keys[i].Type < keys[j].Type
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	return keys
+}
+
+func (t *Tracker) emit(event Event) {
+	if t.cfg.OnEvent != nil {
+		t.cfg.OnEvent(event)
+	}
+}
+
+func (s *satelliteState) copy() Satellite {
+	sat := s.sat
+	sat.History = append([]Observation(nil), s.sat.History...)
+	return sat
+}
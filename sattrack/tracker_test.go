@@ -0,0 +1,123 @@
+package sattrack
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"gotest.tools/v3/assert"
+)
+
+func TestTracker(t *testing.T) {
+	gps3 := Key{Type: erb.SVTypeGPS, ID: 3}
+	gps12 := Key{Type: erb.SVTypeGPS, ID: 12}
+	gal5 := Key{Type: erb.SVTypeGalileo, ID: 5}
+	var events []Event
+	tracker := NewTracker(Config{
+		HistoryLength: 3,
+		OnEvent: func(event Event) {
+			events = append(events, event)
+		},
+	})
+	tracker.UpdateSTAT(erb.STAT{TimeGPS: 113968400, WeekGPS: 2059})
+	for i, svs := range [][]erb.SV{
+		{
+			{ID: 3, Type: erb.SVTypeGPS, SignalStrength: 40, CarrierPhase: 1000, ElevationDegrees: 30},
+			{ID: 12, Type: erb.SVTypeGPS, SignalStrength: 36, CarrierPhase: 2000},
+		},
+		{
+			{ID: 3, Type: erb.SVTypeGPS, SignalStrength: 41, CarrierPhase: 1100, ElevationDegrees: 31},
+			{ID: 12, Type: erb.SVTypeGPS, SignalStrength: 37, CarrierPhase: 2050},
+			{ID: 5, Type: erb.SVTypeGalileo, SignalStrength: 30},
+		},
+		{
+			{ID: 3, Type: erb.SVTypeGPS, SignalStrength: 42, CarrierPhase: 1200.5, ElevationDegrees: 32},
+			{ID: 12, Type: erb.SVTypeGPS, SignalStrength: 38, CarrierPhase: 2137},
+		},
+		{
+			{ID: 3, Type: erb.SVTypeGPS, SignalStrength: 43, CarrierPhase: 1300.5, ElevationDegrees: 33},
+			{ID: 12, Type: erb.SVTypeGPS, SignalStrength: 38, CarrierPhase: 2187},
+		},
+	} {
+		timeGPS := 113968400 + uint32(i)*1000
+		tracker.UpdateSVI(erb.SVI{TimeGPS: timeGPS, NumSVs: uint8(len(svs))}, svs)
+	}
+	assert.DeepEqual(t, []Event{
+		{Type: EventTypeAcquired, Key: gps3, TimeGPS: 113968400},
+		{Type: EventTypeAcquired, Key: gps12, TimeGPS: 113968400},
+		{Type: EventTypeAcquired, Key: gal5, TimeGPS: 113969400},
+		{Type: EventTypeCycleSlip, Key: gps12, TimeGPS: 113970400, DeviationCycles: 37},
+		{Type: EventTypeLost, Key: gal5, TimeGPS: 113970400},
+	}, events)
+	sat, ok := tracker.Satellite(gps3)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, Satellite{
+		Key:              gps3,
+		FirstSeenTimeGPS: 113968400,
+		FirstSeen:        time.Date(2019, 6, 24, 7, 39, 10, 400e6, time.UTC),
+		LastSeenTimeGPS:  113971400,
+		LastSeen:         time.Date(2019, 6, 24, 7, 39, 13, 400e6, time.UTC),
+		Epochs:           4,
+		Visible:          true,
+		History: []Observation{
+			{TimeGPS: 113969400, SignalStrength: 41, CarrierPhase: 1100, ElevationDegrees: 31},
+			{TimeGPS: 113970400, SignalStrength: 42, CarrierPhase: 1200.5, ElevationDegrees: 32},
+			{TimeGPS: 113971400, SignalStrength: 43, CarrierPhase: 1300.5, ElevationDegrees: 33},
+		},
+	}, sat)
+	sat, ok = tracker.Satellite(gps12)
+	assert.Assert(t, ok)
+	assert.Equal(t, uint64(1), sat.CycleSlips)
+	assert.Assert(t, !sat.CycleSlipSuspected)
+	assert.Equal(t, 3, len(tracker.Satellites()))
+	assert.DeepEqual(t, []Summary{
+		{Type: erb.SVTypeGPS, Tracked: 2, Visible: 2, MeanSignalStrength: 40.5, CycleSlips: 1},
+		{Type: erb.SVTypeGalileo, Tracked: 1},
+	}, tracker.Summaries())
+}
+
+func TestTracker_CycleSlipDoppler(t *testing.T) {
+	gps3 := Key{Type: erb.SVTypeGPS, ID: 3}
+	var events []Event
+	tracker := NewTracker(Config{
+		OnEvent: func(event Event) {
+			if event.Type == EventTypeCycleSlip {
+				events = append(events, event)
+			}
+		},
+	})
+	// a receding satellite, with a Doppler shift changing by the acceleration of the receiver
+	for i, sv := range []erb.SV{
+		{CarrierPhase: 1000, DopplerFrequencyHz: -1000},
+		{CarrierPhase: 2005, DopplerFrequencyHz: -1010},
+		{CarrierPhase: 3025, DopplerFrequencyHz: -1030},
+		{CarrierPhase: 4060, DopplerFrequencyHz: -1040},
+		{CarrierPhase: 5110, DopplerFrequencyHz: -1050}, // 5 cycles slipped
+	} {
+		sv.ID, sv.Type = gps3.ID, gps3.Type
+		tracker.UpdateSVI(erb.SVI{TimeGPS: 1000 + uint32(i)*1000, NumSVs: 1}, []erb.SV{sv})
+	}
+	assert.DeepEqual(t, []Event{
+		{Type: EventTypeCycleSlip, Key: gps3, TimeGPS: 5000, DeviationCycles: 5},
+	}, events)
+}
+
+func TestTracker_Update(t *testing.T) {
+	var buf bytes.Buffer
+	enc := erb.NewEncoder(&buf)
+	assert.NilError(t, enc.EncodeSVI(erb.SVI{TimeGPS: 1000, NumSVs: 2}, []erb.SV{
+		{ID: 3, Type: erb.SVTypeGPS, SignalStrength: 44},
+		{ID: 12, Type: erb.SVTypeGalileo, SignalStrength: 38},
+	}))
+	tracker := NewTracker(Config{})
+	sc := erb.NewScanner(&buf)
+	for sc.Scan() {
+		tracker.Update(sc)
+	}
+	satellites := tracker.Satellites()
+	assert.Equal(t, 2, len(satellites))
+	assert.Equal(t, "GPS-3", satellites[0].Key.String())
+	assert.Equal(t, "Galileo-12", satellites[1].Key.String())
+	assert.Assert(t, satellites[0].FirstSeen.IsZero())
+}
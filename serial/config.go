@@ -0,0 +1,97 @@
+package serial
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Config configures a serial port.
+type Config struct {
+	// Name of the serial port device, for example /dev/ttyUSB0.
+	Name string
+	// BaudRate of the serial port. Defaults to 115200.
+	BaudRate int
+	// DataBits per character, 5 to 8. Defaults to 8.
+	DataBits int
+	// StopBits per character, 1 or 2. Defaults to 1.
+	StopBits int
+	// Parity mode. Defaults to no parity.
+	Parity Parity
+	// FlowControl mode. Defaults to no flow control.
+	FlowControl FlowControl
+}
+
+func (c *Config) setDefaults() {
+	if c.BaudRate == 0 {
+		c.BaudRate = 115200
+	}
+	if c.DataBits == 0 {
+		c.DataBits = 8
+	}
+	if c.StopBits == 0 {
+		c.StopBits = 1
+	}
+}
+
+// ParseURL parses a serial port config from a URL on the form:
+//
+//	serial:///dev/ttyUSB0?baud=115200&databits=8&stopbits=1&parity=none&flow=none
+//
+// Supported parity modes are none, odd and even, and supported flow control modes are none, hardware and software.
+func ParseURL(s string) (Config, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return Config{}, fmt.Errorf("parse serial URL: %w", err)
+	}
+	if u.Scheme != "serial" {
+		return Config{}, fmt.Errorf("parse serial URL %s: unsupported scheme %q", s, u.Scheme)
+	}
+	if u.Host != "" || u.Path == "" {
+		return Config{}, fmt.Errorf("parse serial URL %s: expected serial:///path/to/device", s)
+	}
+	cfg := Config{Name: u.Path}
+	q := u.Query()
+	for key, values := range q {
+		value := values[len(values)-1]
+		switch key {
+		case "baud":
+			if cfg.BaudRate, err = strconv.Atoi(value); err != nil {
+				return Config{}, fmt.Errorf("parse serial URL %s: baud: %w", s, err)
+			}
+		case "databits":
+			if cfg.DataBits, err = strconv.Atoi(value); err != nil {
+				return Config{}, fmt.Errorf("parse serial URL %s: databits: %w", s, err)
+			}
+		case "stopbits":
+			if cfg.StopBits, err = strconv.Atoi(value); err != nil {
+				return Config{}, fmt.Errorf("parse serial URL %s: stopbits: %w", s, err)
+			}
+		case "parity":
+			switch value {
+			case "none":
+				cfg.Parity = ParityNone
+			case "odd":
+				cfg.Parity = ParityOdd
+			case "even":
+				cfg.Parity = ParityEven
+			default:
+				return Config{}, fmt.Errorf("parse serial URL %s: unsupported parity %q", s, value)
+			}
+		case "flow":
+			switch value {
+			case "none":
+				cfg.FlowControl = FlowControlNone
+			case "hardware", "rtscts":
+				cfg.FlowControl = FlowControlHardware
+			case "software", "xonxoff":
+				cfg.FlowControl = FlowControlSoftware
+			default:
+				return Config{}, fmt.Errorf("parse serial URL %s: unsupported flow control %q", s, value)
+			}
+		default:
+			return Config{}, fmt.Errorf("parse serial URL %s: unknown parameter %q", s, key)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,65 @@
+package serial
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseURL(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		url      string
+		expected Config
+		err      string
+	}{
+		{
+			name:     "name only",
+			url:      "serial:///dev/ttyUSB0",
+			expected: Config{Name: "/dev/ttyUSB0"},
+		},
+		{
+			name: "all parameters",
+			url:  "serial:///dev/ttyACM0?baud=57600&databits=7&stopbits=2&parity=even&flow=hardware",
+			expected: Config{
+				Name:        "/dev/ttyACM0",
+				BaudRate:    57600,
+				DataBits:    7,
+				StopBits:    2,
+				Parity:      ParityEven,
+				FlowControl: FlowControlHardware,
+			},
+		},
+		{
+			name: "wrong scheme",
+			url:  "tcp://localhost:9001",
+			err:  `parse serial URL tcp://localhost:9001: unsupported scheme "tcp"`,
+		},
+		{
+			name: "host",
+			url:  "serial://dev/ttyUSB0",
+			err:  "parse serial URL serial://dev/ttyUSB0: expected serial:///path/to/device",
+		},
+		{
+			name: "unknown parity",
+			url:  "serial:///dev/ttyUSB0?parity=mark",
+			err:  `parse serial URL serial:///dev/ttyUSB0?parity=mark: unsupported parity "mark"`,
+		},
+		{
+			name: "unknown parameter",
+			url:  "serial:///dev/ttyUSB0?speed=9600",
+			err:  `parse serial URL serial:///dev/ttyUSB0?speed=9600: unknown parameter "speed"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseURL(tt.url)
+			if tt.err != "" {
+				assert.Error(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
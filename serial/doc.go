@@ -0,0 +1,4 @@
+// Package serial provides a serial port transport for Emlid Reach receivers connected over UART.
+//
+// An open Port implements net.Conn, and can be wrapped in an erb.Scanner or dialed by a reach.Client.
+package serial
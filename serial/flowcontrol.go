@@ -0,0 +1,14 @@
+package serial
+
+// FlowControl represents the flow control mode of a serial port.
+type FlowControl uint8
+
+//go:generate stringer -type FlowControl -trimprefix FlowControl
+
+const (
+	FlowControlNone FlowControl = iota
+	// FlowControlHardware uses the RTS/CTS lines for flow control.
+	FlowControlHardware
+	// FlowControlSoftware uses XON/XOFF characters for flow control.
+	FlowControlSoftware
+)
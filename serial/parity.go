@@ -0,0 +1,12 @@
+package serial
+
+// Parity represents the parity mode of a serial port.
+type Parity uint8
+
+//go:generate stringer -type Parity -trimprefix Parity
+
+const (
+	ParityNone Parity = iota
+	ParityOdd
+	ParityEven
+)
@@ -0,0 +1,77 @@
+package serial
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// Port is an open serial port.
+type Port struct {
+	f    *os.File
+	addr Addr
+}
+
+var _ net.Conn = &Port{}
+
+// Addr is the address of a serial port.
+type Addr struct {
+	// Name of the serial port device.
+	Name string
+}
+
+var _ net.Addr = Addr{}
+
+// Network returns the network name of serial ports.
+func (a Addr) Network() string {
+	return "serial"
+}
+
+// String returns the name of the serial port device.
+func (a Addr) String() string {
+	return a.Name
+}
+
+// Read implements io.Reader.
+func (p *Port) Read(b []byte) (int, error) {
+	return p.f.Read(b)
+}
+
+// Write implements io.Writer.
+func (p *Port) Write(b []byte) (int, error) {
+	return p.f.Write(b)
+}
+
+// Close implements io.Closer.
+func (p *Port) Close() error {
+	if err := p.f.Close(); err != nil {
+		return fmt.Errorf("serial port %s: %w", p.addr.Name, err)
+	}
+	return nil
+}
+
+// LocalAddr implements net.Conn.
+func (p *Port) LocalAddr() net.Addr {
+	return p.addr
+}
+
+// RemoteAddr implements net.Conn.
+func (p *Port) RemoteAddr() net.Addr {
+	return p.addr
+}
+
+// SetDeadline implements net.Conn.
+func (p *Port) SetDeadline(t time.Time) error {
+	return p.f.SetDeadline(t)
+}
+
+// SetReadDeadline implements net.Conn.
+func (p *Port) SetReadDeadline(t time.Time) error {
+	return p.f.SetReadDeadline(t)
+}
+
+// SetWriteDeadline implements net.Conn.
+func (p *Port) SetWriteDeadline(t time.Time) error {
+	return p.f.SetWriteDeadline(t)
+}
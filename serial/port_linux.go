@@ -0,0 +1,129 @@
+package serial
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// baudRates maps baud rates to termios speed flags.
+var baudRates = map[int]uint32{
+	1200:    syscall.B1200,
+	2400:    syscall.B2400,
+	4800:    syscall.B4800,
+	9600:    syscall.B9600,
+	19200:   syscall.B19200,
+	38400:   syscall.B38400,
+	57600:   syscall.B57600,
+	115200:  syscall.B115200,
+	230400:  syscall.B230400,
+	460800:  syscall.B460800,
+	500000:  syscall.B500000,
+	576000:  syscall.B576000,
+	921600:  syscall.B921600,
+	1000000: syscall.B1000000,
+	1152000: syscall.B1152000,
+	1500000: syscall.B1500000,
+	2000000: syscall.B2000000,
+	2500000: syscall.B2500000,
+	3000000: syscall.B3000000,
+	3500000: syscall.B3500000,
+	4000000: syscall.B4000000,
+}
+
+// dataBits maps data bits to termios character size flags.
+var dataBits = map[int]uint32{
+	5: syscall.CS5,
+	6: syscall.CS6,
+	7: syscall.CS7,
+	8: syscall.CS8,
+}
+
+// Open the serial port with the provided config.
+func Open(cfg Config) (*Port, error) {
+	cfg.setDefaults()
+	f, err := os.OpenFile(cfg.Name, os.O_RDWR|syscall.O_NOCTTY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("open serial port %s: %w", cfg.Name, err)
+	}
+	if err := configure(f, &cfg); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("open serial port %s: %w", cfg.Name, err)
+	}
+	return &Port{f: f, addr: Addr{Name: cfg.Name}}, nil
+}
+
+func configure(f *os.File, cfg *Config) error {
+	speed, ok := baudRates[cfg.BaudRate]
+	if !ok {
+		return fmt.Errorf("unsupported baud rate %d", cfg.BaudRate)
+	}
+	size, ok := dataBits[cfg.DataBits]
+	if !ok {
+		return fmt.Errorf("unsupported data bits %d", cfg.DataBits)
+	}
+	var t termios
+	if err := ioctl(f, syscall.TCGETS, unsafe.Pointer(&t)); err != nil {
+		return fmt.Errorf("get termios: %w", err)
+	}
+	// raw mode
+	t.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON | syscall.IXOFF | syscall.INPCK
+	t.Oflag &^= syscall.OPOST
+	t.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	t.Cflag &^= cbaud | syscall.CSIZE | syscall.CSTOPB | syscall.PARENB | syscall.PARODD | crtscts
+	t.Cflag |= syscall.CREAD | syscall.CLOCAL | speed | size
+	t.setSpeed(cfg.BaudRate)
+	switch cfg.StopBits {
+	case 1:
+	case 2:
+		t.Cflag |= syscall.CSTOPB
+	default:
+		return fmt.Errorf("unsupported stop bits %d", cfg.StopBits)
+	}
+	switch cfg.Parity {
+	case ParityNone:
+	case ParityOdd:
+		t.Cflag |= syscall.PARENB | syscall.PARODD
+		t.Iflag |= syscall.INPCK
+	case ParityEven:
+		t.Cflag |= syscall.PARENB
+		t.Iflag |= syscall.INPCK
+	default:
+		return fmt.Errorf("unsupported parity %v", cfg.Parity)
+	}
+	switch cfg.FlowControl {
+	case FlowControlNone:
+	case FlowControlHardware:
+		t.Cflag |= crtscts
+	case FlowControlSoftware:
+		t.Iflag |= syscall.IXON | syscall.IXOFF
+	default:
+		return fmt.Errorf("unsupported flow control %v", cfg.FlowControl)
+	}
+	// block reads until at least one byte is available, the runtime poller handles the blocking
+	t.Cc[syscall.VMIN] = 1
+	t.Cc[syscall.VTIME] = 0
+	if err := ioctl(f, syscall.TCSETS, unsafe.Pointer(&t)); err != nil {
+		return fmt.Errorf("set termios: %w", err)
+	}
+	return nil
+}
+
+func ioctl(f *os.File, request uintptr, arg unsafe.Pointer) error {
+	rawConn, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var errno syscall.Errno
+	if err := rawConn.Control(func(fd uintptr) {
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg))
+	}); err != nil {
+		return err
+	}
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package serial
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+
+	"go.einride.tech/reach/erb"
+	"go.einride.tech/reach/erbsim"
+	"gotest.tools/v3/assert"
+)
+
+func TestOpen_PseudoTerminal(t *testing.T) {
+	master, slaveName := openPseudoTerminal(t)
+	defer func() {
+		assert.NilError(t, master.Close())
+	}()
+	port, err := Open(Config{Name: slaveName, BaudRate: 230400, Parity: ParityNone})
+	assert.NilError(t, err)
+	defer func() {
+		assert.NilError(t, port.Close())
+	}()
+	assert.Equal(t, "serial", port.RemoteAddr().Network())
+	assert.Equal(t, slaveName, port.RemoteAddr().String())
+	sim := erbsim.NewSimulator(erbsim.Config{
+		Waypoints: []erbsim.Waypoint{
+			{LatitudeDegrees: 57.7, LongitudeDegrees: 12.7, FixType: erb.FixTypeRTK},
+		},
+	})
+	const epochs = 5
+	go func() {
+		_ = sim.WriteEpochs(master, epochs)
+	}()
+	assert.NilError(t, port.SetReadDeadline(time.Now().Add(5*time.Second)))
+	sc := erb.NewScanner(port)
+	var n int
+	for n < epochs && sc.Scan() {
+		if sc.ID() == erb.IDPOS {
+			n++
+			assert.Assert(t, sc.POS().LatitudeDegrees > 57.6)
+		}
+	}
+	assert.NilError(t, sc.Err())
+	assert.Equal(t, epochs, n)
+}
+
+func TestOpen_ReadDeadline(t *testing.T) {
+	master, slaveName := openPseudoTerminal(t)
+	defer func() {
+		assert.NilError(t, master.Close())
+	}()
+	port, err := Open(Config{Name: slaveName})
+	assert.NilError(t, err)
+	defer func() {
+		assert.NilError(t, port.Close())
+	}()
+	assert.NilError(t, port.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+	_, err = port.Read(make([]byte, 1))
+	assert.Assert(t, os.IsTimeout(err), "%v", err)
+}
+
+func TestOpen_Termios(t *testing.T) {
+	master, slaveName := openPseudoTerminal(t)
+	defer func() {
+		assert.NilError(t, master.Close())
+	}()
+	port, err := Open(Config{
+		Name:        slaveName,
+		BaudRate:    115200,
+		StopBits:    2,
+		FlowControl: FlowControlHardware,
+	})
+	assert.NilError(t, err)
+	defer func() {
+		assert.NilError(t, port.Close())
+	}()
+	var actual termios
+	assert.NilError(t, ioctl(port.f, syscall.TCGETS, unsafe.Pointer(&actual)))
+	assert.Equal(t, uint32(syscall.B115200), actual.Cflag&cbaud)
+	assert.Equal(t, uint32(syscall.CS8), actual.Cflag&syscall.CSIZE)
+	assert.Equal(t, uint32(syscall.CSTOPB), actual.Cflag&syscall.CSTOPB)
+	assert.Equal(t, uint32(crtscts), actual.Cflag&crtscts)
+	assert.Equal(t, uint32(0), actual.Lflag&syscall.ICANON)
+	assert.Equal(t, uint8(1), actual.Cc[syscall.VMIN])
+	assert.Equal(t, uint8(0), actual.Cc[syscall.VTIME])
+}
+
+func TestOpen_UnsupportedBaudRate(t *testing.T) {
+	master, slaveName := openPseudoTerminal(t)
+	defer func() {
+		assert.NilError(t, master.Close())
+	}()
+	_, err := Open(Config{Name: slaveName, BaudRate: 1234})
+	assert.Error(t, err, fmt.Sprintf("open serial port %s: unsupported baud rate 1234", slaveName))
+}
+
+func openPseudoTerminal(t *testing.T) (*os.File, string) {
+	t.Helper()
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("pseudo-terminals not available: %v", err)
+	}
+	var unlock int32
+	if err := ioctl(master, syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
+		_ = master.Close()
+		t.Skipf("unlock pseudo-terminal: %v", err)
+	}
+	var n uint32
+	if err := ioctl(master, syscall.TIOCGPTN, unsafe.Pointer(&n)); err != nil {
+		_ = master.Close()
+		t.Skipf("get pseudo-terminal number: %v", err)
+	}
+	return master, fmt.Sprintf("/dev/pts/%d", n)
+}
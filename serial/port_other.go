@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package serial
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Open the serial port with the provided config.
+func Open(cfg Config) (*Port, error) {
+	return nil, fmt.Errorf("open serial port %s: unsupported OS %s", cfg.Name, runtime.GOOS)
+}
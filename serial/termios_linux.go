@@ -0,0 +1,23 @@
+//go:build linux && !mips && !mipsle && !mips64 && !mips64le && !ppc64 && !ppc64le
+// +build linux,!mips,!mipsle,!mips64,!mips64le,!ppc64,!ppc64le
+
+package serial
+
+// termios flags missing from package syscall, with the values of the generic Linux architectures.
+const (
+	cbaud   = 0x100f
+	crtscts = 0x80000000
+)
+
+// termios is the kernel termios structure of the TCGETS and TCSETS ioctls, on the generic Linux architectures.
+type termios struct {
+	Iflag uint32
+	Oflag uint32
+	Cflag uint32
+	Lflag uint32
+	Line  uint8
+	Cc    [19]uint8
+}
+
+// setSpeed has no effect, since the speed is only set by the baud rate flags of Cflag.
+func (t *termios) setSpeed(int) {}
@@ -0,0 +1,31 @@
+//go:build linux && (ppc64 || ppc64le)
+// +build linux
+// +build ppc64 ppc64le
+
+package serial
+
+// termios flags missing from package syscall, with the values of the PowerPC Linux architectures.
+const (
+	cbaud   = 0xff
+	crtscts = 0x80000000
+)
+
+// termios is the kernel termios structure of the TCGETS and TCSETS ioctls, on the PowerPC Linux architectures.
+//
+// Unlike the other architectures, the control characters precede the line discipline, and the speeds are included.
+type termios struct {
+	Iflag  uint32
+	Oflag  uint32
+	Cflag  uint32
+	Lflag  uint32
+	Cc     [19]uint8
+	Line   uint8
+	Ispeed uint32
+	Ospeed uint32
+}
+
+// setSpeed sets the input and output speeds in bits per second, along with the baud rate flags of Cflag.
+func (t *termios) setSpeed(baudRate int) {
+	t.Ispeed = uint32(baudRate)
+	t.Ospeed = uint32(baudRate)
+}
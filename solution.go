@@ -0,0 +1,147 @@
+package reach
+
+import (
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// Solution is a navigation solution of a single navigation epoch.
+type Solution struct {
+	// WeekGPS is the week number of the navigation epoch.
+	WeekGPS uint16
+	// TimeGPS is the time of week in milliseconds of the navigation epoch.
+	TimeGPS uint32
+	// HasStatus is true when the status fields of the solution were received in the epoch.
+	HasStatus bool
+	// FixType is the fix type.
+	FixType erb.FixType
+	// HasFix is true when position and velocity are valid.
+	HasFix bool
+	// NumSVs is the number of used space vehicles.
+	NumSVs uint8
+	// HasPosition is true when the position fields of the solution were received in the epoch.
+	HasPosition bool
+	// Latitude component (degrees).
+	LatitudeDegrees float64
+	// Longitude component (degrees).
+	LongitudeDegrees float64
+	// AltitudeEllipsoid is the height above ellipsoid (m).
+	AltitudeEllipsoidMeters float64
+	// AltitudeMeanSeaLevel is the height above mean sea level (m).
+	AltitudeMeanSeaLevelMeters float64
+	// HorizontalAccuracy is the horizontal accuracy estimate (m).
+	HorizontalAccuracyMeters float64
+	// VerticalAccuracy is the vertical accuracy estimate (m).
+	VerticalAccuracyMeters float64
+	// HasVelocity is true when the velocity fields of the solution were received in the epoch.
+	HasVelocity bool
+	// North velocity component (m/s).
+	NorthMetersPerSecond float64
+	// East velocity component (m/s).
+	EastMetersPerSecond float64
+	// Down velocity component (m/s).
+	DownMetersPerSecond float64
+	// Speed is the 2D ground speed (m/s).
+	SpeedMetersPerSecond float64
+	// Heading is the 2D heading of motion (degrees).
+	HeadingDegrees float64
+	// SpeedAccuracy is the speed accuracy estimate (m/s).
+	SpeedAccuracyMetersPerSecond float64
+	// HasDOP is true when the DOP fields of the solution were received in the epoch.
+	HasDOP bool
+	// GeometricDOP is the geometric dilution of precision.
+	GeometricDOP float64
+	// PositionDOP is the position dilution of precision.
+	PositionDOP float64
+	// VerticalDOP is the vertical dilution of precision.
+	VerticalDOP float64
+	// HorizontalDOP is the horizontal dilution of precision.
+	HorizontalDOP float64
+	// HasSatellites is true when the satellites of the solution were received in the epoch.
+	HasSatellites bool
+	// Satellites used in the solution.
+	Satellites []erb.SV
+}
+
+// Time returns the UTC time of the navigation epoch.
+func (s *Solution) Time() time.Time {
+	return erb.UTCTime(s.WeekGPS, s.TimeGPS)
+}
+
+// IsComplete returns true when all parts of the solution were received in the epoch.
+func (s *Solution) IsComplete() bool {
+	return s.HasStatus && s.HasPosition && s.HasVelocity && s.HasDOP && s.HasSatellites
+}
+
+func (s *Solution) setSTAT(stat erb.STAT) {
+	s.HasStatus = true
+	s.WeekGPS = stat.WeekGPS
+	s.FixType = stat.FixType
+	s.HasFix = stat.HasFix
+	s.NumSVs = stat.NumSVs
+}
+
+func (s *Solution) setPOS(pos erb.POS) {
+	s.HasPosition = true
+	s.LatitudeDegrees = pos.LatitudeDegrees
+	s.LongitudeDegrees = pos.LongitudeDegrees
+	s.AltitudeEllipsoidMeters = pos.AltitudeEllipsoidMeters
+	s.AltitudeMeanSeaLevelMeters = pos.AltitudeMeanSeaLevelMeters
+	s.HorizontalAccuracyMeters = float64(pos.HorizontalAccuracyMillimeters) / 1e3
+	s.VerticalAccuracyMeters = float64(pos.VerticalAccuracyMillimeters) / 1e3
+}
+
+func (s *Solution) setVEL(vel erb.VEL) {
+	s.HasVelocity = true
+	s.NorthMetersPerSecond = float64(vel.NorthCentimetersPerSecond) / 1e2
+	s.EastMetersPerSecond = float64(vel.EastCentimetersPerSecond) / 1e2
+	s.DownMetersPerSecond = float64(vel.DownCentimetersPerSecond) / 1e2
+	s.SpeedMetersPerSecond = float64(vel.SpeedCentimetersPerSecond) / 1e2
+	s.HeadingDegrees = vel.HeadingDegrees
+	s.SpeedAccuracyMetersPerSecond = float64(vel.SpeedAccuracyCentimetersPerSecond) / 1e2
+}
+
+func (s *Solution) setDOPS(dops erb.DOPS) {
+	s.HasDOP = true
+	s.GeometricDOP = dops.Geometric
+	s.PositionDOP = dops.Position
+	s.VerticalDOP = dops.Vertical
+	s.HorizontalDOP = dops.Horizontal
+}
+
+// holdFrom fills the parts of the solution that were not received in the epoch from the previous solution.
+//
+// The Has fields are left unchanged, and report which parts were received in the epoch.
+func (s *Solution) holdFrom(prev *Solution) {
+	if !s.HasStatus {
+		s.FixType = prev.FixType
+		s.HasFix = prev.HasFix
+		s.NumSVs = prev.NumSVs
+	}
+	if !s.HasPosition {
+		s.LatitudeDegrees = prev.LatitudeDegrees
+		s.LongitudeDegrees = prev.LongitudeDegrees
+		s.AltitudeEllipsoidMeters = prev.AltitudeEllipsoidMeters
+		s.AltitudeMeanSeaLevelMeters = prev.AltitudeMeanSeaLevelMeters
+		s.HorizontalAccuracyMeters = prev.HorizontalAccuracyMeters
+		s.VerticalAccuracyMeters = prev.VerticalAccuracyMeters
+	}
+	if !s.HasVelocity {
+		s.NorthMetersPerSecond = prev.NorthMetersPerSecond
+		s.EastMetersPerSecond = prev.EastMetersPerSecond
+		s.DownMetersPerSecond = prev.DownMetersPerSecond
+		s.SpeedMetersPerSecond = prev.SpeedMetersPerSecond
+		s.HeadingDegrees = prev.HeadingDegrees
+		s.SpeedAccuracyMetersPerSecond = prev.SpeedAccuracyMetersPerSecond
+	}
+	if !s.HasDOP {
+		s.GeometricDOP = prev.GeometricDOP
+		s.PositionDOP = prev.PositionDOP
+		s.VerticalDOP = prev.VerticalDOP
+		s.HorizontalDOP = prev.HorizontalDOP
+	}
+	if !s.HasSatellites {
+		s.Satellites = prev.Satellites
+	}
+}
@@ -0,0 +1,135 @@
+package reach
+
+import (
+	"go.einride.tech/reach/erb"
+)
+
+// SolutionScanner provides a convenient interface for reading complete navigation solutions from an ERB stream.
+//
+// The messages of each navigation epoch are correlated by their TimeGPS, and a Solution is emitted when all
+// messages of the epoch have been received, or when the next epoch starts. Late messages of the previous epoch, such
+// as retransmissions after a complete epoch, are ignored.
+type SolutionScanner struct {
+	sc         *erb.Scanner
+	policy     IncompleteEpochPolicy
+	pending    Solution
+	hasPending bool
+	last       Solution
+	hasLast    bool
+	solution   Solution
+	weekGPS    uint16
+	hasWeekGPS bool
+	// previousTimeGPS is the time of week of the previous epoch, for ignoring late messages.
+	previousTimeGPS  uint32
+	hasPreviousEpoch bool
+}
+
+// NewSolutionScanner returns a new SolutionScanner reading from sc, handling incomplete epochs according to policy.
+func NewSolutionScanner(sc *erb.Scanner, policy IncompleteEpochPolicy) *SolutionScanner {
+	return &SolutionScanner{sc: sc, policy: policy}
+}
+
+// Scan advances the SolutionScanner to the next solution, which will then be available through the Solution method.
+func (s *SolutionScanner) Scan() bool {
+	for s.sc.Scan() {
+		timeGPS, ok := s.sc.TimeGPS()
+		if !ok {
+			continue
+		}
+		if s.hasPreviousEpoch && timeGPS == s.previousTimeGPS {
+			// a late message of the previous epoch
+			continue
+		}
+		if s.hasPending && s.pending.TimeGPS != timeGPS {
+			prev := s.pending
+			s.finishEpoch()
+			s.startEpoch(timeGPS)
+			s.addMessage()
+			if s.emit(prev) {
+				return true
+			}
+			continue
+		}
+		if !s.hasPending {
+			s.startEpoch(timeGPS)
+		}
+		s.addMessage()
+		if s.pending.IsComplete() {
+			s.finishEpoch()
+			if s.emit(s.pending) {
+				return true
+			}
+		}
+	}
+	if s.hasPending {
+		s.finishEpoch()
+		if s.emit(s.pending) {
+			return true
+		}
+	}
+	return false
+}
+
+// Solution returns the current solution.
+func (s *SolutionScanner) Solution() Solution {
+	return s.solution
+}
+
+// Err returns the first non-EOF error encountered by the underlying scanner.
+func (s *SolutionScanner) Err() error {
+	return s.sc.Err()
+}
+
+func (s *SolutionScanner) startEpoch(timeGPS uint32) {
+	// handle week rollover for epochs without a STAT message
+	if s.hasWeekGPS {
+		s.weekGPS = erb.ResolveWeekGPS(s.weekGPS, s.pending.TimeGPS, timeGPS)
+	}
+	s.pending = Solution{TimeGPS: timeGPS, WeekGPS: s.weekGPS}
+	s.hasPending = true
+}
+
+func (s *SolutionScanner) finishEpoch() {
+	s.hasPending = false
+	s.previousTimeGPS, s.hasPreviousEpoch = s.pending.TimeGPS, true
+}
+
+func (s *SolutionScanner) addMessage() {
+	switch s.sc.ID() {
+	case erb.IDSTAT:
+		stat := s.sc.STAT()
+		s.pending.setSTAT(stat)
+		s.weekGPS = stat.WeekGPS
+		s.hasWeekGPS = true
+	case erb.IDPOS:
+		s.pending.setPOS(s.sc.POS())
+	case erb.IDVEL:
+		s.pending.setVEL(s.sc.VEL())
+	case erb.IDDOPS:
+		s.pending.setDOPS(s.sc.DOPS())
+	case erb.IDSVI:
+		s.pending.HasSatellites = true
+		s.pending.Satellites = make([]erb.SV, 0, s.sc.SVI().NumSVs)
+		for s.sc.ScanSVI() {
+			s.pending.Satellites = append(s.pending.Satellites, s.sc.SV())
+		}
+	}
+}
+
+func (s *SolutionScanner) emit(solution Solution) bool {
+	if !solution.IsComplete() {
+		switch s.policy {
+		case IncompleteEpochPolicyDrop:
+			return false
+		case IncompleteEpochPolicyHoldLast:
+			if s.hasLast {
+				solution.holdFrom(&s.last)
+			}
+		case IncompleteEpochPolicyEmit:
+		}
+	}
+	s.solution = solution
+	s.last = solution
+	s.hasLast = true
+	return true
+}
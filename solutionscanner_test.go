@@ -0,0 +1,131 @@
+package reach
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"go.einride.tech/reach/erbsim"
+	"gotest.tools/v3/assert"
+)
+
+func TestSolutionScanner(t *testing.T) {
+	sim := newTestSimulator()
+	var buf bytes.Buffer
+	assert.NilError(t, sim.WriteEpochs(&buf, 3))
+	sc := NewSolutionScanner(erb.NewScanner(&buf), IncompleteEpochPolicyEmit)
+	var solutions []Solution
+	for sc.Scan() {
+		solutions = append(solutions, sc.Solution())
+	}
+	assert.NilError(t, sc.Err())
+	assert.Equal(t, 3, len(solutions))
+	for i, solution := range solutions {
+		epoch := sim.Epoch(i)
+		assert.Assert(t, solution.IsComplete())
+		assert.Equal(t, epoch.STAT.WeekGPS, solution.WeekGPS)
+		assert.Equal(t, epoch.STAT.TimeGPS, solution.TimeGPS)
+		assert.Assert(t, epoch.STAT.Time().Equal(solution.Time()))
+		assert.Equal(t, epoch.STAT.FixType, solution.FixType)
+		assert.Equal(t, epoch.POS.LatitudeDegrees, solution.LatitudeDegrees)
+		assert.Equal(t, float64(epoch.VEL.EastCentimetersPerSecond)/100, solution.EastMetersPerSecond)
+		assert.Equal(t, len(epoch.SVs), len(solution.Satellites))
+	}
+}
+
+func TestSolutionScanner_IncompleteEpochs(t *testing.T) {
+	sim := newTestSimulator()
+	// write 3 epochs, where the second epoch is missing its POS message
+	var buf bytes.Buffer
+	enc := erb.NewEncoder(&buf)
+	for i := 0; i < 3; i++ {
+		epoch := sim.Epoch(i)
+		if i != 1 {
+			assert.NilError(t, enc.EncodePOS(epoch.POS))
+		}
+		assert.NilError(t, enc.EncodeSTAT(epoch.STAT))
+		assert.NilError(t, enc.EncodeDOPS(epoch.DOPS))
+		assert.NilError(t, enc.EncodeVEL(epoch.VEL))
+		assert.NilError(t, enc.EncodeSVI(epoch.SVI, epoch.SVs))
+	}
+	for _, tt := range []struct {
+		policy            IncompleteEpochPolicy
+		expectedTimes     []uint32
+		expectedLatitude1 float64
+	}{
+		{
+			policy:        IncompleteEpochPolicyEmit,
+			expectedTimes: []uint32{1000, 2000, 3000},
+		},
+		{
+			policy:        IncompleteEpochPolicyDrop,
+			expectedTimes: []uint32{1000, 3000},
+		},
+		{
+			policy:            IncompleteEpochPolicyHoldLast,
+			expectedTimes:     []uint32{1000, 2000, 3000},
+			expectedLatitude1: sim.Epoch(0).POS.LatitudeDegrees,
+		},
+	} {
+		tt := tt
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			sc := NewSolutionScanner(erb.NewScanner(bytes.NewReader(buf.Bytes())), tt.policy)
+			var solutions []Solution
+			for sc.Scan() {
+				solutions = append(solutions, sc.Solution())
+			}
+			assert.NilError(t, sc.Err())
+			times := make([]uint32, 0, len(solutions))
+			for _, solution := range solutions {
+				times = append(times, solution.TimeGPS)
+			}
+			assert.DeepEqual(t, tt.expectedTimes, times)
+			if len(solutions) == 3 {
+				assert.Assert(t, !solutions[1].IsComplete())
+				assert.Assert(t, !solutions[1].HasPosition)
+				assert.Assert(t, solutions[1].HasVelocity)
+				assert.Equal(t, tt.expectedLatitude1, solutions[1].LatitudeDegrees)
+			}
+		})
+	}
+}
+
+func TestSolutionScanner_LateMessages(t *testing.T) {
+	sim := newTestSimulator()
+	// write 2 epochs, where the SVI message of the first epoch is retransmitted after the epoch is complete
+	var buf bytes.Buffer
+	enc := erb.NewEncoder(&buf)
+	for i := 0; i < 2; i++ {
+		epoch := sim.Epoch(i)
+		assert.NilError(t, enc.EncodePOS(epoch.POS))
+		assert.NilError(t, enc.EncodeSTAT(epoch.STAT))
+		assert.NilError(t, enc.EncodeDOPS(epoch.DOPS))
+		assert.NilError(t, enc.EncodeVEL(epoch.VEL))
+		assert.NilError(t, enc.EncodeSVI(epoch.SVI, epoch.SVs))
+		if i == 0 {
+			assert.NilError(t, enc.EncodeSVI(epoch.SVI, epoch.SVs))
+		}
+	}
+	sc := NewSolutionScanner(erb.NewScanner(&buf), IncompleteEpochPolicyEmit)
+	var times []uint32
+	for sc.Scan() {
+		solution := sc.Solution()
+		assert.Assert(t, solution.IsComplete())
+		times = append(times, solution.TimeGPS)
+	}
+	assert.NilError(t, sc.Err())
+	assert.DeepEqual(t, []uint32{1000, 2000}, times)
+}
+
+func newTestSimulator() *erbsim.Simulator {
+	return erbsim.NewSimulator(erbsim.Config{
+		Interval: time.Second,
+		WeekGPS:  2059,
+		TimeGPS:  1000,
+		Waypoints: []erbsim.Waypoint{
+			{Time: 0, LatitudeDegrees: 57.7, LongitudeDegrees: 12.7, FixType: erb.FixTypeRTK},
+			{Time: 10 * time.Second, LatitudeDegrees: 57.7, LongitudeDegrees: 12.8, FixType: erb.FixTypeRTK},
+		},
+	})
+}
@@ -0,0 +1,2 @@
+// Package track provides export of navigation solutions from ERB streams to map formats, such as GPX and KML.
+package track
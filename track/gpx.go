@@ -0,0 +1,119 @@
+package track
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// GPXExtensionsNamespace is the XML namespace of the GPX extensions written by WriteGPX.
+const GPXExtensionsNamespace = "https://go.einride.tech/reach/gpx/v1"
+
+type gpxFile struct {
+	XMLName        xml.Name `xml:"gpx"`
+	Version        string   `xml:"version,attr"`
+	Creator        string   `xml:"creator,attr"`
+	Namespace      string   `xml:"xmlns,attr"`
+	ReachNamespace string   `xml:"xmlns:reach,attr"`
+	Track          gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Name    string          `xml:"name,omitempty"`
+	Segment gpxTrackSegment `xml:"trkseg"`
+}
+
+type gpxTrackSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+type gpxPoint struct {
+	Latitude   string        `xml:"lat,attr"`
+	Longitude  string        `xml:"lon,attr"`
+	Elevation  string        `xml:"ele"`
+	Time       string        `xml:"time"`
+	Fix        string        `xml:"fix,omitempty"`
+	Sat        uint8         `xml:"sat"`
+	HDOP       string        `xml:"hdop,omitempty"`
+	VDOP       string        `xml:"vdop,omitempty"`
+	PDOP       string        `xml:"pdop,omitempty"`
+	Extensions gpxExtensions `xml:"extensions"`
+}
+
+type gpxExtensions struct {
+	FixType                 erb.FixType `xml:"reach:fixType"`
+	AltitudeEllipsoidMeters string      `xml:"reach:altitudeEllipsoid"`
+}
+
+// WriteGPX writes the track as a GPX 1.1 document.
+//
+// The elevation of each point is the height above mean sea level, and each point has the fix type and the height
+// above ellipsoid as extensions in the GPXExtensionsNamespace.
+func WriteGPX(w io.Writer, t *Track) error {
+	file := gpxFile{
+		Version:        "1.1",
+		Creator:        "go.einride.tech/reach",
+		Namespace:      "http://www.topografix.com/GPX/1/1",
+		ReachNamespace: GPXExtensionsNamespace,
+		Track:          gpxTrack{Name: t.Name},
+	}
+	file.Track.Segment.Points = make([]gpxPoint, 0, len(t.Points))
+	for _, p := range t.Points {
+		point := gpxPoint{
+			Latitude:  formatFloat(p.LatitudeDegrees, 9),
+			Longitude: formatFloat(p.LongitudeDegrees, 9),
+			Elevation: formatFloat(p.AltitudeMeanSeaLevelMeters, 3),
+			Time:      formatTime(p.Time),
+			Fix:       gpxFix(p.FixType),
+			Sat:       p.NumSVs,
+			Extensions: gpxExtensions{
+				FixType:                 p.FixType,
+				AltitudeEllipsoidMeters: formatFloat(p.AltitudeEllipsoidMeters, 3),
+			},
+		}
+		if p.HasDOP {
+			point.HDOP = formatFloat(p.HorizontalDOP, 2)
+			point.VDOP = formatFloat(p.VerticalDOP, 2)
+			point.PDOP = formatFloat(p.PositionDOP, 2)
+		}
+		file.Track.Segment.Points = append(file.Track.Segment.Points, point)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write GPX: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return fmt.Errorf("write GPX: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("write GPX: %w", err)
+	}
+	return nil
+}
+
+// gpxFix returns the GPX fix value of a fix type.
+func gpxFix(fixType erb.FixType) string {
+	switch fixType {
+	case erb.FixTypeNoFix:
+		return "none"
+	case erb.FixTypeSingle:
+		return "3d"
+	case erb.FixTypeFloat, erb.FixTypeRTK:
+		return "dgps"
+	default:
+		return ""
+	}
+}
+
+func formatFloat(f float64, precision int) string {
+	return strconv.FormatFloat(f, 'f', precision, 64)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
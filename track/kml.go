@@ -0,0 +1,126 @@
+package track
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.einride.tech/reach/erb"
+)
+
+// KMLColors are the line colors of each fix type in KML documents, on the KML form aabbggrr.
+//
+// Fix types without a color are drawn in gray.
+var KMLColors = map[erb.FixType]string{
+	erb.FixTypeSingle: "ff0000ff", // red
+	erb.FixTypeFloat:  "ff00ffff", // yellow
+	erb.FixTypeRTK:    "ff00ff00", // green
+}
+
+// kmlDefaultColor is the line color of fix types without a color.
+const kmlDefaultColor = "ff808080"
+
+// kmlLineWidth is the line width of track segments.
+const kmlLineWidth = 4
+
+type kmlFile struct {
+	XMLName   xml.Name    `xml:"kml"`
+	Namespace string      `xml:"xmlns,attr"`
+	Document  kmlDocument `xml:"Document"`
+}
+
+type kmlDocument struct {
+	Name       string         `xml:"name,omitempty"`
+	Styles     []kmlStyle     `xml:"Style"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlStyle struct {
+	ID        string       `xml:"id,attr"`
+	LineStyle kmlLineStyle `xml:"LineStyle"`
+}
+
+type kmlLineStyle struct {
+	Color string `xml:"color"`
+	Width int    `xml:"width"`
+}
+
+type kmlPlacemark struct {
+	Name       string        `xml:"name"`
+	TimeSpan   kmlTimeSpan   `xml:"TimeSpan"`
+	StyleURL   string        `xml:"styleUrl"`
+	LineString kmlLineString `xml:"LineString"`
+}
+
+type kmlTimeSpan struct {
+	Begin string `xml:"begin"`
+	End   string `xml:"end"`
+}
+
+type kmlLineString struct {
+	AltitudeMode string `xml:"altitudeMode"`
+	Coordinates  string `xml:"coordinates"`
+}
+
+// WriteKML writes the track as a KML document, with one line per segment colored by the fix type of the segment.
+//
+// The altitude of each point is the height above mean sea level.
+func WriteKML(w io.Writer, t *Track) error {
+	file := kmlFile{
+		Namespace: "http://www.opengis.net/kml/2.2",
+		Document:  kmlDocument{Name: t.Name},
+	}
+	segments := t.Segments()
+	hasStyle := map[erb.FixType]bool{}
+	for _, segment := range segments {
+		if !hasStyle[segment.FixType] {
+			hasStyle[segment.FixType] = true
+			color, ok := KMLColors[segment.FixType]
+			if !ok {
+				color = kmlDefaultColor
+			}
+			file.Document.Styles = append(file.Document.Styles, kmlStyle{
+				ID:        kmlStyleID(segment.FixType),
+				LineStyle: kmlLineStyle{Color: color, Width: kmlLineWidth},
+			})
+		}
+		var coordinates strings.Builder
+		for i, p := range segment.Points {
+			if i > 0 {
+				coordinates.WriteByte(' ')
+			}
+			coordinates.WriteString(formatFloat(p.LongitudeDegrees, 9))
+			coordinates.WriteByte(',')
+			coordinates.WriteString(formatFloat(p.LatitudeDegrees, 9))
+			coordinates.WriteByte(',')
+			coordinates.WriteString(formatFloat(p.AltitudeMeanSeaLevelMeters, 3))
+		}
+		first, last := segment.Points[0], segment.Points[len(segment.Points)-1]
+		file.Document.Placemarks = append(file.Document.Placemarks, kmlPlacemark{
+			Name:     segment.FixType.String(),
+			TimeSpan: kmlTimeSpan{Begin: formatTime(first.Time), End: formatTime(last.Time)},
+			StyleURL: "#" + kmlStyleID(segment.FixType),
+			LineString: kmlLineString{
+				AltitudeMode: "absolute",
+				Coordinates:  coordinates.String(),
+			},
+		})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write KML: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return fmt.Errorf("write KML: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("write KML: %w", err)
+	}
+	return nil
+}
+
+func kmlStyleID(fixType erb.FixType) string {
+	return "fixType" + fixType.String()
+}
@@ -0,0 +1,104 @@
+package track
+
+import (
+	"fmt"
+	"time"
+
+	"go.einride.tech/reach"
+	"go.einride.tech/reach/erb"
+)
+
+// Point is a point of a track.
+type Point struct {
+	// Time of the navigation epoch (UTC).
+	Time time.Time
+	// Latitude component (degrees).
+	LatitudeDegrees float64
+	// Longitude component (degrees).
+	LongitudeDegrees float64
+	// AltitudeEllipsoid is the height above ellipsoid (m).
+	AltitudeEllipsoidMeters float64
+	// AltitudeMeanSeaLevel is the height above mean sea level (m).
+	AltitudeMeanSeaLevelMeters float64
+	// FixType is the fix type.
+	FixType erb.FixType
+	// NumSVs is the number of used space vehicles.
+	NumSVs uint8
+	// HasDOP is true when the DOP fields of the point are valid.
+	HasDOP bool
+	// HorizontalDOP is the horizontal dilution of precision.
+	HorizontalDOP float64
+	// VerticalDOP is the vertical dilution of precision.
+	VerticalDOP float64
+	// PositionDOP is the position dilution of precision.
+	PositionDOP float64
+}
+
+// Track is a named sequence of points.
+type Track struct {
+	// Name of the track.
+	Name string
+	// Points of the track, in time order.
+	Points []Point
+}
+
+// Segment is a sequence of consecutive points of a track with the same fix type.
+type Segment struct {
+	// FixType of the points in the segment.
+	FixType erb.FixType
+	// Points of the segment.
+	Points []Point
+}
+
+// Read a track from an ERB stream.
+//
+// A point is added for each navigation epoch with a position and a fix. Status and DOP fields missing from an epoch
+// are held from the previous epoch.
+func Read(sc *erb.Scanner) ([]Point, error) {
+	ss := reach.NewSolutionScanner(sc, reach.IncompleteEpochPolicyHoldLast)
+	var points []Point
+	var hasDOP bool
+	for ss.Scan() {
+		solution := ss.Solution()
+		hasDOP = hasDOP || solution.HasDOP
+		if !solution.HasPosition || solution.FixType == erb.FixTypeNoFix {
+			continue
+		}
+		points = append(points, Point{
+			Time:                       solution.Time(),
+			LatitudeDegrees:            solution.LatitudeDegrees,
+			LongitudeDegrees:           solution.LongitudeDegrees,
+			AltitudeEllipsoidMeters:    solution.AltitudeEllipsoidMeters,
+			AltitudeMeanSeaLevelMeters: solution.AltitudeMeanSeaLevelMeters,
+			FixType:                    solution.FixType,
+			NumSVs:                     solution.NumSVs,
+			HasDOP:                     hasDOP,
+			HorizontalDOP:              solution.HorizontalDOP,
+			VerticalDOP:                solution.VerticalDOP,
+			PositionDOP:                solution.PositionDOP,
+		})
+	}
+	if err := ss.Err(); err != nil {
+		return nil, fmt.Errorf("read track: %w", err)
+	}
+	return points, nil
+}
+
+// Segments returns the track split into segments of consecutive points with the same fix type.
+//
+// Each segment after the first starts with the last point of the previous segment, to keep the track continuous.
+func (t *Track) Segments() []Segment {
+	var segments []Segment
+	for i, point := range t.Points {
+		if len(segments) == 0 || segments[len(segments)-1].FixType != point.FixType {
+			segment := Segment{FixType: point.FixType}
+			if i > 0 {
+				segment.Points = append(segment.Points, t.Points[i-1])
+			}
+			segments = append(segments, segment)
+		}
+		last := &segments[len(segments)-1]
+		last.Points = append(last.Points, point)
+	}
+	return segments
+}
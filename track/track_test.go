@@ -0,0 +1,92 @@
+package track
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"go.einride.tech/reach/erbsim"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestRead(t *testing.T) {
+	points := readTestTrack(t)
+	assert.Equal(t, 5, len(points))
+	assert.Equal(t, erb.FixTypeSingle, points[0].FixType)
+	assert.Equal(t, erb.FixTypeRTK, points[len(points)-1].FixType)
+	assert.Assert(t, points[0].HasDOP)
+	assert.Equal(t, time.Second, points[1].Time.Sub(points[0].Time))
+}
+
+func TestTrack_Segments(t *testing.T) {
+	tr := Track{Points: readTestTrack(t)}
+	segments := tr.Segments()
+	assert.Equal(t, 3, len(segments))
+	assert.Equal(t, erb.FixTypeSingle, segments[0].FixType)
+	assert.Equal(t, 2, len(segments[0].Points))
+	assert.Equal(t, erb.FixTypeFloat, segments[1].FixType)
+	// segments after the first start with the last point of the previous segment
+	assert.Equal(t, 2, len(segments[1].Points))
+	assert.DeepEqual(t, segments[0].Points[1], segments[1].Points[0])
+	assert.Equal(t, erb.FixTypeRTK, segments[2].FixType)
+	assert.Equal(t, 3, len(segments[2].Points))
+}
+
+func TestWriteGPX(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NilError(t, WriteGPX(&buf, &Track{Name: "test", Points: readTestTrack(t)}))
+	golden.Assert(t, buf.String(), "track.gpx.golden")
+}
+
+func TestWriteKML(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NilError(t, WriteKML(&buf, &Track{Name: "test", Points: readTestTrack(t)}))
+	golden.Assert(t, buf.String(), "track.kml.golden")
+}
+
+func readTestTrack(t *testing.T) []Point {
+	t.Helper()
+	sim := erbsim.NewSimulator(erbsim.Config{
+		Interval: time.Second,
+		WeekGPS:  2059,
+		TimeGPS:  113968000,
+		Waypoints: []erbsim.Waypoint{
+			{
+				Time:                    0,
+				LatitudeDegrees:         57.7,
+				LongitudeDegrees:        12.7,
+				AltitudeEllipsoidMeters: 70,
+				FixType:                 erb.FixTypeNoFix,
+			},
+			{
+				Time:                    500 * time.Millisecond,
+				LatitudeDegrees:         57.7,
+				LongitudeDegrees:        12.7,
+				AltitudeEllipsoidMeters: 70,
+				FixType:                 erb.FixTypeSingle,
+			},
+			{
+				Time:                    2500 * time.Millisecond,
+				LatitudeDegrees:         57.7002,
+				LongitudeDegrees:        12.7002,
+				AltitudeEllipsoidMeters: 71,
+				FixType:                 erb.FixTypeFloat,
+			},
+			{
+				Time:                    3500 * time.Millisecond,
+				LatitudeDegrees:         57.7003,
+				LongitudeDegrees:        12.7003,
+				AltitudeEllipsoidMeters: 71,
+				FixType:                 erb.FixTypeRTK,
+			},
+		},
+		GeoidSeparationMeters: 35,
+	})
+	var buf bytes.Buffer
+	assert.NilError(t, sim.WriteEpochs(&buf, 6))
+	points, err := Read(erb.NewScanner(&buf))
+	assert.NilError(t, err)
+	return points
+}
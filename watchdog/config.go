@@ -0,0 +1,42 @@
+package watchdog
+
+import (
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// Config configures a Watchdog.
+//
+// Thresholds with a zero value are not checked.
+type Config struct {
+	// MaxIntervals are the maximum times between messages of each expected ID. IDs not in the map are not checked.
+	MaxIntervals map[erb.ID]time.Duration
+	// MaxEpochGap is the maximum time between consecutive navigation epochs, measured by their TimeGPS.
+	MaxEpochGap time.Duration
+	// OnEvent is an optional callback invoked with each event of the watchdog.
+	//
+	// The callback must not call methods of the watchdog.
+	OnEvent func(Event)
+	// Now is an optional function returning the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// DefaultMaxIntervals returns maximum intervals for a Reach sending all messages at the provided update interval.
+//
+// A message is considered stalled when three consecutive messages have been missed.
+func DefaultMaxIntervals(interval time.Duration) map[erb.ID]time.Duration {
+	return map[erb.ID]time.Duration{
+		erb.IDPOS:  3 * interval,
+		erb.IDSTAT: 3 * interval,
+		erb.IDDOPS: 3 * interval,
+		erb.IDVEL:  3 * interval,
+		erb.IDSVI:  3 * interval,
+	}
+}
+
+func (c *Config) setDefaults() {
+	if c.Now == nil {
+		c.Now = time.Now
+	}
+}
@@ -0,0 +1,3 @@
+// Package watchdog provides a stream health watchdog, that detects when an ERB stream from a Reach drifts from an
+// expected profile of message rates and timestamps.
+package watchdog
@@ -0,0 +1,19 @@
+package watchdog
+
+// EventType represents the type of a watchdog event.
+type EventType uint8
+
+//go:generate stringer -type EventType -trimprefix EventType
+
+const (
+	// EventTypeStalled is the event when no message of an expected ID has been received within its maximum interval.
+	EventTypeStalled EventType = iota
+	// EventTypeResumed is the event when a message of a stalled ID is received again.
+	EventTypeResumed
+	// EventTypeEpochGap is the event when the time between consecutive navigation epochs is too large.
+	EventTypeEpochGap
+	// EventTypeTimeBackwards is the event when the TimeGPS of a navigation epoch is before the previous epoch.
+	EventTypeTimeBackwards
+	// EventTypeDuplicateEpoch is the event when a message is received twice for the same navigation epoch.
+	EventTypeDuplicateEpoch
+)
@@ -0,0 +1,268 @@
+package watchdog
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.einride.tech/reach/erb"
+)
+
+// rateSmoothing is the weight of each new message interval in the smoothed message rate.
+const rateSmoothing = 0.2
+
+// Event is an event of a Watchdog.
+type Event struct {
+	// Type of the event.
+	Type EventType
+	// ID of the message that caused the event.
+	ID erb.ID
+	// Time is the time at which the event was detected.
+	Time time.Time
+	// TimeGPS is the time of week in milliseconds of the navigation epoch that caused the event.
+	TimeGPS uint32
+	// PreviousTimeGPS is the time of week in milliseconds of the previous navigation epoch.
+	PreviousTimeGPS uint32
+	// Interval is the duration of the stall, outage, gap or backwards jump.
+	Interval time.Duration
+}
+
+// MessageStats are the statistics of the messages with an ID.
+type MessageStats struct {
+	// Count is the number of received messages.
+	Count uint64
+	// LastReceived is the time at which the last message was received.
+	LastReceived time.Time
+	// Rate is the smoothed rate of messages per second.
+	Rate float64
+	// Stalled is true when no message has been received within the maximum interval.
+	Stalled bool
+}
+
+// Stats are the statistics of a Watchdog.
+type Stats struct {
+	// Messages are the statistics of each received or expected message ID.
+	Messages map[erb.ID]MessageStats
+	// Epochs is the number of navigation epochs.
+	Epochs uint64
+	// Stalls is the number of times a message ID has stalled.
+	Stalls uint64
+	// EpochGaps is the number of too large gaps between navigation epochs.
+	EpochGaps uint64
+	// TimeBackwards is the number of times the TimeGPS of a navigation epoch jumped backwards.
+	TimeBackwards uint64
+	// DuplicateEpochs is the number of messages received twice for the same navigation epoch.
+	DuplicateEpochs uint64
+}
+
+type messageState struct {
+	stats      MessageStats
+	interval   time.Duration
+	timeGPS    uint32
+	hasTimeGPS bool
+}
+
+// Watchdog monitors the health of an ERB stream.
+//
+// The watchdog tracks the rate of each message ID, the gaps between navigation epochs, the monotonicity of TimeGPS and
+// duplicate epochs. Late messages of the previous epoch are ignored when tracking epochs. Stalls are detected when
+// messages are received, and when Check is called, which allows detecting stalls while other messages keep arriving
+// as well as when the stream is silent.
+//
+// Update and Check may be called from different goroutines.
+type Watchdog struct {
+	mu       sync.Mutex
+	cfg      Config
+	ids      []erb.ID
+	start    time.Time
+	messages map[erb.ID]*messageState
+	stats    Stats
+	timeGPS  uint32
+	hasEpoch bool
+	// previousTimeGPS is the time of week of the previous navigation epoch, for ignoring late messages.
+	previousTimeGPS  uint32
+	hasPreviousEpoch bool
+}
+
+// NewWatchdog returns a new Watchdog with the provided config.
+//
+// Expected messages that have not been received are considered stalled after their maximum interval from the creation
+// of the watchdog.
+func NewWatchdog(cfg Config) *Watchdog {
+	cfg.setDefaults()
+	ids := make([]erb.ID, 0, len(cfg.MaxIntervals))
+	for id := range cfg.MaxIntervals {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return &Watchdog{
+		cfg:      cfg,
+		ids:      ids,
+		start:    cfg.Now(),
+		messages: map[erb.ID]*messageState{},
+	}
+}
+
+// Stats returns the current statistics of the watchdog.
+func (w *Watchdog) Stats() Stats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	stats := w.stats
+	stats.Messages = make(map[erb.ID]MessageStats, len(w.messages))
+	for id, state := range w.messages {
+		stats.Messages[id] = state.stats
+	}
+	return stats
+}
+
+// Update the watchdog with the current message of the scanner.
+func (w *Watchdog) Update(sc *erb.Scanner) {
+	timeGPS, hasTimeGPS := sc.TimeGPS()
+	w.mu.Lock()
+	now := w.cfg.Now()
+	events := w.update(now, sc.ID(), timeGPS, hasTimeGPS)
+	events = w.checkStalls(now, events)
+	w.mu.Unlock()
+	w.emit(events)
+}
+
+// Check the watchdog for stalled messages.
+//
+// Check should be called periodically, to detect stalls when no messages are received.
+func (w *Watchdog) Check() {
+	w.mu.Lock()
+	events := w.checkStalls(w.cfg.Now(), nil)
+	w.mu.Unlock()
+	w.emit(events)
+}
+
+func (w *Watchdog) update(now time.Time, id erb.ID, timeGPS uint32, hasTimeGPS bool) []Event {
+	var events []Event
+	state := w.message(id)
+	if state.stats.Count > 0 {
+		interval := now.Sub(state.stats.LastReceived)
+		if state.interval == 0 {
+			state.interval = interval
+		} else {
+			state.interval += time.Duration(rateSmoothing * float64(interval-state.interval))
+		}
+		if state.interval > 0 {
+			state.stats.Rate = 1 / state.interval.Seconds()
+		}
+	}
+	if state.stats.Stalled {
+		state.stats.Stalled = false
+		events = append(events, Event{
+			Type:     EventTypeResumed,
+			ID:       id,
+			Time:     now,
+			TimeGPS:  timeGPS,
+			Interval: now.Sub(w.lastReceived(state)),
+		})
+	}
+	state.stats.Count++
+	state.stats.LastReceived = now
+	if !hasTimeGPS {
+		return events
+	}
+	if state.hasTimeGPS && state.timeGPS == timeGPS {
+		w.stats.DuplicateEpochs++
+		events = append(events, Event{
+			Type:            EventTypeDuplicateEpoch,
+			ID:              id,
+			Time:            now,
+			TimeGPS:         timeGPS,
+			PreviousTimeGPS: w.timeGPS,
+		})
+	}
+	state.timeGPS, state.hasTimeGPS = timeGPS, true
+	if !w.hasEpoch {
+		w.timeGPS, w.hasEpoch = timeGPS, true
+		w.stats.Epochs++
+		return events
+	}
+	if timeGPS == w.timeGPS {
+		return events
+	}
+	if w.hasPreviousEpoch && timeGPS == w.previousTimeGPS {
+		// a late message of the previous epoch
+		return events
+	}
+	elapsed := erb.ElapsedGPS(w.timeGPS, timeGPS)
+	switch {
+	case elapsed < 0:
+		w.stats.TimeBackwards++
+		events = append(events, Event{
+			Type:            EventTypeTimeBackwards,
+			ID:              id,
+			Time:            now,
+			TimeGPS:         timeGPS,
+			PreviousTimeGPS: w.timeGPS,
+			Interval:        -elapsed,
+		})
+	case w.cfg.MaxEpochGap > 0 && elapsed > w.cfg.MaxEpochGap:
+		w.stats.EpochGaps++
+		events = append(events, Event{
+			Type:            EventTypeEpochGap,
+			ID:              id,
+			Time:            now,
+			TimeGPS:         timeGPS,
+			PreviousTimeGPS: w.timeGPS,
+			Interval:        elapsed,
+		})
+	}
+	w.stats.Epochs++
+	w.previousTimeGPS, w.hasPreviousEpoch = w.timeGPS, true
+	w.timeGPS = timeGPS
+	return events
+}
+
+func (w *Watchdog) checkStalls(now time.Time, events []Event) []Event {
+	for _, id := range w.ids {
+		state := w.message(id)
+		if state.stats.Stalled {
+			continue
+		}
+		if interval := now.Sub(w.lastReceived(state)); interval > w.cfg.MaxIntervals[id] {
+			state.stats.Stalled = true
+			w.stats.Stalls++
+			events = append(events, Event{
+				Type:            EventTypeStalled,
+				ID:              id,
+				Time:            now,
+				TimeGPS:         w.timeGPS,
+				PreviousTimeGPS: state.timeGPS,
+				Interval:        interval,
+			})
+		}
+	}
+	return events
+}
+
+func (w *Watchdog) message(id erb.ID) *messageState {
+	state, ok := w.messages[id]
+	if !ok {
+		state = &messageState{}
+		w.messages[id] = state
+	}
+	return state
+}
+
+// lastReceived returns the time of the last message, or the creation of the watchdog if no message was received.
+func (w *Watchdog) lastReceived(state *messageState) time.Time {
+	if state.stats.Count == 0 {
+		return w.start
+	}
+	return state.stats.LastReceived
+}
+
+func (w *Watchdog) emit(events []Event) {
+	if w.cfg.OnEvent == nil {
+		return
+	}
+	for _, event := range events {
+		w.cfg.OnEvent(event)
+	}
+}
@@ -0,0 +1,220 @@
+package watchdog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"go.einride.tech/reach/erb"
+	"gotest.tools/v3/assert"
+)
+
+type testMessage struct {
+	// elapsed is the time since the start of the test at which the message is received.
+	elapsed time.Duration
+	id      erb.ID
+	timeGPS uint32
+}
+
+func encodeTestMessages(t *testing.T, messages []testMessage) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := erb.NewEncoder(&buf)
+	for _, m := range messages {
+		switch m.id {
+		case erb.IDPOS:
+			assert.NilError(t, enc.EncodePOS(erb.POS{TimeGPS: m.timeGPS}))
+		case erb.IDSVI:
+			assert.NilError(t, enc.EncodeSVI(erb.SVI{TimeGPS: m.timeGPS}, nil))
+		default:
+			t.Fatalf("unsupported test message: %v", m.id)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestWatchdog(t *testing.T) {
+	start := time.Unix(0, 0).UTC()
+	for _, tt := range []struct {
+		name           string
+		cfg            Config
+		messages       []testMessage
+		check          time.Duration
+		expectedEvents []Event
+		expectedStats  Stats
+	}{
+		{
+			name: "POS stalled while SVI continues",
+			cfg:  Config{MaxIntervals: map[erb.ID]time.Duration{erb.IDPOS: 300 * time.Millisecond}},
+			messages: []testMessage{
+				{elapsed: 0, id: erb.IDPOS, timeGPS: 1000},
+				{elapsed: 0, id: erb.IDSVI, timeGPS: 1000},
+				{elapsed: 100 * time.Millisecond, id: erb.IDSVI, timeGPS: 1100},
+				{elapsed: 200 * time.Millisecond, id: erb.IDSVI, timeGPS: 1200},
+				{elapsed: 400 * time.Millisecond, id: erb.IDSVI, timeGPS: 1400},
+				{elapsed: 500 * time.Millisecond, id: erb.IDPOS, timeGPS: 1500},
+			},
+			expectedEvents: []Event{
+				{
+					Type:            EventTypeStalled,
+					ID:              erb.IDPOS,
+					Time:            start.Add(400 * time.Millisecond),
+					TimeGPS:         1400,
+					PreviousTimeGPS: 1000,
+					Interval:        400 * time.Millisecond,
+				},
+				{
+					Type:     EventTypeResumed,
+					ID:       erb.IDPOS,
+					Time:     start.Add(500 * time.Millisecond),
+					TimeGPS:  1500,
+					Interval: 500 * time.Millisecond,
+				},
+			},
+			expectedStats: Stats{
+				Messages: map[erb.ID]MessageStats{
+					erb.IDPOS: {Count: 2, LastReceived: start.Add(500 * time.Millisecond), Rate: 2},
+					erb.IDSVI: {Count: 4, LastReceived: start.Add(400 * time.Millisecond), Rate: 10 / 1.2},
+				},
+				Epochs: 5,
+				Stalls: 1,
+			},
+		},
+		{
+			name: "stalled when silent",
+			cfg:  Config{MaxIntervals: map[erb.ID]time.Duration{erb.IDPOS: 300 * time.Millisecond}},
+			messages: []testMessage{
+				{elapsed: 0, id: erb.IDPOS, timeGPS: 1000},
+			},
+			check: time.Second,
+			expectedEvents: []Event{
+				{
+					Type:            EventTypeStalled,
+					ID:              erb.IDPOS,
+					Time:            start.Add(time.Second),
+					TimeGPS:         1000,
+					PreviousTimeGPS: 1000,
+					Interval:        time.Second,
+				},
+			},
+			expectedStats: Stats{
+				Messages: map[erb.ID]MessageStats{
+					erb.IDPOS: {Count: 1, LastReceived: start, Stalled: true},
+				},
+				Epochs: 1,
+				Stalls: 1,
+			},
+		},
+		{
+			name: "epoch gap, backwards and duplicate",
+			cfg:  Config{MaxEpochGap: 150 * time.Millisecond},
+			messages: []testMessage{
+				{elapsed: 0, id: erb.IDPOS, timeGPS: 1000},
+				{elapsed: 100 * time.Millisecond, id: erb.IDPOS, timeGPS: 1300},
+				{elapsed: 200 * time.Millisecond, id: erb.IDPOS, timeGPS: 1200},
+				{elapsed: 300 * time.Millisecond, id: erb.IDPOS, timeGPS: 1200},
+			},
+			expectedEvents: []Event{
+				{
+					Type:            EventTypeEpochGap,
+					ID:              erb.IDPOS,
+					Time:            start.Add(100 * time.Millisecond),
+					TimeGPS:         1300,
+					PreviousTimeGPS: 1000,
+					Interval:        300 * time.Millisecond,
+				},
+				{
+					Type:            EventTypeTimeBackwards,
+					ID:              erb.IDPOS,
+					Time:            start.Add(200 * time.Millisecond),
+					TimeGPS:         1200,
+					PreviousTimeGPS: 1300,
+					Interval:        100 * time.Millisecond,
+				},
+				{
+					Type:            EventTypeDuplicateEpoch,
+					ID:              erb.IDPOS,
+					Time:            start.Add(300 * time.Millisecond),
+					TimeGPS:         1200,
+					PreviousTimeGPS: 1200,
+				},
+			},
+			expectedStats: Stats{
+				Messages: map[erb.ID]MessageStats{
+					erb.IDPOS: {Count: 4, LastReceived: start.Add(300 * time.Millisecond), Rate: 10},
+				},
+				Epochs:          3,
+				EpochGaps:       1,
+				TimeBackwards:   1,
+				DuplicateEpochs: 1,
+			},
+		},
+		{
+			name: "late message of previous epoch",
+			cfg:  Config{MaxEpochGap: 250 * time.Millisecond},
+			messages: []testMessage{
+				{elapsed: 0, id: erb.IDPOS, timeGPS: 1000},
+				{elapsed: 200 * time.Millisecond, id: erb.IDPOS, timeGPS: 1200},
+				{elapsed: 210 * time.Millisecond, id: erb.IDSVI, timeGPS: 1000},
+				{elapsed: 220 * time.Millisecond, id: erb.IDSVI, timeGPS: 1200},
+				{elapsed: 400 * time.Millisecond, id: erb.IDPOS, timeGPS: 1400},
+			},
+			expectedStats: Stats{
+				Messages: map[erb.ID]MessageStats{
+					erb.IDPOS: {Count: 3, LastReceived: start.Add(400 * time.Millisecond), Rate: 5},
+					erb.IDSVI: {Count: 2, LastReceived: start.Add(220 * time.Millisecond), Rate: 100},
+				},
+				Epochs: 3,
+			},
+		},
+		{
+			name: "week rollover",
+			cfg:  Config{MaxEpochGap: 150 * time.Millisecond},
+			messages: []testMessage{
+				{elapsed: 0, id: erb.IDPOS, timeGPS: erb.MillisecondsPerWeek - 100},
+				{elapsed: 100 * time.Millisecond, id: erb.IDPOS, timeGPS: 0},
+			},
+			expectedStats: Stats{
+				Messages: map[erb.ID]MessageStats{
+					erb.IDPOS: {Count: 2, LastReceived: start.Add(100 * time.Millisecond), Rate: 10},
+				},
+				Epochs: 2,
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var events []Event
+			now := start
+			cfg := tt.cfg
+			cfg.Now = func() time.Time {
+				return now
+			}
+			cfg.OnEvent = func(event Event) {
+				events = append(events, event)
+			}
+			w := NewWatchdog(cfg)
+			sc := erb.NewScanner(bytes.NewReader(encodeTestMessages(t, tt.messages)))
+			for _, m := range tt.messages {
+				assert.Assert(t, sc.Scan())
+				now = start.Add(m.elapsed)
+				w.Update(sc)
+			}
+			if tt.check > 0 {
+				now = start.Add(tt.check)
+				w.Check()
+			}
+			assert.DeepEqual(t, tt.expectedEvents, events)
+			stats := w.Stats()
+			for id, expected := range tt.expectedStats.Messages {
+				actual := stats.Messages[id]
+				assert.Equal(t, expected.Count, actual.Count, id)
+				assert.Equal(t, expected.LastReceived, actual.LastReceived, id)
+				assert.Equal(t, expected.Stalled, actual.Stalled, id)
+				assert.Assert(t, actual.Rate > expected.Rate-0.01 && actual.Rate < expected.Rate+0.01, id)
+			}
+			stats.Messages, tt.expectedStats.Messages = nil, nil
+			assert.DeepEqual(t, tt.expectedStats, stats)
+		})
+	}
+}